@@ -0,0 +1,18 @@
+/*
+ * Watchman Admin API
+ *
+ * Watchman is an HTTP API and Go library to download, parse and offer search functions over numerous trade sanction lists from the United States, European Union governments, agencies, and non profits for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package admin
+
+// BundleFile struct for BundleFile
+type BundleFile struct {
+	Name string `json:"name,omitempty"`
+	// SHA-256 checksum of the file
+	Sha256 string `json:"sha256,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+}
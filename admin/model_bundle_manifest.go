@@ -0,0 +1,27 @@
+/*
+ * Watchman Admin API
+ *
+ * Watchman is an HTTP API and Go library to download, parse and offer search functions over numerous trade sanction lists from the United States, European Union governments, agencies, and non profits for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package admin
+
+import (
+	"time"
+)
+
+// BundleManifest struct for BundleManifest
+type BundleManifest struct {
+	// Format version of the bundle
+	Version int32 `json:"version,omitempty"`
+	// Bundle ID, from when it was created
+	Id        string    `json:"id,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// Version of Watchman which created the bundle
+	WatchmanVersion string `json:"watchmanVersion,omitempty"`
+	// Files in the bundle for each list keyed by list name
+	Lists map[string]BundleList `json:"lists,omitempty"`
+}
@@ -26,6 +26,10 @@ type DataRefresh struct {
 	// Count of BSL denied persons after index
 	DeniedPersons int32 `json:"deniedPersons,omitempty"`
 	// Count of BIS entities after index
-	BisEntities int32     `json:"bisEntities,omitempty"`
-	Timestamp   time.Time `json:"timestamp,omitempty"`
+	BisEntities int32 `json:"bisEntities,omitempty"`
+	// Quality report of each list keyed by list name
+	Quality map[string]ListQuality `json:"quality,omitempty"`
+	// When each list was last refreshed successfully keyed by list name
+	Freshness map[string]ListFreshness `json:"freshness,omitempty"`
+	Timestamp time.Time                `json:"timestamp,omitempty"`
 }
@@ -0,0 +1,22 @@
+/*
+ * Watchman Admin API
+ *
+ * Watchman is an HTTP API and Go library to download, parse and offer search functions over numerous trade sanction lists from the United States, European Union governments, agencies, and non profits for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package admin
+
+import (
+	"time"
+)
+
+// ListFreshness When a list's data was last refreshed successfully
+type ListFreshness struct {
+	// When the list was last refreshed successfully, zero if it has never loaded
+	RefreshedAt time.Time `json:"refreshedAt,omitempty"`
+	// Why the most recent refresh of the list failed, in which case its previous data is still served
+	Error string `json:"error,omitempty"`
+}
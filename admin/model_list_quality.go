@@ -0,0 +1,28 @@
+/*
+ * Watchman Admin API
+ *
+ * Watchman is an HTTP API and Go library to download, parse and offer search functions over numerous trade sanction lists from the United States, European Union governments, agencies, and non profits for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package admin
+
+// ListQuality struct for ListQuality
+type ListQuality struct {
+	// Number of records read from the list's file(s)
+	RowsRead int32 `json:"rowsRead,omitempty"`
+	// Number of rows and records left out of the search index
+	RowsSkipped int32 `json:"rowsSkipped,omitempty"`
+	// Count of skipped rows by reason
+	SkipReasons map[string]int32 `json:"skipReasons,omitempty"`
+	// Share (0-1) of records with each field empty
+	EmptyFields map[string]float64 `json:"emptyFields,omitempty"`
+	// Fields which are empty far more often than in the previous refresh
+	UnexpectedlyEmpty []string `json:"unexpectedlyEmpty,omitempty"`
+	// Columns of the file's header which no longer match the expected columns
+	HeaderDrift []string `json:"headerDrift,omitempty"`
+	// Why the list couldn't be downloaded or read, in which case its previous data was kept
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Watchman Admin API
+ *
+ * Watchman is an HTTP API and Go library to download, parse and offer search functions over numerous trade sanction lists from the United States, European Union governments, agencies, and non profits for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package admin
+
+// SnapshotFile struct for SnapshotFile
+type SnapshotFile struct {
+	Name string `json:"name,omitempty"`
+	// SHA-256 digest the file is stored under
+	Digest string `json:"digest,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+}
@@ -0,0 +1,23 @@
+/*
+ * Watchman Admin API
+ *
+ * Watchman is an HTTP API and Go library to download, parse and offer search functions over numerous trade sanction lists from the United States, European Union governments, agencies, and non profits for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package admin
+
+import (
+	"time"
+)
+
+// SnapshotList struct for SnapshotList
+type SnapshotList struct {
+	// When the list's files were downloaded. Lists which failed to refresh carry their files forward from an older snapshot.
+	RefreshedAt time.Time `json:"refreshedAt,omitempty"`
+	// Raw files the list was read from
+	Files  []SnapshotFile `json:"files,omitempty"`
+	Parsed SnapshotFile   `json:"parsed,omitempty"`
+}
@@ -0,0 +1,25 @@
+/*
+ * Watchman Admin API
+ *
+ * Watchman is an HTTP API and Go library to download, parse and offer search functions over numerous trade sanction lists from the United States, European Union governments, agencies, and non profits for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package admin
+
+import (
+	"time"
+)
+
+// SnapshotSummary struct for SnapshotSummary
+type SnapshotSummary struct {
+	// Snapshot ID, from when it was created
+	Id        string    `json:"id,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// Total size in bytes of the files the snapshot references
+	Size int64 `json:"size,omitempty"`
+	// Files stored for each list keyed by list name
+	Lists map[string]SnapshotListSummary `json:"lists,omitempty"`
+}
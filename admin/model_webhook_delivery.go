@@ -0,0 +1,39 @@
+/*
+ * Watchman Admin API
+ *
+ * Watchman is an HTTP API and Go library to download, parse and offer search functions over numerous trade sanction lists from the United States, European Union governments, agencies, and non profits for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package admin
+
+import (
+	"time"
+)
+
+// WebhookDelivery struct for WebhookDelivery
+type WebhookDelivery struct {
+	Id string `json:"id,omitempty"`
+	// Only set for watch deliveries
+	WatchID string `json:"watchID,omitempty"`
+	// Only set for download subscriber deliveries
+	SubscriberID string `json:"subscriberID,omitempty"`
+	// How the delivery is sent
+	Notifier string `json:"notifier,omitempty"`
+	// Only set for deliveries sent to a webhook
+	Webhook string `json:"webhook,omitempty"`
+	// JSON body sent to the webhook, only included when getting a single delivery
+	Body map[string]interface{} `json:"body,omitempty"`
+	// Pending deliveries are attempted again at nextAttemptAt. Dead deliveries ran out of attempts or can't succeed and are kept until replayed.
+	Status        string    `json:"status,omitempty"`
+	Attempts      int32     `json:"attempts,omitempty"`
+	NextAttemptAt time.Time `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt time.Time `json:"lastAttemptAt,omitempty"`
+	// HTTP status code from the last attempt, if a response was received
+	LastStatusCode int32     `json:"lastStatusCode,omitempty"`
+	LastError      string    `json:"lastError,omitempty"`
+	CreatedAt      time.Time `json:"createdAt,omitempty"`
+	UpdatedAt      time.Time `json:"updatedAt,omitempty"`
+}
@@ -0,0 +1,28 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// AlertAuditEntry Change made to an alert
+type AlertAuditEntry struct {
+	EntryID string `json:"entryID,omitempty"`
+	AlertID string `json:"alertID,omitempty"`
+	// User who made the change. Alerts raised by monitoring have no actor.
+	Actor  string `json:"actor,omitempty"`
+	Action string `json:"action,omitempty"`
+	// Status or assignee before the change
+	Previous string `json:"previous,omitempty"`
+	// Status or assignee after the change, or the commentID of comments
+	Value     string    `json:"value,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
@@ -0,0 +1,23 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// AlertComment Analyst's comment on an alert
+type AlertComment struct {
+	CommentID string    `json:"commentID,omitempty"`
+	AlertID   string    `json:"alertID,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
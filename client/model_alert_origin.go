@@ -0,0 +1,19 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// AlertOrigin What raised an alert
+type AlertOrigin string
+
+// List of AlertOrigin
+const (
+	ALERTORIGIN_MONITORING AlertOrigin = "monitoring"
+	ALERTORIGIN_SEARCH     AlertOrigin = "search"
+)
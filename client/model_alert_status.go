@@ -0,0 +1,23 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// AlertStatus Where an alert is in an analyst's review
+type AlertStatus string
+
+// List of AlertStatus
+const (
+	ALERTSTATUS_OPEN           AlertStatus = "open"
+	ALERTSTATUS_IN_REVIEW      AlertStatus = "in_review"
+	ALERTSTATUS_ESCALATED      AlertStatus = "escalated"
+	ALERTSTATUS_TRUE_POSITIVE  AlertStatus = "true_positive"
+	ALERTSTATUS_FALSE_POSITIVE AlertStatus = "false_positive"
+	ALERTSTATUS_CLOSED         AlertStatus = "closed"
+)
@@ -28,7 +28,12 @@ type BisEntities struct {
 	// The link to the official SSI list
 	SourceListURL string `json:"sourceListURL,omitempty"`
 	// The link for information regarding the source
-	SourceInfoURL string `json:"sourceInfoURL,omitempty"`
+	SourceInfoURL   string              `json:"sourceInfoURL,omitempty"`
+	Citizenships    []string            `json:"citizenships,omitempty"`
+	DatesOfBirth    []string            `json:"datesOfBirth,omitempty"`
+	Nationalities   []string            `json:"nationalities,omitempty"`
+	PlacesOfBirth   []string            `json:"placesOfBirth,omitempty"`
+	Identifications []CslIdentification `json:"identifications,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
@@ -0,0 +1,17 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// ChangeSummary Counts of the entities which changed on a list
+type ChangeSummary struct {
+	Added    int32 `json:"added,omitempty"`
+	Removed  int32 `json:"removed,omitempty"`
+	Modified int32 `json:"modified,omitempty"`
+}
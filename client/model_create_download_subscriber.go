@@ -0,0 +1,20 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// CreateDownloadSubscriber Webhook to call after data refreshes
+type CreateDownloadSubscriber struct {
+	// HTTPS url to call after data refreshes
+	Webhook string `json:"webhook"`
+	// Optional Authorization header sent with the subscriber's webhooks
+	AuthToken string `json:"authToken,omitempty"`
+	// Only call the webhook when one of these lists changed or failed to refresh
+	Lists []string `json:"lists,omitempty"`
+}
@@ -0,0 +1,17 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// CslIdentification Form of identification from the US Consolidated Screening List
+type CslIdentification struct {
+	Country string `json:"country,omitempty"`
+	Number  string `json:"number,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
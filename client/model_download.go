@@ -15,11 +15,17 @@ import (
 
 // Download Metadata and stats about downloaded OFAC data
 type Download struct {
-	SDNs              int32     `json:"SDNs,omitempty"`
-	AltNames          int32     `json:"altNames,omitempty"`
-	Addresses         int32     `json:"addresses,omitempty"`
-	SectoralSanctions int32     `json:"sectoralSanctions,omitempty"`
-	DeniedPersons     int32     `json:"deniedPersons,omitempty"`
-	BisEntities       int32     `json:"bisEntities,omitempty"`
-	Timestamp         time.Time `json:"timestamp,omitempty"`
+	// Download ID, used to read the changes of each list
+	Id                string `json:"id,omitempty"`
+	SDNs              int32  `json:"SDNs,omitempty"`
+	AltNames          int32  `json:"altNames,omitempty"`
+	Addresses         int32  `json:"addresses,omitempty"`
+	SectoralSanctions int32  `json:"sectoralSanctions,omitempty"`
+	DeniedPersons     int32  `json:"deniedPersons,omitempty"`
+	BisEntities       int32  `json:"bisEntities,omitempty"`
+	// When each list was last refreshed successfully keyed by list name
+	Freshness map[string]ListFreshness `json:"freshness,omitempty"`
+	// Counts of the entities added, removed or modified on each list since the previous download keyed by list name. Only lists with stable IDs (SDNs, EUCSL, UKCSL and UKSanctionsList) are compared.
+	Changes   map[string]ChangeSummary `json:"changes,omitempty"`
+	Timestamp time.Time                `json:"timestamp,omitempty"`
 }
@@ -0,0 +1,17 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// DownloadChanges Entities which changed on each list during a download
+type DownloadChanges struct {
+	DownloadID string `json:"downloadID,omitempty"`
+	// Changes keyed by list name
+	Lists map[string]ListChanges `json:"lists,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// DownloadListDetail How a list fared in a refresh
+type DownloadListDetail struct {
+	// Entities on the list after the refresh
+	Entities int32         `json:"entities,omitempty"`
+	Changes  ChangeSummary `json:"changes,omitempty"`
+	// Why the list failed to refresh, in which case its previous data is still served
+	Error string `json:"error,omitempty"`
+}
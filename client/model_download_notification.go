@@ -0,0 +1,24 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// DownloadNotification Body of the webhooks sent to download subscribers
+type DownloadNotification struct {
+	SubscriberID string `json:"subscriberID,omitempty"`
+	// Download ID, used to read the changes of each list
+	DownloadID string `json:"downloadID,omitempty"`
+	// How each list the subscriber selected (or every list) fared, keyed by list name
+	Lists     map[string]DownloadListDetail `json:"lists,omitempty"`
+	Timestamp time.Time                     `json:"timestamp,omitempty"`
+}
@@ -0,0 +1,26 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// DownloadSubscriber Webhook called after data refreshes
+type DownloadSubscriber struct {
+	SubscriberID string `json:"subscriberID,omitempty"`
+	// HTTPS url the subscriber's webhooks are sent to
+	Webhook string `json:"webhook,omitempty"`
+	// Lists the subscriber is notified of. The subscriber is only called when one of them changed or failed to refresh. Subscribers without lists are called after every refresh.
+	Lists     []string  `json:"lists,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// Secret the subscriber's webhooks are signed with. It's only returned when the subscriber is created.
+	SigningSecret string `json:"signingSecret,omitempty"`
+}
@@ -0,0 +1,23 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// DownloadSubscriberSigningSecret Secret a download subscriber's webhooks are signed with
+type DownloadSubscriberSigningSecret struct {
+	SubscriberID string `json:"subscriberID,omitempty"`
+	// HMAC-SHA256 key for verifying the X-Watchman-Signature header
+	SigningSecret string `json:"signingSecret,omitempty"`
+	// When the replaced secret stops signing webhooks
+	PreviousExpiresAt time.Time `json:"previousExpiresAt,omitempty"`
+}
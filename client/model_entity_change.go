@@ -0,0 +1,17 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// EntityChange An entity which changed, identified by its ID on the list
+type EntityChange struct {
+	// Stable ID of the entity, e.g. an SDN's EntityID
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
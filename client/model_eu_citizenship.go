@@ -0,0 +1,19 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// EuCitizenship Citizenship listed for an entity on the EU Consolidated Sanctions List
+type EuCitizenship struct {
+	LogicalId          int32  `json:"logicalId,omitempty"`
+	Region             string `json:"region,omitempty"`
+	CountryIso2Code    string `json:"countryIso2Code,omitempty"`
+	CountryDescription string `json:"countryDescription,omitempty"`
+	Remark             string `json:"remark,omitempty"`
+}
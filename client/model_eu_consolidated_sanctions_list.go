@@ -28,6 +28,12 @@ type EuConsolidatedSanctionsList struct {
 	BirthCities                []string               `json:"birthCities,omitempty"`
 	BirthCountries             []string               `json:"birthCountries,omitempty"`
 	ValidFromTo                map[string]interface{} `json:"validFromTo,omitempty"`
+	EntityUnitedNationId       string                 `json:"entityUnitedNationId,omitempty"`
+	EntityDesignationDate      string                 `json:"entityDesignationDate,omitempty"`
+	EntityDesignationDetails   string                 `json:"entityDesignationDetails,omitempty"`
+	EntityRegulation           EuRegulation           `json:"entityRegulation,omitempty"`
+	Identifications            []EuIdentification     `json:"identifications,omitempty"`
+	Citizenships               []EuCitizenship        `json:"citizenships,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
@@ -0,0 +1,32 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// EuIdentification Identity document listed for an entity on the EU Consolidated Sanctions List
+type EuIdentification struct {
+	LogicalId          int32  `json:"logicalId,omitempty"`
+	Number             string `json:"number,omitempty"`
+	KnownExpired       bool   `json:"knownExpired,omitempty"`
+	KnownFalse         bool   `json:"knownFalse,omitempty"`
+	ReportedLost       bool   `json:"reportedLost,omitempty"`
+	RevokedByIssuer    bool   `json:"revokedByIssuer,omitempty"`
+	Diplomatic         bool   `json:"diplomatic,omitempty"`
+	IssuedBy           string `json:"issuedBy,omitempty"`
+	IssuedDate         string `json:"issuedDate,omitempty"`
+	ValidFrom          string `json:"validFrom,omitempty"`
+	ValidTo            string `json:"validTo,omitempty"`
+	NameOnDocument     string `json:"nameOnDocument,omitempty"`
+	TypeCode           string `json:"typeCode,omitempty"`
+	TypeDescription    string `json:"typeDescription,omitempty"`
+	Region             string `json:"region,omitempty"`
+	CountryIso2Code    string `json:"countryIso2Code,omitempty"`
+	CountryDescription string `json:"countryDescription,omitempty"`
+	Remark             string `json:"remark,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// EuRegulation Regulation an entity was added to the EU Consolidated Sanctions List under
+type EuRegulation struct {
+	Type               string `json:"type,omitempty"`
+	OrganisationType   string `json:"organisationType,omitempty"`
+	PublicationDate    string `json:"publicationDate,omitempty"`
+	EntryIntoForceDate string `json:"entryIntoForceDate,omitempty"`
+	NumberTitle        string `json:"numberTitle,omitempty"`
+	Programme          string `json:"programme,omitempty"`
+	PublicationURL     string `json:"publicationURL,omitempty"`
+}
@@ -11,17 +11,20 @@ package client
 
 // ForeignSanctionsEvader struct for ForeignSanctionsEvader
 type ForeignSanctionsEvader struct {
-	EntityID      string   `json:"entityID,omitempty"`
-	EntityNumber  string   `json:"entityNumber,omitempty"`
-	Type          string   `json:"type,omitempty"`
-	Programs      []string `json:"programs,omitempty"`
-	Name          string   `json:"name,omitempty"`
-	Addresses     []string `json:"addresses,omitempty"`
-	SourceListURL string   `json:"sourceListURL,omitempty"`
-	Citizenships  string   `json:"citizenships,omitempty"`
-	DatesOfBirth  string   `json:"datesOfBirth,omitempty"`
-	SourceInfoURL string   `json:"sourceInfoURL,omitempty"`
-	IDs           []string `json:"IDs,omitempty"`
+	EntityID        string              `json:"entityID,omitempty"`
+	EntityNumber    string              `json:"entityNumber,omitempty"`
+	Type            string              `json:"type,omitempty"`
+	Programs        []string            `json:"programs,omitempty"`
+	Name            string              `json:"name,omitempty"`
+	Addresses       []string            `json:"addresses,omitempty"`
+	SourceListURL   string              `json:"sourceListURL,omitempty"`
+	Citizenships    []string            `json:"citizenships,omitempty"`
+	DatesOfBirth    []string            `json:"datesOfBirth,omitempty"`
+	SourceInfoURL   string              `json:"sourceInfoURL,omitempty"`
+	IDs             []string            `json:"IDs,omitempty"`
+	Nationalities   []string            `json:"nationalities,omitempty"`
+	PlacesOfBirth   []string            `json:"placesOfBirth,omitempty"`
+	Identifications []CslIdentification `json:"identifications,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
@@ -0,0 +1,21 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// FtMEntity FollowTheMoney entity imported from the initial data directory
+type FtMEntity struct {
+	Id         string              `json:"id,omitempty"`
+	Schema     string              `json:"schema,omitempty"`
+	Caption    string              `json:"caption,omitempty"`
+	Properties map[string][]string `json:"properties,omitempty"`
+	Datasets   []string            `json:"datasets,omitempty"`
+	// Match percentage of search query
+	Match float32 `json:"match,omitempty"`
+}
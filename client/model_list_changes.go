@@ -0,0 +1,17 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// ListChanges Entities added, removed or modified on a list since the previous download
+type ListChanges struct {
+	Added    []EntityChange `json:"added,omitempty"`
+	Removed  []EntityChange `json:"removed,omitempty"`
+	Modified []EntityChange `json:"modified,omitempty"`
+}
@@ -0,0 +1,22 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// ListFreshness When a list's data was last refreshed successfully
+type ListFreshness struct {
+	// When the list was last refreshed successfully, zero if it has never loaded
+	RefreshedAt time.Time `json:"refreshedAt,omitempty"`
+	// Why the most recent refresh of the list failed, in which case its previous data is still served
+	Error string `json:"error,omitempty"`
+}
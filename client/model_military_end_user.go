@@ -11,12 +11,17 @@ package client
 
 // MilitaryEndUser struct for MilitaryEndUser
 type MilitaryEndUser struct {
-	EntityID  string `json:"entityID,omitempty"`
-	Name      string `json:"name,omitempty"`
-	Addresses string `json:"addresses,omitempty"`
-	FRNotice  string `json:"FRNotice,omitempty"`
-	StartDate string `json:"startDate,omitempty"`
-	EndDate   string `json:"endDate,omitempty"`
+	EntityID        string              `json:"entityID,omitempty"`
+	Name            string              `json:"name,omitempty"`
+	Addresses       string              `json:"addresses,omitempty"`
+	FRNotice        string              `json:"FRNotice,omitempty"`
+	StartDate       string              `json:"startDate,omitempty"`
+	EndDate         string              `json:"endDate,omitempty"`
+	Citizenships    []string            `json:"citizenships,omitempty"`
+	DatesOfBirth    []string            `json:"datesOfBirth,omitempty"`
+	Nationalities   []string            `json:"nationalities,omitempty"`
+	PlacesOfBirth   []string            `json:"placesOfBirth,omitempty"`
+	Identifications []CslIdentification `json:"identifications,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
@@ -0,0 +1,29 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// MonitoredEntity Customer or company screened after every refresh
+type MonitoredEntity struct {
+	MonitoredID string                `json:"monitoredID,omitempty"`
+	Type        MonitoredType         `json:"type,omitempty"`
+	Name        string                `json:"name,omitempty"`
+	DateOfBirth string                `json:"dateOfBirth,omitempty"`
+	Country     string                `json:"country,omitempty"`
+	Identifiers []MonitoredIdentifier `json:"identifiers,omitempty"`
+	// Entities matched the last time the customer or company was screened
+	Matches    []MonitoredMatch `json:"matches,omitempty"`
+	ScreenedAt time.Time        `json:"screenedAt,omitempty"`
+	CreatedAt  time.Time        `json:"createdAt,omitempty"`
+	UpdatedAt  time.Time        `json:"updatedAt,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// MonitoredEntityRequest Details of a customer or company to screen after every refresh
+type MonitoredEntityRequest struct {
+	Name string `json:"name"`
+	// Customer's date of birth as YYYY-MM-DD, YYYY-MM or YYYY. Entities listed with other birth years aren't matched.
+	DateOfBirth string                `json:"dateOfBirth,omitempty"`
+	Country     string                `json:"country,omitempty"`
+	Identifiers []MonitoredIdentifier `json:"identifiers,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// MonitoredIdentifier Passport, national ID, registration number, etc of a monitored entity
+type MonitoredIdentifier struct {
+	Type string `json:"type,omitempty"`
+	// Compared against the numbers listed for matched entities ignoring spaces, dashes and case
+	Number  string `json:"number"`
+	Country string `json:"country,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// MonitoredMatch Entity a monitored customer or company matched
+type MonitoredMatch struct {
+	List string `json:"list,omitempty"`
+	// Source list the entity is from
+	Source   string `json:"source,omitempty"`
+	EntityID string `json:"entityID,omitempty"`
+	// Name of entities listed without an ID
+	Name  string  `json:"name,omitempty"`
+	Match float64 `json:"match,omitempty"`
+}
@@ -11,15 +11,20 @@ package client
 
 // NonProliferationSanction struct for NonProliferationSanction
 type NonProliferationSanction struct {
-	EntityID              string   `json:"entityID,omitempty"`
-	Programs              []string `json:"programs,omitempty"`
-	Name                  string   `json:"name,omitempty"`
-	FederalRegisterNotice string   `json:"federalRegisterNotice,omitempty"`
-	StartDate             string   `json:"startDate,omitempty"`
-	Remarks               []string `json:"remarks,omitempty"`
-	SourceListURL         string   `json:"sourceListURL,omitempty"`
-	AlternateNames        []string `json:"alternateNames,omitempty"`
-	SourceInfoURL         string   `json:"sourceInfoURL,omitempty"`
+	EntityID              string              `json:"entityID,omitempty"`
+	Programs              []string            `json:"programs,omitempty"`
+	Name                  string              `json:"name,omitempty"`
+	FederalRegisterNotice string              `json:"federalRegisterNotice,omitempty"`
+	StartDate             string              `json:"startDate,omitempty"`
+	Remarks               []string            `json:"remarks,omitempty"`
+	SourceListURL         string              `json:"sourceListURL,omitempty"`
+	AlternateNames        []string            `json:"alternateNames,omitempty"`
+	SourceInfoURL         string              `json:"sourceInfoURL,omitempty"`
+	Citizenships          []string            `json:"citizenships,omitempty"`
+	DatesOfBirth          []string            `json:"datesOfBirth,omitempty"`
+	Nationalities         []string            `json:"nationalities,omitempty"`
+	PlacesOfBirth         []string            `json:"placesOfBirth,omitempty"`
+	Identifications       []CslIdentification `json:"identifications,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
@@ -11,17 +11,22 @@ package client
 
 // NonSdnChineseMilitaryIndustrialComplex struct for NonSdnChineseMilitaryIndustrialComplex
 type NonSdnChineseMilitaryIndustrialComplex struct {
-	EntityID       string   `json:"entityID,omitempty"`
-	EntityNumber   string   `json:"entityNumber,omitempty"`
-	Type           string   `json:"type,omitempty"`
-	Programs       []string `json:"programs,omitempty"`
-	Name           string   `json:"name,omitempty"`
-	Addresses      []string `json:"addresses,omitempty"`
-	Remarks        []string `json:"remarks,omitempty"`
-	SourceListURL  string   `json:"sourceListURL,omitempty"`
-	AlternateNames []string `json:"alternateNames,omitempty"`
-	SourceInfoURL  string   `json:"sourceInfoURL,omitempty"`
-	IDs            []string `json:"IDs,omitempty"`
+	EntityID        string              `json:"entityID,omitempty"`
+	EntityNumber    string              `json:"entityNumber,omitempty"`
+	Type            string              `json:"type,omitempty"`
+	Programs        []string            `json:"programs,omitempty"`
+	Name            string              `json:"name,omitempty"`
+	Addresses       []string            `json:"addresses,omitempty"`
+	Remarks         []string            `json:"remarks,omitempty"`
+	SourceListURL   string              `json:"sourceListURL,omitempty"`
+	AlternateNames  []string            `json:"alternateNames,omitempty"`
+	SourceInfoURL   string              `json:"sourceInfoURL,omitempty"`
+	IDs             []string            `json:"IDs,omitempty"`
+	Citizenships    []string            `json:"citizenships,omitempty"`
+	DatesOfBirth    []string            `json:"datesOfBirth,omitempty"`
+	Nationalities   []string            `json:"nationalities,omitempty"`
+	PlacesOfBirth   []string            `json:"placesOfBirth,omitempty"`
+	Identifications []CslIdentification `json:"identifications,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
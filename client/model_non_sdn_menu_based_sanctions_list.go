@@ -11,16 +11,21 @@ package client
 
 // NonSdnMenuBasedSanctionsList struct for NonSdnMenuBasedSanctionsList
 type NonSdnMenuBasedSanctionsList struct {
-	EntityID       string   `json:"EntityID,omitempty"`
-	EntityNumber   string   `json:"EntityNumber,omitempty"`
-	Type           string   `json:"Type,omitempty"`
-	Programs       []string `json:"Programs,omitempty"`
-	Name           string   `json:"Name,omitempty"`
-	Addresses      []string `json:"Addresses,omitempty"`
-	Remarks        []string `json:"Remarks,omitempty"`
-	AlternateNames []string `json:"AlternateNames,omitempty"`
-	SourceInfoURL  string   `json:"SourceInfoURL,omitempty"`
-	IDs            []string `json:"IDs,omitempty"`
+	EntityID        string              `json:"EntityID,omitempty"`
+	EntityNumber    string              `json:"EntityNumber,omitempty"`
+	Type            string              `json:"Type,omitempty"`
+	Programs        []string            `json:"Programs,omitempty"`
+	Name            string              `json:"Name,omitempty"`
+	Addresses       []string            `json:"Addresses,omitempty"`
+	Remarks         []string            `json:"Remarks,omitempty"`
+	AlternateNames  []string            `json:"AlternateNames,omitempty"`
+	SourceInfoURL   string              `json:"SourceInfoURL,omitempty"`
+	IDs             []string            `json:"IDs,omitempty"`
+	Citizenships    []string            `json:"citizenships,omitempty"`
+	DatesOfBirth    []string            `json:"datesOfBirth,omitempty"`
+	Nationalities   []string            `json:"nationalities,omitempty"`
+	PlacesOfBirth   []string            `json:"placesOfBirth,omitempty"`
+	Identifications []CslIdentification `json:"identifications,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
@@ -0,0 +1,23 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// Notifier How a watch's notifications are sent. Notifiers other than webhook are configured on the server and send a JSON envelope holding the deliveryID, watchID, timestamp, signature and body.
+type Notifier string
+
+// List of Notifier
+const (
+	NOTIFIER_WEBHOOK Notifier = "webhook"
+	NOTIFIER_KAFKA   Notifier = "kafka"
+	NOTIFIER_NATS    Notifier = "nats"
+	NOTIFIER_AMQP    Notifier = "amqp"
+	NOTIFIER_FILE    Notifier = "file"
+	NOTIFIER_STDOUT  Notifier = "stdout"
+)
@@ -0,0 +1,20 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// NotifyPolicy When a watch's webhook is called after a refresh. always calls it whenever the watch has hits, on-change when its hits or their match differ from those it was last notified of, and on-new-hit when an entity it wasn't last notified of matches.
+type NotifyPolicy string
+
+// List of NotifyPolicy
+const (
+	NOTIFYPOLICY_ALWAYS     NotifyPolicy = "always"
+	NOTIFYPOLICY_ON_CHANGE  NotifyPolicy = "on-change"
+	NOTIFYPOLICY_ON_NEW_HIT NotifyPolicy = "on-new-hit"
+)
@@ -13,4 +13,6 @@ package client
 type OfacWatch struct {
 	// Object representing a customer or company watch
 	WatchID string `json:"watchID,omitempty"`
+	// Secret the watch's webhooks are signed with. It's only returned when the watch is created.
+	SigningSecret string `json:"signingSecret,omitempty"`
 }
@@ -11,8 +11,14 @@ package client
 
 // OfacWatchRequest Webhook or other means of notification on search criteria. OFAC will make a POST request with a body of the customer or company (SDN, AltNames, and Address).
 type OfacWatchRequest struct {
-	// Private token supplied by clients to be used for authenticating webhooks.
-	AuthToken string `json:"authToken"`
-	// HTTPS url for webhook on search match
-	Webhook string `json:"webhook"`
+	// Private token supplied by clients to be used for authenticating webhooks. Required unless the watch uses another notifier.
+	AuthToken string `json:"authToken,omitempty"`
+	// HTTPS url for webhook on search match. Required unless the watch uses another notifier.
+	Webhook string `json:"webhook,omitempty"`
+	// Lists the watch is screened against. Watches without lists use the server's WATCH_LISTS. Watches of an ID select at most one list and otherwise use the first of WATCH_LISTS.
+	Lists []string `json:"lists,omitempty"`
+	// Lowest match a name watch is notified of, between 0 and 1. Only name watches accept a minMatch.
+	MinMatch     float64      `json:"minMatch,omitempty"`
+	NotifyPolicy NotifyPolicy `json:"notifyPolicy,omitempty"`
+	Notifier     Notifier     `json:"notifier,omitempty"`
 }
@@ -11,18 +11,21 @@ package client
 
 // PalestinianLegislativeCouncil struct for PalestinianLegislativeCouncil
 type PalestinianLegislativeCouncil struct {
-	EntityID       string   `json:"entityID,omitempty"`
-	EntityNumber   string   `json:"entityNumber,omitempty"`
-	Type           string   `json:"type,omitempty"`
-	Programs       []string `json:"programs,omitempty"`
-	Name           string   `json:"name,omitempty"`
-	Addresses      []string `json:"addresses,omitempty"`
-	Remarks        string   `json:"remarks,omitempty"`
-	SourceListURL  string   `json:"sourceListURL,omitempty"`
-	AlternateNames []string `json:"alternateNames,omitempty"`
-	DatesOfBirth   string   `json:"datesOfBirth,omitempty"`
-	PlacesOfBirth  string   `json:"placesOfBirth,omitempty"`
-	SourceInfoURL  string   `json:"sourceInfoURL,omitempty"`
+	EntityID        string              `json:"entityID,omitempty"`
+	EntityNumber    string              `json:"entityNumber,omitempty"`
+	Type            string              `json:"type,omitempty"`
+	Programs        []string            `json:"programs,omitempty"`
+	Name            string              `json:"name,omitempty"`
+	Addresses       []string            `json:"addresses,omitempty"`
+	Remarks         string              `json:"remarks,omitempty"`
+	SourceListURL   string              `json:"sourceListURL,omitempty"`
+	AlternateNames  []string            `json:"alternateNames,omitempty"`
+	DatesOfBirth    []string            `json:"datesOfBirth,omitempty"`
+	PlacesOfBirth   []string            `json:"placesOfBirth,omitempty"`
+	SourceInfoURL   string              `json:"sourceInfoURL,omitempty"`
+	Citizenships    []string            `json:"citizenships,omitempty"`
+	Nationalities   []string            `json:"nationalities,omitempty"`
+	Identifications []CslIdentification `json:"identifications,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
@@ -0,0 +1,25 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// PoliticallyExposedPerson struct for PoliticallyExposedPerson
+type PoliticallyExposedPerson struct {
+	EntityID       string        `json:"entityID,omitempty"`
+	Name           string        `json:"name,omitempty"`
+	AlternateNames []string      `json:"alternateNames,omitempty"`
+	BirthDates     []string      `json:"birthDates,omitempty"`
+	Countries      []string      `json:"countries,omitempty"`
+	Nationalities  []string      `json:"nationalities,omitempty"`
+	Positions      []PepPosition `json:"positions,omitempty"`
+	Datasets       []string      `json:"datasets,omitempty"`
+	SourceURL      string        `json:"sourceURL,omitempty"`
+	// Match percentage of search query
+	Match float32 `json:"match,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// RaiseSearchAlerts Name to raise alerts on the matching entities of
+type RaiseSearchAlerts struct {
+	// Name to search for
+	Name string `json:"name"`
+	// Lowest match score entities are alerted on, MONITOR_MIN_MATCH is used when it's higher
+	MinMatch float64 `json:"minMatch,omitempty"`
+}
@@ -0,0 +1,45 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// ScreeningAlert Raised when a monitored customer or company newly matches an entity, or on the hits of a search
+type ScreeningAlert struct {
+	AlertID       string        `json:"alertID,omitempty"`
+	Origin        AlertOrigin   `json:"origin,omitempty"`
+	MonitoredType MonitoredType `json:"monitoredType,omitempty"`
+	// Customer or company the alert was raised for, only set on alerts raised by monitoring
+	MonitoredID string `json:"monitoredID,omitempty"`
+	// Name searched for, only set on alerts raised by searches
+	Query    string `json:"query,omitempty"`
+	List     string `json:"list,omitempty"`
+	Source   string `json:"source,omitempty"`
+	EntityID string `json:"entityID,omitempty"`
+	// Primary name of the matched entity
+	Name  string  `json:"name,omitempty"`
+	Match float64 `json:"match,omitempty"`
+	// One of the monitored customer's or company's identifiers is listed for the entity
+	IdentifierMatch bool `json:"identifierMatch,omitempty"`
+	// The monitored customer's or company's country is listed as a nationality, citizenship, address, etc of the entity
+	CountryMatch bool `json:"countryMatch,omitempty"`
+	// Refresh which raised the alert. Alerts raised when a customer or company is registered don't have one.
+	DownloadID string `json:"downloadID,omitempty"`
+	// Matched entity as it was listed. Only returned for a single alert.
+	Entity map[string]interface{} `json:"entity,omitempty"`
+	Status AlertStatus            `json:"status,omitempty"`
+	// Analyst reviewing the alert
+	Assignee  string    `json:"assignee,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// When the alert's status or assignee last changed
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
@@ -33,5 +33,11 @@ type Search struct {
 	EuConsolidatedSanctionsList            []EuConsolidatedSanctionsList            `json:"euConsolidatedSanctionsList,omitempty"`
 	UkConsolidatedSanctionsList            []UkConsolidatedSanctionsList            `json:"ukConsolidatedSanctionsList,omitempty"`
 	UkSanctionsList                        []UkSanctionsList                        `json:"ukSanctionsList,omitempty"`
+	PoliticallyExposedPersons              []PoliticallyExposedPerson               `json:"politicallyExposedPersons,omitempty"`
+	FtmEntities                            []FtMEntity                              `json:"ftmEntities,omitempty"`
 	RefreshedAt                            time.Time                                `json:"refreshedAt,omitempty"`
+	// When each list was last refreshed successfully keyed by list name
+	Freshness map[string]ListFreshness `json:"freshness,omitempty"`
+	// ID of the snapshot which was searched
+	Snapshot string `json:"snapshot,omitempty"`
 }
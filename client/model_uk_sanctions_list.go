@@ -11,12 +11,25 @@ package client
 
 // UkSanctionsList struct for UkSanctionsList
 type UkSanctionsList struct {
-	Names            []string `json:"names,omitempty"`
-	NonLatinNames    []string `json:"nonLatinNames,omitempty"`
-	EntityType       string   `json:"entityType,omitempty"`
-	Addresses        []string `json:"addresses,omitempty"`
-	AddressCountries []string `json:"addressCountries,omitempty"`
-	StateLocalities  []string `json:"stateLocalities,omitempty"`
+	Names               []string                  `json:"names,omitempty"`
+	NonLatinNames       []string                  `json:"nonLatinNames,omitempty"`
+	EntityType          string                    `json:"entityType,omitempty"`
+	Addresses           []string                  `json:"addresses,omitempty"`
+	AddressCountries    []string                  `json:"addressCountries,omitempty"`
+	StateLocalities     []string                  `json:"stateLocalities,omitempty"`
+	OfsiGroupId         string                    `json:"ofsiGroupId,omitempty"`
+	RegimeName          string                    `json:"regimeName,omitempty"`
+	DesignationSource   string                    `json:"designationSource,omitempty"`
+	DateDesignated      string                    `json:"dateDesignated,omitempty"`
+	Titles              []string                  `json:"titles,omitempty"`
+	DatesOfBirth        []string                  `json:"datesOfBirth,omitempty"`
+	TownsOfBirth        []string                  `json:"townsOfBirth,omitempty"`
+	CountryOfBirth      string                    `json:"countryOfBirth,omitempty"`
+	Nationalities       []string                  `json:"nationalities,omitempty"`
+	Positions           []string                  `json:"positions,omitempty"`
+	Gender              string                    `json:"gender,omitempty"`
+	Passports           []UkSanctionsListDocument `json:"passports,omitempty"`
+	NationalIdentifiers []UkSanctionsListDocument `json:"nationalIdentifiers,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
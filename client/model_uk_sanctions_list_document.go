@@ -0,0 +1,16 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// UkSanctionsListDocument Passport or national identifier listed on the UK Sanctions List
+type UkSanctionsListDocument struct {
+	Number                string `json:"number,omitempty"`
+	AdditionalInformation string `json:"additionalInformation,omitempty"`
+}
@@ -11,11 +11,16 @@ package client
 
 // Unverified struct for Unverified
 type Unverified struct {
-	EntityID      string   `json:"entityID,omitempty"`
-	Name          string   `json:"name,omitempty"`
-	Addresses     []string `json:"addresses,omitempty"`
-	SourceListURL string   `json:"sourceListURL,omitempty"`
-	SourceInfoURL string   `json:"sourceInfoURL,omitempty"`
+	EntityID        string              `json:"entityID,omitempty"`
+	Name            string              `json:"name,omitempty"`
+	Addresses       []string            `json:"addresses,omitempty"`
+	SourceListURL   string              `json:"sourceListURL,omitempty"`
+	SourceInfoURL   string              `json:"sourceInfoURL,omitempty"`
+	Citizenships    []string            `json:"citizenships,omitempty"`
+	DatesOfBirth    []string            `json:"datesOfBirth,omitempty"`
+	Nationalities   []string            `json:"nationalities,omitempty"`
+	PlacesOfBirth   []string            `json:"placesOfBirth,omitempty"`
+	Identifications []CslIdentification `json:"identifications,omitempty"`
 	// Match percentage of search query
 	Match float32 `json:"match,omitempty"`
 }
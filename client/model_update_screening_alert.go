@@ -0,0 +1,19 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// UpdateScreeningAlert Changes to an alert. Fields which are left out are unchanged.
+type UpdateScreeningAlert struct {
+	Status AlertStatus `json:"status,omitempty"`
+	// Analyst to assign the alert to, empty to unassign it
+	Assignee string `json:"assignee,omitempty"`
+	// Comment added alongside the changes
+	Comment string `json:"comment,omitempty"`
+}
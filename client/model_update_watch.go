@@ -0,0 +1,26 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// UpdateWatch Watch fields to change
+type UpdateWatch struct {
+	// HTTPS url for webhook on search match
+	Webhook string `json:"webhook,omitempty"`
+	// Private token supplied by clients to be used for authenticating webhooks.
+	AuthToken string `json:"authToken,omitempty"`
+	// Lists the watch is screened against. An empty array goes back to the server's WATCH_LISTS.
+	Lists *[]string `json:"lists,omitempty"`
+	// Lowest match a name watch is notified of, between 0 and 1. Zero removes the watch's threshold.
+	MinMatch float64 `json:"minMatch,omitempty"`
+	// When the watch's webhook is called, an empty string goes back to the server's WATCH_NOTIFY_POLICY.
+	NotifyPolicy *string `json:"notifyPolicy,omitempty"`
+	// How the watch's notifications are sent, an empty string goes back to the server's WATCH_NOTIFIER.
+	Notifier *string `json:"notifier,omitempty"`
+}
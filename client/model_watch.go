@@ -0,0 +1,37 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// Watch Customer or company watch
+type Watch struct {
+	WatchID string    `json:"watchID,omitempty"`
+	Type    WatchType `json:"type,omitempty"`
+	// Customer a customer watch is for
+	CustomerID string `json:"customerID,omitempty"`
+	// Company a company watch is for
+	CompanyID string `json:"companyID,omitempty"`
+	// Name a customer name or company name watch searches for
+	Name string `json:"name,omitempty"`
+	// HTTPS url the watch's webhooks are sent to
+	Webhook string `json:"webhook,omitempty"`
+	// Lists the watch is screened against. Watches which haven't selected lists return the server's WATCH_LISTS.
+	Lists []string `json:"lists,omitempty"`
+	// Lowest match a name watch is notified of. Unset watches use the server default.
+	MinMatch     float64      `json:"minMatch,omitempty"`
+	NotifyPolicy NotifyPolicy `json:"notifyPolicy,omitempty"`
+	Notifier     Notifier     `json:"notifier,omitempty"`
+	CreatedAt    time.Time    `json:"createdAt,omitempty"`
+	// Latest attempts at calling the webhook, newest first. Only returned for a single watch.
+	RecentWebhooks []WebhookAttempt `json:"recentWebhooks,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+// WatchType Kind of watch
+type WatchType string
+
+// List of WatchType
+const (
+	WATCHTYPE_CUSTOMER      WatchType = "customer"
+	WATCHTYPE_CUSTOMER_NAME WatchType = "customerName"
+	WATCHTYPE_COMPANY       WatchType = "company"
+	WATCHTYPE_COMPANY_NAME  WatchType = "companyName"
+)
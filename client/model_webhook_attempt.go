@@ -0,0 +1,21 @@
+/*
+ * Watchman API
+ *
+ * Moov Watchman offers download, parse, and search functions over numerous U.S. trade sanction lists for complying with regional laws. Also included is a web UI and async webhook notification service to initiate processes on remote systems.
+ *
+ * API version: v1
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package client
+
+import (
+	"time"
+)
+
+// WebhookAttempt Attempt at calling a watch's webhook
+type WebhookAttempt struct {
+	AttemptedAt time.Time `json:"attemptedAt,omitempty"`
+	// HTTP status code the webhook responded with
+	Status int32 `json:"status,omitempty"`
+}
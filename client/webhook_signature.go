@@ -0,0 +1,94 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader holds one or more comma separated signatures of a webhook. More
+	// than one signature is sent while a watch's signing secret is being rotated.
+	WebhookSignatureHeader = "X-Watchman-Signature"
+
+	// WebhookTimestampHeader holds the Unix time (in seconds) a webhook was sent at
+	WebhookTimestampHeader = "X-Watchman-Timestamp"
+
+	// DefaultWebhookTolerance is how old (or far in the future) a webhook's timestamp can be
+	// before it's rejected as a replay.
+	DefaultWebhookTolerance = 5 * time.Minute
+
+	webhookSignatureVersion = "v1="
+)
+
+var (
+	ErrWebhookSignatureMissing = errors.New("webhook signature is missing")
+	ErrWebhookSignatureInvalid = errors.New("webhook signature is invalid")
+	ErrWebhookTimestampInvalid = errors.New("webhook timestamp is missing or outside the tolerance")
+)
+
+// SignWebhook returns the signature of body sent at timestamp. It's the hex encoded
+// HMAC-SHA256 of "<unix timestamp>.<body>" keyed with the watch's signing secret.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return webhookSignatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks the signature and timestamp headers of a webhook against its body.
+// A tolerance of zero uses DefaultWebhookTolerance.
+func VerifyWebhook(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+	signatures := header.Get(WebhookSignatureHeader)
+	if signatures == "" {
+		return ErrWebhookSignatureMissing
+	}
+
+	seconds, err := strconv.ParseInt(header.Get(WebhookTimestampHeader), 10, 64)
+	if err != nil {
+		return ErrWebhookTimestampInvalid
+	}
+	timestamp := time.Unix(seconds, 0)
+	if diff := time.Since(timestamp); diff > tolerance || diff < -tolerance {
+		return ErrWebhookTimestampInvalid
+	}
+
+	expected := SignWebhook(secret, timestamp, body)
+	for _, sig := range strings.Split(signatures, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(sig)), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrWebhookSignatureInvalid
+}
+
+// VerifyWebhookRequest reads the body of an incoming webhook and verifies its signature.
+// The body is returned so it can be decoded once it's trusted.
+func VerifyWebhookRequest(r *http.Request, secret string, tolerance time.Duration) ([]byte, error) {
+	if r.Body == nil {
+		return nil, ErrWebhookSignatureInvalid
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading webhook: %w", err)
+	}
+	if err := VerifyWebhook(secret, r.Header, body, tolerance); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
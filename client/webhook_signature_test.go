@@ -0,0 +1,77 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func signedHeader(secret string, timestamp time.Time, body []byte) http.Header {
+	header := make(http.Header)
+	header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+	header.Set(WebhookSignatureHeader, SignWebhook(secret, timestamp, body))
+	return header
+}
+
+func TestSignWebhook(t *testing.T) {
+	when := time.Unix(1677834240, 0)
+	// printf '1677834240.{"id":"306"}' | openssl dgst -sha256 -hmac secret
+	sig := SignWebhook("secret", when, []byte(`{"id":"306"}`))
+	require.Equal(t, "v1=13516c81eedd35f1a671d7b597524c38089c3564ee159c2c504d18376aec8cdb", sig)
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	body := []byte(`{"id":"306"}`)
+	now := time.Now()
+
+	require.NoError(t, VerifyWebhook("secret", signedHeader("secret", now, body), body, 0))
+
+	// wrong secret or modified body
+	require.ErrorIs(t, VerifyWebhook("other", signedHeader("secret", now, body), body, 0), ErrWebhookSignatureInvalid)
+	require.ErrorIs(t, VerifyWebhook("secret", signedHeader("secret", now, body), []byte(`{"id":"307"}`), 0), ErrWebhookSignatureInvalid)
+
+	// replayed webhooks are rejected
+	old := signedHeader("secret", now.Add(-time.Hour), body)
+	require.ErrorIs(t, VerifyWebhook("secret", old, body, 0), ErrWebhookTimestampInvalid)
+	require.NoError(t, VerifyWebhook("secret", old, body, 2*time.Hour))
+
+	// the timestamp is signed
+	header := signedHeader("secret", now.Add(-time.Minute), body)
+	header.Set(WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	require.ErrorIs(t, VerifyWebhook("secret", header, body, 0), ErrWebhookSignatureInvalid)
+
+	require.ErrorIs(t, VerifyWebhook("secret", make(http.Header), body, 0), ErrWebhookSignatureMissing)
+}
+
+func TestVerifyWebhook__rotation(t *testing.T) {
+	body := []byte(`{"id":"306"}`)
+	now := time.Now()
+
+	header := signedHeader("new", now, body)
+	header.Set(WebhookSignatureHeader, SignWebhook("new", now, body)+","+SignWebhook("old", now, body))
+
+	require.NoError(t, VerifyWebhook("new", header, body, 0))
+	require.NoError(t, VerifyWebhook("old", header, body, 0))
+	require.ErrorIs(t, VerifyWebhook("other", header, body, 0), ErrWebhookSignatureInvalid)
+}
+
+func TestVerifyWebhookRequest(t *testing.T) {
+	body := []byte(`{"id":"306"}`)
+	req := httptest.NewRequest("POST", "/ofac", bytes.NewReader(body))
+	for k, v := range signedHeader("secret", time.Now(), body) {
+		req.Header[k] = v
+	}
+
+	out, err := VerifyWebhookRequest(req, "secret", 0)
+	require.NoError(t, err)
+	require.Equal(t, body, out)
+}
@@ -0,0 +1,452 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base"
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+)
+
+var (
+	errAlertTransition = errors.New("invalid alert status change")
+	errAlertConflict   = errors.New("alert was changed by another request")
+	errNoAlertChanges  = errors.New("no status, assignee or comment provided")
+	errNoAlertComment  = errors.New("no comment provided")
+)
+
+const (
+	// maxAlertAssigneeLength and maxAlertCommentLength are the longest assignee and comment an alert accepts
+	maxAlertAssigneeLength = 128
+	maxAlertCommentLength  = 10000
+)
+
+// alertStatus is where an alert is in an analyst's review
+type alertStatus string
+
+const (
+	alertOpen          alertStatus = "open"
+	alertInReview      alertStatus = "in_review"
+	alertEscalated     alertStatus = "escalated"
+	alertTruePositive  alertStatus = "true_positive"
+	alertFalsePositive alertStatus = "false_positive"
+	alertClosed        alertStatus = "closed"
+)
+
+// alertTransitions are the statuses an alert can move to from each status. Resolved alerts
+// (true_positive, false_positive and closed) can only be re-opened.
+var alertTransitions = map[alertStatus][]alertStatus{
+	alertOpen:          {alertInReview, alertEscalated, alertTruePositive, alertFalsePositive, alertClosed},
+	alertInReview:      {alertOpen, alertEscalated, alertTruePositive, alertFalsePositive, alertClosed},
+	alertEscalated:     {alertInReview, alertTruePositive, alertFalsePositive, alertClosed},
+	alertTruePositive:  {alertOpen},
+	alertFalsePositive: {alertOpen},
+	alertClosed:        {alertOpen},
+}
+
+// unresolvedAlertStatuses are the statuses of alerts still waiting on an analyst
+var unresolvedAlertStatuses = []alertStatus{alertOpen, alertInReview, alertEscalated}
+
+func (s alertStatus) valid() bool {
+	_, ok := alertTransitions[s]
+	return ok
+}
+
+func (s alertStatus) unresolved() bool {
+	for _, status := range unresolvedAlertStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (s alertStatus) canMoveTo(next alertStatus) bool {
+	for _, to := range alertTransitions[s] {
+		if to == next {
+			return true
+		}
+	}
+	return false
+}
+
+// readAlertStatuses reads a comma separated list of statuses
+func readAlertStatuses(str string) ([]alertStatus, error) {
+	var out []alertStatus
+	for _, v := range strings.Split(str, ",") {
+		status := alertStatus(strings.TrimSpace(v))
+		if !status.valid() {
+			return nil, fmt.Errorf("invalid alert status %q", v)
+		}
+		out = append(out, status)
+	}
+	return out, nil
+}
+
+// alertUpdate holds the changes an analyst makes to an alert. Fields which are nil are unchanged
+// and an empty Assignee unassigns the alert.
+type alertUpdate struct {
+	Status   *alertStatus `json:"status"`
+	Assignee *string      `json:"assignee"`
+
+	// Comment is added to the alert alongside the changes
+	Comment string `json:"comment"`
+}
+
+func (u alertUpdate) validate() error {
+	if u.Status == nil && u.Assignee == nil && u.Comment == "" {
+		return errNoAlertChanges
+	}
+	if u.Status != nil && !u.Status.valid() {
+		return fmt.Errorf("invalid alert status %q", *u.Status)
+	}
+	if u.Assignee != nil && len(*u.Assignee) > maxAlertAssigneeLength {
+		return fmt.Errorf("assignee is longer than %d characters", maxAlertAssigneeLength)
+	}
+	if len(u.Comment) > maxAlertCommentLength {
+		return fmt.Errorf("comment is longer than %d characters", maxAlertCommentLength)
+	}
+	return nil
+}
+
+type alertComment struct {
+	ID        string    `json:"commentID"`
+	AlertID   string    `json:"alertID"`
+	Author    string    `json:"author"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// alertAction is the kind of change recorded in an alert's audit log
+type alertAction string
+
+const (
+	alertActionCreated   alertAction = "created"
+	alertActionStatus    alertAction = "status"
+	alertActionAssignee  alertAction = "assignee"
+	alertActionCommented alertAction = "commented"
+)
+
+type alertAuditEntry struct {
+	ID      string      `json:"entryID"`
+	AlertID string      `json:"alertID"`
+	Actor   string      `json:"actor,omitempty"`
+	Action  alertAction `json:"action"`
+
+	// Previous and Value are what a status or assignee changed from and to, Value holds the commentID of comments
+	Previous string `json:"previous,omitempty"`
+	Value    string `json:"value,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// HTTP routes
+
+func updateScreeningAlert(logger log.Logger, repo alertRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		alertID, userID := getAlertID(w, r), moovhttp.GetUserID(r)
+		if alertID == "" {
+			return
+		}
+		if userID == "" {
+			moovhttp.Problem(w, errNoUserID)
+			return
+		}
+		var update alertUpdate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if err := update.validate(); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		alert, err := repo.updateAlert(alertID, update, userID, time.Now())
+		if err != nil {
+			if errors.Is(err, errAlertConflict) {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			moovhttp.Problem(w, err)
+			return
+		}
+		if alert == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+			"userID":    log.String(userID),
+		}).Logf("updated alert=%s status=%s assignee=%s", alertID, alert.Status, alert.Assignee)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(alert)
+	}
+}
+
+func listAlertComments(logger log.Logger, repo alertRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		alertID := getAlertID(w, r)
+		if alertID == "" {
+			return
+		}
+		if !alertExists(w, repo, alertID) {
+			return
+		}
+		comments, err := repo.listComments(alertID)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if comments == nil {
+			comments = []*alertComment{}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(comments)
+	}
+}
+
+func addAlertComment(logger log.Logger, repo alertRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		alertID, userID := getAlertID(w, r), moovhttp.GetUserID(r)
+		if alertID == "" {
+			return
+		}
+		if userID == "" {
+			moovhttp.Problem(w, errNoUserID)
+			return
+		}
+		var req struct {
+			Comment string `json:"comment"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if strings.TrimSpace(req.Comment) == "" {
+			moovhttp.Problem(w, errNoAlertComment)
+			return
+		}
+		if len(req.Comment) > maxAlertCommentLength {
+			moovhttp.Problem(w, fmt.Errorf("comment is longer than %d characters", maxAlertCommentLength))
+			return
+		}
+		if !alertExists(w, repo, alertID) {
+			return
+		}
+
+		comment := &alertComment{
+			ID:        base.ID(),
+			AlertID:   alertID,
+			Author:    userID,
+			Comment:   req.Comment,
+			CreatedAt: time.Now(),
+		}
+		if err := repo.addComment(comment); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(comment)
+	}
+}
+
+func listAlertAuditLog(logger log.Logger, repo alertRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		alertID := getAlertID(w, r)
+		if alertID == "" {
+			return
+		}
+		if !alertExists(w, repo, alertID) {
+			return
+		}
+		entries, err := repo.listAuditLog(alertID)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if entries == nil {
+			entries = []*alertAuditEntry{}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(entries)
+	}
+}
+
+// alertExists responds with a 404 when alertID doesn't exist
+func alertExists(w http.ResponseWriter, repo alertRepository, alertID string) bool {
+	alerts, err := repo.listAlerts(alertFilter{alertID: alertID, limit: 1})
+	if err != nil {
+		moovhttp.Problem(w, err)
+		return false
+	}
+	if len(alerts) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+// Repository
+
+func (r *sqliteAlertRepository) updateAlert(alertID string, update alertUpdate, actor string, now time.Time) (*screeningAlert, error) {
+	alerts, err := r.listAlerts(alertFilter{alertID: alertID, limit: 1})
+	if err != nil || len(alerts) == 0 {
+		return nil, err
+	}
+	alert := alerts[0]
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	if err := updateAlertTx(tx, alert, update, actor, now); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.getAlert(alertID)
+}
+
+func updateAlertTx(tx *sql.Tx, alert *screeningAlert, update alertUpdate, actor string, now time.Time) error {
+	if update.Status != nil && *update.Status != alert.Status {
+		if !alert.Status.canMoveTo(*update.Status) {
+			return fmt.Errorf("%w: %s alerts can't move to %s", errAlertTransition, alert.Status, *update.Status)
+		}
+		// only move the alert from the status it was read with, so concurrent changes aren't lost
+		res, err := tx.Exec(`update screening_alerts set status = ?, open_key = ?, updated_at = ? where id = ? and status = ?;`,
+			*update.Status, alert.openKey(*update.Status), now, alert.ID, alert.Status)
+		if err != nil {
+			if database.UniqueViolation(err) {
+				return fmt.Errorf("%w: the search hit has another unresolved alert", errAlertConflict)
+			}
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return errAlertConflict
+		}
+		if err := addAuditEntry(tx, alert.ID, actor, alertActionStatus, string(alert.Status), string(*update.Status), now); err != nil {
+			return err
+		}
+	}
+	if update.Assignee != nil && *update.Assignee != alert.Assignee {
+		res, err := tx.Exec(`update screening_alerts set assignee = ?, updated_at = ? where id = ? and coalesce(assignee, '') = ?;`,
+			*update.Assignee, now, alert.ID, alert.Assignee)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return errAlertConflict
+		}
+		if err := addAuditEntry(tx, alert.ID, actor, alertActionAssignee, alert.Assignee, *update.Assignee, now); err != nil {
+			return err
+		}
+	}
+	if update.Comment != "" {
+		comment := &alertComment{ID: base.ID(), AlertID: alert.ID, Author: actor, Comment: update.Comment, CreatedAt: now}
+		if err := addCommentTx(tx, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *sqliteAlertRepository) addComment(comment *alertComment) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := addCommentTx(tx, comment); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func addCommentTx(tx *sql.Tx, comment *alertComment) error {
+	query := `insert into alert_comments (id, alert_id, author, body, created_at) values (?, ?, ?, ?, ?);`
+	if _, err := tx.Exec(query, comment.ID, comment.AlertID, comment.Author, comment.Comment, comment.CreatedAt); err != nil {
+		return err
+	}
+	return addAuditEntry(tx, comment.AlertID, comment.Author, alertActionCommented, "", comment.ID, comment.CreatedAt)
+}
+
+func addAuditEntry(tx *sql.Tx, alertID, actor string, action alertAction, previous, value string, now time.Time) error {
+	query := `insert into alert_audit_log (id, alert_id, actor, action, previous_value, value, created_at) values (?, ?, ?, ?, ?, ?, ?);`
+	_, err := tx.Exec(query, base.ID(), alertID, actor, action, previous, value, now)
+	return err
+}
+
+func (r *sqliteAlertRepository) listComments(alertID string) ([]*alertComment, error) {
+	query := `select id, alert_id, author, body, created_at from alert_comments where alert_id = ? order by created_at asc, id asc;`
+	rows, err := r.db.Query(query, alertID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*alertComment
+	for rows.Next() {
+		var comment alertComment
+		var author sql.NullString
+		if err := rows.Scan(&comment.ID, &comment.AlertID, &author, &comment.Comment, &comment.CreatedAt); err != nil {
+			return nil, err
+		}
+		comment.Author = author.String
+		out = append(out, &comment)
+	}
+	return out, rows.Err()
+}
+
+func (r *sqliteAlertRepository) listAuditLog(alertID string) ([]*alertAuditEntry, error) {
+	query := `select id, alert_id, actor, action, previous_value, value, created_at from alert_audit_log where alert_id = ? order by created_at asc, id asc;`
+	rows, err := r.db.Query(query, alertID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*alertAuditEntry
+	for rows.Next() {
+		var entry alertAuditEntry
+		var actor, previous, value sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.AlertID, &actor, &entry.Action, &previous, &value, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entry.Actor = actor.String
+		entry.Previous = previous.String
+		entry.Value = value.String
+		out = append(out, &entry)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,328 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertStatus(t *testing.T) {
+	require.True(t, alertOpen.canMoveTo(alertInReview))
+	require.True(t, alertEscalated.canMoveTo(alertFalsePositive))
+	require.False(t, alertEscalated.canMoveTo(alertOpen))
+	require.True(t, alertClosed.canMoveTo(alertOpen))
+	require.False(t, alertTruePositive.canMoveTo(alertClosed))
+	require.False(t, alertOpen.canMoveTo(alertOpen))
+
+	statuses, err := readAlertStatuses("open, in_review")
+	require.NoError(t, err)
+	require.Equal(t, []alertStatus{alertOpen, alertInReview}, statuses)
+
+	_, err = readAlertStatuses("open,reviewing")
+	require.Error(t, err)
+}
+
+func TestAlertCases__repository(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, repo *sqliteAlertRepository) {
+		now := time.Now().Truncate(time.Second)
+		alert := &screeningAlert{ID: base.ID(), Origin: alertOriginMonitoring, MonitoredType: monitoredCustomer, MonitoredID: "cust-1",
+			List: "SDNs", Source: "SDNs", EntityID: "2676", Match: 0.95, Status: alertOpen, CreatedAt: now}
+		require.NoError(t, repo.addAlert(alert, ""))
+
+		status, assignee := alertInReview, "jane"
+		updated, err := repo.updateAlert(alert.ID, alertUpdate{Status: &status, Assignee: &assignee, Comment: "looking into it"}, "jane", now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, alertInReview, updated.Status)
+		require.Equal(t, "jane", updated.Assignee)
+		require.NotNil(t, updated.UpdatedAt)
+
+		// resolved alerts can only be re-opened
+		status = alertFalsePositive
+		_, err = repo.updateAlert(alert.ID, alertUpdate{Status: &status}, "jane", now.Add(2*time.Minute))
+		require.NoError(t, err)
+		status = alertEscalated
+		_, err = repo.updateAlert(alert.ID, alertUpdate{Status: &status}, "jane", now.Add(3*time.Minute))
+		require.ErrorIs(t, err, errAlertTransition)
+
+		// changes made since the alert was read aren't overwritten
+		stale := *updated
+		stale.Status = alertInReview
+		tx, err := repo.db.Begin()
+		require.NoError(t, err)
+		require.ErrorIs(t, updateAlertTx(tx, &stale, alertUpdate{Status: &status}, "bob", now), errAlertConflict)
+		require.NoError(t, tx.Rollback())
+
+		missing, err := repo.updateAlert("missing", alertUpdate{Status: &status}, "jane", now)
+		require.NoError(t, err)
+		require.Nil(t, missing)
+
+		comments, err := repo.listComments(alert.ID)
+		require.NoError(t, err)
+		require.Len(t, comments, 1)
+		require.Equal(t, "jane", comments[0].Author)
+		require.Equal(t, "looking into it", comments[0].Comment)
+
+		entries, err := repo.listAuditLog(alert.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 5)
+		require.Equal(t, alertActionCreated, entries[0].Action)
+		require.Empty(t, entries[0].Actor)
+		actions := make(map[alertAction]*alertAuditEntry)
+		for _, e := range entries[1:4] {
+			actions[e.Action] = e
+		}
+		require.Equal(t, "open", actions[alertActionStatus].Previous)
+		require.Equal(t, "in_review", actions[alertActionStatus].Value)
+		require.Equal(t, "jane", actions[alertActionAssignee].Value)
+		require.Equal(t, comments[0].ID, actions[alertActionCommented].Value)
+		require.Equal(t, "false_positive", entries[4].Value)
+
+		// analyst queue filters
+		other := &screeningAlert{ID: base.ID(), Origin: alertOriginSearch, Query: "jane doe", List: "EUCSL", Source: "EUCSL",
+			Name: "Jane Doe", Match: 0.91, Status: alertOpen, CreatedAt: now}
+		require.NoError(t, repo.addAlert(other, "bob"))
+
+		for _, tc := range []struct {
+			filter   alertFilter
+			expected string
+		}{
+			{alertFilter{statuses: unresolvedAlertStatuses, limit: 10}, other.ID},
+			{alertFilter{statuses: []alertStatus{alertFalsePositive}, limit: 10}, alert.ID},
+			{alertFilter{assignee: "jane", limit: 10}, alert.ID},
+			{alertFilter{unassigned: true, limit: 10}, other.ID},
+			{alertFilter{origin: alertOriginSearch, query: "jane doe", limit: 10}, other.ID},
+			{alertFilter{origin: alertOriginMonitoring, limit: 10}, alert.ID},
+		} {
+			alerts, err := repo.listAlerts(tc.filter)
+			require.NoError(t, err)
+			require.Len(t, alerts, 1, "%#v", tc.filter)
+			require.Equal(t, tc.expected, alerts[0].ID)
+		}
+	}
+
+	// SQLite tests
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+	check(t, &sqliteAlertRepository{sqliteDB.DB})
+
+	// MySQL tests
+	mysqlDB := database.TestMySQLConnection(t)
+	check(t, &sqliteAlertRepository{mysqlDB})
+}
+
+func TestAlertCases__routes(t *testing.T) {
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteAlertRepository{db.DB}
+	require.NoError(t, repo.addAlert(&screeningAlert{ID: "alertID", Origin: alertOriginMonitoring, MonitoredType: monitoredCustomer, MonitoredID: "cust-1",
+		List: "SDNs", Source: "SDNs", EntityID: "2676", Match: 0.95, Status: alertOpen, CreatedAt: time.Now()}, ""))
+
+	router := mux.NewRouter()
+	addAlertRoutes(log.NewNopLogger(), router, repo)
+
+	do := func(method, path, userID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		if userID != "" {
+			req.Header.Set("X-User-Id", userID)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, tc := range []struct {
+		userID, body string
+	}{
+		{"", `{"status":"in_review"}`},
+		{"jane", `{}`},
+		{"jane", `{"status":"reviewing"}`},
+		{"jane", `{"status":"open"}`},
+	} {
+		w := do("PATCH", "/alerts/alertID", tc.userID, tc.body)
+		if tc.body == `{"status":"open"}` {
+			// the alert is already open, so nothing changes
+			require.Equal(t, http.StatusOK, w.Code)
+			continue
+		}
+		require.Equal(t, http.StatusBadRequest, w.Code, tc.body)
+	}
+
+	w := do("PATCH", "/alerts/alertID", "jane", `{"status":"escalated","assignee":"bob","comment":"needs a second look"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var alert screeningAlert
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&alert))
+	require.Equal(t, alertEscalated, alert.Status)
+	require.Equal(t, "bob", alert.Assignee)
+
+	w = do("PATCH", "/alerts/alertID", "bob", `{"status":"open"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do("GET", "/alerts?status=escalated&assignee=bob", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"alertID":"alertID"`)
+
+	for _, query := range []string{"status=reviewing", "unassigned=maybe", "unassigned=true&assignee=bob", "origin=watch"} {
+		w = do("GET", "/alerts?"+query, "", "")
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	w = do("POST", "/alerts/alertID/comments", "", `{"comment":"hello"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("POST", "/alerts/alertID/comments", "bob", `{"comment":" "}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("POST", "/alerts/alertID/comments", "bob", `{"comment":"same person, closing"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = do("GET", "/alerts/alertID/comments", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var comments []alertComment
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&comments))
+	require.Len(t, comments, 2)
+
+	w = do("GET", "/alerts/alertID/audit", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var entries []alertAuditEntry
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&entries))
+	require.Len(t, entries, 5)
+
+	for _, req := range [][2]string{
+		{"PATCH", "/alerts/missing"},
+		{"GET", "/alerts/missing/comments"},
+		{"POST", "/alerts/missing/comments"},
+		{"GET", "/alerts/missing/audit"},
+	} {
+		w = do(req[0], req[1], "jane", `{"status":"closed","comment":"hello"}`)
+		require.Equal(t, http.StatusNotFound, w.Code, req[1])
+	}
+}
+
+func TestAlertCases__search(t *testing.T) {
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteAlertRepository{db.DB}
+
+	router := mux.NewRouter()
+	addSearchRoutes(log.NewNopLogger(), router, createTestWatchListsSearcher(t), repo)
+
+	raise := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/search/alerts", strings.NewReader(body))
+		req.Header.Set("X-User-Id", "jane")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := raise(`{"name":"Saddam Hussein Al-Tikriti"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var alerts []*screeningAlert
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&alerts))
+	require.Len(t, alerts, 1)
+	require.Equal(t, alertOriginSearch, alerts[0].Origin)
+	require.Equal(t, "saddam hussein al-tikriti", alerts[0].Query)
+	require.Equal(t, "EUCSL", alerts[0].List)
+	alertID := alerts[0].ID
+
+	entries, err := repo.listAuditLog(alertID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "jane", entries[0].Actor)
+
+	// raising again returns the unresolved alert instead of raising another
+	w = raise(`{"name":"saddam hussein al-tikriti"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	alerts = nil
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&alerts))
+	require.Len(t, alerts, 1)
+	require.Equal(t, alertID, alerts[0].ID)
+
+	// once it's resolved a new alert is raised
+	status := alertFalsePositive
+	_, err = repo.updateAlert(alertID, alertUpdate{Status: &status}, "jane", time.Now())
+	require.NoError(t, err)
+
+	w = raise(`{"name":"Saddam Hussein Al-Tikriti"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	alerts = nil
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&alerts))
+	require.Len(t, alerts, 1)
+	require.NotEqual(t, alertID, alerts[0].ID)
+
+	// the resolved alert can't be re-opened while the new one is unresolved
+	status = alertOpen
+	_, err = repo.updateAlert(alertID, alertUpdate{Status: &status}, "jane", time.Now())
+	require.ErrorIs(t, err, errAlertConflict)
+
+	// searches don't raise alerts
+	req := httptest.NewRequest("GET", "/search?name=Banco+Nacional+de+Cuba&alert=true", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), `"alerts"`)
+
+	for _, body := range []string{
+		`{"name":""}`,
+		`{"name":"Banco","minMatch":1.5}`,
+		`{"name":`,
+	} {
+		w = raise(body)
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestAlertCases__searchOpenKey(t *testing.T) {
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteAlertRepository{db.DB}
+
+	newAlert := func() *screeningAlert {
+		return &screeningAlert{
+			ID:        base.ID(),
+			Origin:    alertOriginSearch,
+			Query:     "banco nacional de cuba",
+			List:      "SDNs",
+			Source:    "OFAC",
+			EntityID:  "306",
+			Match:     1.0,
+			Status:    alertOpen,
+			CreatedAt: time.Now(),
+		}
+	}
+
+	// only one unresolved alert is stored for each search hit
+	require.NoError(t, repo.addAlert(newAlert(), ""))
+	err := repo.addAlert(newAlert(), "")
+	require.True(t, database.UniqueViolation(err), err)
+
+	// resolved alerts and monitoring alerts have no key
+	resolved := newAlert()
+	resolved.Status = alertClosed
+	require.NoError(t, repo.addAlert(resolved, ""))
+
+	for i := 0; i < 2; i++ {
+		monitored := newAlert()
+		monitored.Origin, monitored.Query = alertOriginMonitoring, ""
+		monitored.MonitoredType, monitored.MonitoredID = monitoredCustomer, "customerID"
+		require.NoError(t, repo.addAlert(monitored, ""))
+	}
+}
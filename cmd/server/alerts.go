@@ -0,0 +1,530 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base"
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/gorilla/mux"
+)
+
+var (
+	errNoAlertID = errors.New("no alertID found")
+)
+
+// alertOrigin is what raised an alert
+type alertOrigin string
+
+const (
+	alertOriginMonitoring alertOrigin = "monitoring"
+	alertOriginSearch     alertOrigin = "search"
+)
+
+func (o alertOrigin) valid() bool {
+	return o == alertOriginMonitoring || o == alertOriginSearch
+}
+
+// screeningAlert is raised when a monitored customer or company newly matches an entity on one of
+// the lists at or above monitorMinMatch, or when a search asks for alerts on its hits.
+type screeningAlert struct {
+	ID     string      `json:"alertID"`
+	Origin alertOrigin `json:"origin"`
+
+	// MonitoredType and MonitoredID are set on alerts raised by screening monitored entities
+	MonitoredType monitoredType `json:"monitoredType,omitempty"`
+	MonitoredID   string        `json:"monitoredID,omitempty"`
+
+	// Query is the name searched for on alerts raised by searches
+	Query string `json:"query,omitempty"`
+
+	// List, Source, EntityID and Match describe the matched entity like a watch's hits
+	List     string  `json:"list"`
+	Source   string  `json:"source"`
+	EntityID string  `json:"entityID,omitempty"`
+	Name     string  `json:"name,omitempty"`
+	Match    float64 `json:"match"`
+
+	// IdentifierMatch is set when one of the monitored entity's identifiers is listed for the entity
+	IdentifierMatch bool `json:"identifierMatch"`
+
+	// CountryMatch is set when the monitored entity's country is listed for the entity
+	CountryMatch bool `json:"countryMatch"`
+
+	// DownloadID is the refresh which raised the alert, empty when it was raised on registration
+	DownloadID string `json:"downloadID,omitempty"`
+
+	// Entity is the matched entity as it was listed, only included when getting a single alert
+	Entity json.RawMessage `json:"entity,omitempty"`
+
+	// Status and Assignee track an analyst's review of the alert
+	Status   alertStatus `json:"status"`
+	Assignee string      `json:"assignee,omitempty"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+func newScreeningAlert(m *monitoredEntity, hit watchHit, downloadID string, now time.Time) (*screeningAlert, error) {
+	entity, err := json.Marshal(hit.Entity)
+	if err != nil {
+		return nil, fmt.Errorf("problem encoding %s entity %s: %v", hit.Source, hit.EntityID, err)
+	}
+	return &screeningAlert{
+		ID:              base.ID(),
+		Origin:          alertOriginMonitoring,
+		MonitoredType:   m.Type,
+		MonitoredID:     m.ID,
+		List:            hit.List,
+		Source:          hit.Source,
+		EntityID:        hit.EntityID,
+		Name:            hitName(hit),
+		Match:           hit.Match,
+		IdentifierMatch: hitIdentifierMatch(hit, m.Identifiers),
+		CountryMatch:    hitCountryMatch(hit, m.Country),
+		DownloadID:      downloadID,
+		Entity:          entity,
+		Status:          alertOpen,
+		CreatedAt:       now,
+	}, nil
+}
+
+// raiseSearchAlerts raises an alert for each of the entities matching name at or above minMatch
+// (or monitorMinMatch if it's higher) across monitorLists. Entities which already have an unresolved
+// alert from searching the same name aren't raised again.
+func (s *searcher) raiseSearchAlerts(repo alertRepository, name string, minMatch float64, actor string) ([]*screeningAlert, error) {
+	if minMatch < monitorMinMatch {
+		minMatch = monitorMinMatch
+	}
+	query := strings.ToLower(strings.TrimSpace(name))
+
+	now := time.Now()
+	var out []*screeningAlert
+	for _, hit := range s.searchWatchHits(monitorLists, name, "", minMatch, nil) {
+		entity, err := json.Marshal(hit.Entity)
+		if err != nil {
+			return out, fmt.Errorf("problem encoding %s entity %s: %v", hit.Source, hit.EntityID, err)
+		}
+		alert := &screeningAlert{
+			ID:        base.ID(),
+			Origin:    alertOriginSearch,
+			Query:     query,
+			List:      hit.List,
+			Source:    hit.Source,
+			EntityID:  hit.EntityID,
+			Name:      hitName(hit),
+			Match:     hit.Match,
+			Entity:    entity,
+			Status:    alertOpen,
+			CreatedAt: now,
+		}
+		err = repo.addAlert(alert, actor)
+		if err != nil && database.UniqueViolation(err) {
+			// the search hit already has an unresolved alert
+			existing, err := repo.listAlerts(alertFilter{openKey: alert.openKey(alert.Status).String, limit: 1})
+			if err != nil {
+				return out, fmt.Errorf("problem reading alert: %v", err)
+			}
+			if len(existing) == 0 {
+				// it was resolved after the insert failed
+				return out, errAlertConflict
+			}
+			out = append(out, existing[0])
+			continue
+		}
+		if err != nil {
+			return out, fmt.Errorf("problem saving alert: %w", err)
+		}
+		alert.Entity = nil
+		out = append(out, alert)
+	}
+	return out, nil
+}
+
+// openKey identifies the search hit an alert was raised on while it's unresolved at status. A unique
+// index on it keeps each search hit to one unresolved alert, and it's NULL on every other alert.
+func (a *screeningAlert) openKey(status alertStatus) sql.NullString {
+	if a.Origin != alertOriginSearch || !status.unresolved() {
+		return sql.NullString{}
+	}
+	entity := a.EntityID
+	if entity == "" {
+		entity = a.Name
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{string(a.Origin), a.Query, a.List, entity}, "\x00")))
+	return sql.NullString{String: hex.EncodeToString(sum[:]), Valid: true}
+}
+
+type alertFilter struct {
+	alertID       string
+	origin        alertOrigin
+	monitoredType monitoredType
+	monitoredID   string
+	query         string
+	list          string
+	openKey       string
+	statuses      []alertStatus
+	assignee      string
+	unassigned    bool
+	since         time.Time
+
+	limit, offset int
+}
+
+// HTTP routes
+
+func getAlertID(w http.ResponseWriter, r *http.Request) string {
+	v, ok := mux.Vars(r)["alertID"]
+	if !ok || v == "" {
+		moovhttp.Problem(w, errNoAlertID)
+		return ""
+	}
+	return v
+}
+
+func addAlertRoutes(logger log.Logger, r *mux.Router, repo alertRepository) {
+	r.Methods("GET").Path("/alerts").HandlerFunc(listScreeningAlerts(logger, repo))
+	r.Methods("GET").Path("/alerts/{alertID}").HandlerFunc(getScreeningAlert(logger, repo))
+	r.Methods("PATCH").Path("/alerts/{alertID}").HandlerFunc(updateScreeningAlert(logger, repo))
+	r.Methods("GET").Path("/alerts/{alertID}/comments").HandlerFunc(listAlertComments(logger, repo))
+	r.Methods("POST").Path("/alerts/{alertID}/comments").HandlerFunc(addAlertComment(logger, repo))
+	r.Methods("GET").Path("/alerts/{alertID}/audit").HandlerFunc(listAlertAuditLog(logger, repo))
+}
+
+type createSearchAlertsRequest struct {
+	Name     string  `json:"name"`
+	MinMatch float64 `json:"minMatch"`
+}
+
+// createSearchAlerts raises alerts on the entities matching a name, like a search would return them
+func createSearchAlerts(logger log.Logger, searcher *searcher, repo alertRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		var req createSearchAlertsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if strings.TrimSpace(req.Name) == "" {
+			moovhttp.Problem(w, errNoSearchParams)
+			return
+		}
+		if req.MinMatch < 0 || req.MinMatch > 1 {
+			moovhttp.Problem(w, fmt.Errorf("invalid minMatch %v", req.MinMatch))
+			return
+		}
+
+		alerts, err := searcher.raiseSearchAlerts(repo, req.Name, req.MinMatch, moovhttp.GetUserID(r))
+		if err != nil {
+			if errors.Is(err, errAlertConflict) {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			moovhttp.Problem(w, err)
+			return
+		}
+		if alerts == nil {
+			alerts = []*screeningAlert{}
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+			"userID":    log.String(moovhttp.GetUserID(r)),
+		}).Logf("raised %d alerts searching name", len(alerts))
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(alerts)
+	}
+}
+
+// readAlertFilter reads the query parameters alerts are filtered by
+func readAlertFilter(r *http.Request) (alertFilter, error) {
+	q := r.URL.Query()
+	filter := alertFilter{
+		origin:        alertOrigin(q.Get("origin")),
+		monitoredType: monitoredType(q.Get("monitoredType")),
+		monitoredID:   q.Get("monitoredID"),
+		assignee:      q.Get("assignee"),
+		limit:         extractSearchLimit(r),
+	}
+	if filter.origin != "" && !filter.origin.valid() {
+		return filter, fmt.Errorf("invalid origin %q", filter.origin)
+	}
+	if filter.monitoredType != "" && !filter.monitoredType.valid() {
+		return filter, fmt.Errorf("invalid monitoredType %q", filter.monitoredType)
+	}
+	if v := q.Get("query"); v != "" {
+		filter.query = strings.ToLower(strings.TrimSpace(v))
+	}
+	if v := q.Get("status"); v != "" {
+		statuses, err := readAlertStatuses(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.statuses = statuses
+	}
+	if v := q.Get("unassigned"); v != "" {
+		unassigned, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid unassigned %q", v)
+		}
+		filter.unassigned = unassigned
+	}
+	if filter.unassigned && filter.assignee != "" {
+		return filter, errors.New("assignee and unassigned can't be combined")
+	}
+	if v := q.Get("list"); v != "" {
+		lists, err := validateWatchLists([]string{v})
+		if err != nil {
+			return filter, err
+		}
+		filter.list = lists[0]
+	}
+	if v := q.Get("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid since %q", v)
+		}
+		filter.since = since
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("invalid offset %q", v)
+		}
+		filter.offset = n
+	}
+	return filter, nil
+}
+
+func listScreeningAlerts(logger log.Logger, repo alertRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		filter, err := readAlertFilter(r)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		alerts, err := repo.listAlerts(filter)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if alerts == nil {
+			alerts = []*screeningAlert{}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(alerts)
+	}
+}
+
+func getScreeningAlert(logger log.Logger, repo alertRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		alertID := getAlertID(w, r)
+		if alertID == "" {
+			return
+		}
+		alert, err := repo.getAlert(alertID)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if alert == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(alert)
+	}
+}
+
+// Repository
+
+type alertRepository interface {
+	// addAlert stores alert and records who (if anyone) raised it in the audit log
+	addAlert(alert *screeningAlert, actor string) error
+
+	// getAlert returns the alert with the entity it matched, or nil if it doesn't exist
+	getAlert(alertID string) (*screeningAlert, error)
+
+	// listAlerts returns alerts without their entities, newest first
+	listAlerts(filter alertFilter) ([]*screeningAlert, error)
+
+	// updateAlert applies update to an alert, recording each change in the audit log, and returns
+	// the updated alert or nil if it doesn't exist
+	updateAlert(alertID string, update alertUpdate, actor string, now time.Time) (*screeningAlert, error)
+
+	addComment(comment *alertComment) error
+	listComments(alertID string) ([]*alertComment, error)
+
+	// listAuditLog returns an alert's audit log, oldest first
+	listAuditLog(alertID string) ([]*alertAuditEntry, error)
+}
+
+type sqliteAlertRepository struct {
+	db *sql.DB
+}
+
+func (r *sqliteAlertRepository) close() error {
+	return r.db.Close()
+}
+
+func (r *sqliteAlertRepository) addAlert(alert *screeningAlert, actor string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := `insert into screening_alerts (id, origin, monitored_type, monitored_id, search_query, list, source, entity_id, name, match_score, identifier_match, country_match, download_id, entity, status, open_key, created_at) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	_, err = tx.Exec(query, alert.ID, alert.Origin, alert.MonitoredType, alert.MonitoredID, alert.Query, alert.List, alert.Source, alert.EntityID, alert.Name,
+		alert.Match, alert.IdentifierMatch, alert.CountryMatch, alert.DownloadID, string(alert.Entity), alert.Status, alert.openKey(alert.Status), alert.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := addAuditEntry(tx, alert.ID, actor, alertActionCreated, "", string(alert.Status), alert.CreatedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqliteAlertRepository) getAlert(alertID string) (*screeningAlert, error) {
+	alerts, err := r.queryAlerts(true, alertFilter{alertID: alertID, limit: 1})
+	if err != nil || len(alerts) == 0 {
+		return nil, err
+	}
+	return alerts[0], nil
+}
+
+func (r *sqliteAlertRepository) listAlerts(filter alertFilter) ([]*screeningAlert, error) {
+	return r.queryAlerts(false, filter)
+}
+
+func (r *sqliteAlertRepository) queryAlerts(withEntity bool, filter alertFilter) ([]*screeningAlert, error) {
+	columns := `id, origin, monitored_type, monitored_id, search_query, list, source, entity_id, name, match_score, identifier_match, country_match, download_id, status, assignee, created_at, updated_at`
+	if withEntity {
+		columns += `, entity`
+	}
+
+	var where []string
+	var args []interface{}
+	if filter.alertID != "" {
+		where = append(where, "id = ?")
+		args = append(args, filter.alertID)
+	}
+	if filter.origin != "" {
+		where = append(where, "origin = ?")
+		args = append(args, filter.origin)
+	}
+	if filter.monitoredType != "" {
+		where = append(where, "monitored_type = ?")
+		args = append(args, filter.monitoredType)
+	}
+	if filter.monitoredID != "" {
+		where = append(where, "monitored_id = ?")
+		args = append(args, filter.monitoredID)
+	}
+	if filter.query != "" {
+		where = append(where, "search_query = ?")
+		args = append(args, filter.query)
+	}
+	if filter.list != "" {
+		where = append(where, "list = ?")
+		args = append(args, filter.list)
+	}
+	if filter.openKey != "" {
+		where = append(where, "open_key = ?")
+		args = append(args, filter.openKey)
+	}
+	if len(filter.statuses) > 0 {
+		var in []string
+		for _, status := range filter.statuses {
+			in = append(in, "?")
+			args = append(args, status)
+		}
+		where = append(where, "status in ("+strings.Join(in, ", ")+")")
+	}
+	if filter.assignee != "" {
+		where = append(where, "assignee = ?")
+		args = append(args, filter.assignee)
+	}
+	if filter.unassigned {
+		where = append(where, "(assignee is null or assignee = '')")
+	}
+	if !filter.since.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, filter.since)
+	}
+
+	query := `select ` + columns + ` from screening_alerts`
+	if len(where) > 0 {
+		query += ` where ` + strings.Join(where, " and ")
+	}
+	query += ` order by created_at desc, id asc limit ? offset ?;`
+	args = append(args, filter.limit, filter.offset)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*screeningAlert
+	for rows.Next() {
+		var alert screeningAlert
+		var origin, mType, mID, query, entityID, name, downloadID, status, assignee, entity sql.NullString
+		var updatedAt sql.NullTime
+		dest := []interface{}{
+			&alert.ID, &origin, &mType, &mID, &query, &alert.List, &alert.Source, &entityID, &name,
+			&alert.Match, &alert.IdentifierMatch, &alert.CountryMatch, &downloadID, &status, &assignee, &alert.CreatedAt, &updatedAt,
+		}
+		if withEntity {
+			dest = append(dest, &entity)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		alert.Origin = alertOrigin(origin.String)
+		alert.MonitoredType = monitoredType(mType.String)
+		alert.MonitoredID = mID.String
+		alert.Query = query.String
+		alert.EntityID = entityID.String
+		alert.Name = name.String
+		alert.DownloadID = downloadID.String
+		alert.Status = alertStatus(status.String)
+		alert.Assignee = assignee.String
+		if updatedAt.Valid {
+			alert.UpdatedAt = &updatedAt.Time
+		}
+		if entity.String != "" {
+			alert.Entity = json.RawMessage(entity.String)
+		}
+		out = append(out, &alert)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlerts__repository(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, repo *sqliteAlertRepository) {
+		now := time.Now().Truncate(time.Second)
+		first := &screeningAlert{ID: "first", Origin: alertOriginMonitoring, Status: alertOpen, MonitoredType: monitoredCustomer, MonitoredID: "cust-1", List: "SDNs", Source: "SDNs",
+			EntityID: "2676", Name: "AL ZAWAHIRI, Dr. Ayman", Match: 0.95, IdentifierMatch: true, DownloadID: "downloadID",
+			Entity: json.RawMessage(`{"entityID":"2676"}`), CreatedAt: now}
+		second := &screeningAlert{ID: "second", Origin: alertOriginMonitoring, Status: alertOpen, MonitoredType: monitoredCompany, MonitoredID: "comp-1", List: "EUCSL", Source: "euConsolidatedSanctionsList",
+			Match: 0.91, Entity: json.RawMessage(`{}`), CreatedAt: now.Add(time.Minute)}
+		require.NoError(t, repo.addAlert(first, ""))
+		require.NoError(t, repo.addAlert(second, ""))
+
+		found, err := repo.getAlert("first")
+		require.NoError(t, err)
+		require.Equal(t, first.ID, found.ID)
+		require.Equal(t, first.Name, found.Name)
+		require.True(t, found.IdentifierMatch)
+		require.JSONEq(t, `{"entityID":"2676"}`, string(found.Entity))
+
+		found, err = repo.getAlert("missing")
+		require.NoError(t, err)
+		require.Nil(t, found)
+
+		// newest first, without entities
+		alerts, err := repo.listAlerts(alertFilter{limit: 10})
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+		require.Equal(t, "second", alerts[0].ID)
+		require.Empty(t, alerts[0].Entity)
+
+		for _, tc := range []struct {
+			filter   alertFilter
+			expected string
+		}{
+			{alertFilter{monitoredType: monitoredCustomer, limit: 10}, "first"},
+			{alertFilter{monitoredID: "comp-1", limit: 10}, "second"},
+			{alertFilter{list: "SDNs", limit: 10}, "first"},
+			{alertFilter{since: now.Add(time.Second), limit: 10}, "second"},
+			{alertFilter{limit: 1, offset: 1}, "first"},
+		} {
+			alerts, err := repo.listAlerts(tc.filter)
+			require.NoError(t, err)
+			require.Len(t, alerts, 1)
+			require.Equal(t, tc.expected, alerts[0].ID)
+		}
+	}
+
+	// SQLite tests
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+	check(t, &sqliteAlertRepository{sqliteDB.DB})
+
+	// MySQL tests
+	mysqlDB := database.TestMySQLConnection(t)
+	check(t, &sqliteAlertRepository{mysqlDB})
+}
+
+func TestAlerts__routes(t *testing.T) {
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteAlertRepository{db.DB}
+	require.NoError(t, repo.addAlert(&screeningAlert{ID: "alertID", Origin: alertOriginMonitoring, MonitoredType: monitoredCustomer, MonitoredID: "cust-1", List: "SDNs",
+		Source: "SDNs", EntityID: "2676", Match: 0.95, Entity: json.RawMessage(`{"entityID":"2676"}`), Status: alertOpen, CreatedAt: time.Now()}, ""))
+
+	router := mux.NewRouter()
+	addAlertRoutes(log.NewNopLogger(), router, repo)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/alerts?monitoredType=customer&list=sdns", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var alerts []screeningAlert
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&alerts))
+	require.Len(t, alerts, 1)
+	require.Empty(t, alerts[0].Entity)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/alerts?monitoredID=other", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "[]\n", w.Body.String())
+
+	for _, query := range []string{"monitoredType=vessel", "list=OFAC", "since=yesterday", "offset=-1"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/alerts?"+query, nil))
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/alerts/alertID", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var alert screeningAlert
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&alert))
+	require.JSONEq(t, `{"entityID":"2676"}`, string(alert.Entity))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/alerts/missing", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,450 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman"
+	"github.com/moov-io/watchman/internal/bundle"
+)
+
+const (
+	bundlesPath = "/bundles"
+
+	defaultBundlePollInterval = time.Minute
+
+	// maxBundleSize limits the size of uploaded bundles
+	maxBundleSize = 1 << 30
+)
+
+var errBundlesDisabled = errors.New("bundles are not enabled, set BUNDLE_PUBLIC_KEY")
+
+// bundleLoader verifies offline data bundles and swaps their data into the searcher.
+// Bundles are read from a watched directory or uploaded to the admin server.
+type bundleLoader struct {
+	searcher  *searcher
+	repo      downloadRepository
+	publicKey ed25519.PublicKey
+	logger    log.Logger
+
+	// dir is watched for new bundles, uploaded bundles are also kept here
+	dir      string
+	interval time.Duration
+
+	// mu serializes loads
+	mu      sync.Mutex
+	current *bundle.Manifest
+	// seen are the modification times of bundles in dir which were tried
+	seen map[string]time.Time
+}
+
+// newBundleLoader reads the bundle config from environment variables. Bundles are
+// disabled (and nil is returned) when BUNDLE_PUBLIC_KEY is unset.
+func newBundleLoader(logger log.Logger, searcher *searcher, repo downloadRepository) (*bundleLoader, error) {
+	keyPath := os.Getenv("BUNDLE_PUBLIC_KEY")
+	dir := os.Getenv("BUNDLE_DIRECTORY")
+	if keyPath == "" {
+		if dir != "" {
+			return nil, errors.New("BUNDLE_DIRECTORY requires BUNDLE_PUBLIC_KEY")
+		}
+		return nil, nil
+	}
+	key, err := bundle.ReadPublicKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := defaultBundlePollInterval
+	if v := os.Getenv("BUNDLE_POLL_INTERVAL"); v != "" {
+		dur, err := time.ParseDuration(v)
+		if err != nil || dur <= 0 {
+			return nil, fmt.Errorf("invalid BUNDLE_POLL_INTERVAL=%q", v)
+		}
+		interval = dur
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		logger.Logf("watching %s for bundles every %v", dir, interval)
+	}
+
+	return &bundleLoader{
+		searcher:  searcher,
+		repo:      repo,
+		publicKey: key,
+		logger:    logger,
+		dir:       dir,
+		interval:  interval,
+		seen:      make(map[string]time.Time),
+	}, nil
+}
+
+// load verifies and extracts a bundle then refreshes the searcher from its files
+func (l *bundleLoader) load(r io.Reader) (*bundle.Manifest, *DownloadStats, error) {
+	dir, err := os.MkdirTemp("", "watchman-bundle-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	manifest, err := bundle.Extract(r, l.publicKey, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	l.logger.Info().With(log.Fields{
+		"bundle": log.String(manifest.ID),
+	}).Logf("loading bundle %s", manifest.ID)
+
+	// lists which aren't in the bundle keep serving their data
+	var lists []string
+	for _, list := range allLists {
+		if _, exists := manifest.Lists[list]; exists {
+			lists = append(lists, list)
+		}
+	}
+	if len(lists) == 0 {
+		return nil, nil, fmt.Errorf("bundle %s has no lists", manifest.ID)
+	}
+	stats, err := l.searcher.refreshLists(dir, lists)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bundle %s: %v", manifest.ID, err)
+	}
+	if err := l.repo.recordStats(stats); err != nil {
+		return nil, nil, fmt.Errorf("bundle %s: recording stats: %v", manifest.ID, err)
+	}
+	if len(stats.Errors) > 0 {
+		// lists which failed keep serving older data, so the bundle isn't the one being served
+		l.logger.Warn().With(log.Fields{
+			"bundle": log.String(manifest.ID),
+		}).Logf("bundle %s partially loaded: %v", manifest.ID, stats)
+		return manifest, stats, nil
+	}
+	l.current = manifest
+
+	l.logger.Info().With(log.Fields{
+		"bundle": log.String(manifest.ID),
+	}).Logf("serving bundle %s created %v ago", manifest.ID, time.Since(manifest.CreatedAt))
+
+	return manifest, stats, nil
+}
+
+// loadNewest loads the most recently modified bundle in dir which hasn't been tried. Bundles
+// created before the one being served are skipped. No stats are returned when nothing was loaded.
+func (l *bundleLoader) loadNewest() (*bundle.Manifest, *DownloadStats, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	matches, err := filepath.Glob(filepath.Join(l.dir, "*.tar.gz"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var newest string
+	var newestAt time.Time
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if seen, exists := l.seen[path]; exists && seen.Equal(info.ModTime()) {
+			continue
+		}
+		if newest == "" || info.ModTime().After(newestAt) {
+			newest, newestAt = path, info.ModTime()
+		}
+	}
+	if newest == "" {
+		return nil, nil, nil
+	}
+	// bundles which fail are retried once they're modified
+	l.seen[newest] = newestAt
+
+	fd, err := os.Open(newest)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fd.Close()
+
+	manifest, err := bundle.ReadManifest(fd, l.publicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", filepath.Base(newest), err)
+	}
+	if err := l.checkNewer(manifest); err != nil {
+		l.logger.Warn().Logf("skipping %v", err)
+		return nil, nil, nil
+	}
+	if _, err := fd.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+
+	manifest, stats, err := l.load(fd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", filepath.Base(newest), err)
+	}
+	return manifest, stats, nil
+}
+
+// checkNewer returns an error unless manifest was created after the bundle being served
+func (l *bundleLoader) checkNewer(manifest *bundle.Manifest) error {
+	if l.current != nil && !manifest.CreatedAt.After(l.current.CreatedAt) {
+		return fmt.Errorf("bundle %s isn't newer than bundle %s being served", manifest.ID, l.current.ID)
+	}
+	return nil
+}
+
+// upload loads a bundle sent to the admin server. Loaded bundles are moved into dir so
+// they're served again after a restart.
+func (l *bundleLoader) upload(r io.Reader) (*bundle.Manifest, *DownloadStats, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fd, err := os.CreateTemp(l.dir, ".upload-*.tmp")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.Remove(fd.Name())
+	defer fd.Close()
+
+	if _, err := io.Copy(fd, r); err != nil {
+		return nil, nil, fmt.Errorf("reading bundle: %v", err)
+	}
+	if _, err := fd.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+	manifest, err := bundle.ReadManifest(fd, l.publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	// uploads can't replace the data with an older bundle's
+	if err := l.checkNewer(manifest); err != nil {
+		return nil, nil, err
+	}
+	if _, err := fd.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+
+	manifest, stats, err := l.load(fd)
+	if err != nil {
+		return nil, nil, err
+	}
+	if l.dir != "" {
+		where := filepath.Join(l.dir, bundle.Filename(manifest.ID))
+		if err := os.Rename(fd.Name(), where); err != nil {
+			l.logger.Error().LogErrorf("problem keeping bundle %s: %v", manifest.ID, err)
+		} else if info, err := os.Stat(where); err == nil {
+			l.seen[where] = info.ModTime()
+		}
+	}
+	return manifest, stats, nil
+}
+
+// watch polls dir for new bundles and sends the stats of each loaded bundle to updates
+func (l *bundleLoader) watch(updates chan *DownloadStats) {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		_, stats, err := l.loadNewest()
+		if err != nil {
+			l.logger.Error().LogErrorf("problem loading bundle: %v", err)
+			continue
+		}
+		if stats != nil {
+			updates <- stats
+		}
+	}
+}
+
+type bundleLoadResponse struct {
+	Bundle   *bundle.Manifest `json:"bundle"`
+	Download *DownloadStats   `json:"download"`
+}
+
+func loadBundleHandler(logger log.Logger, loader *bundleLoader, updates chan *DownloadStats) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if loader == nil {
+			moovhttp.Problem(w, errBundlesDisabled)
+			return
+		}
+
+		manifest, stats, err := loader.upload(http.MaxBytesReader(w, r.Body, maxBundleSize))
+		if err != nil {
+			logger.Error().LogErrorf("admin: problem loading bundle: %v", err)
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		go func() {
+			updates <- stats
+		}()
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+			"bundle":    log.String(manifest.ID),
+		}).Log("admin: loaded bundle")
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(bundleLoadResponse{
+			Bundle:   manifest,
+			Download: stats,
+		})
+	}
+}
+
+// bundleCommand runs the `bundle` subcommands and returns the exit code
+func bundleCommand(logger log.Logger, args []string) int {
+	usage := "usage: server bundle [create|keygen] [flags]"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("bundle create", flag.ContinueOnError)
+		output := fs.String("output", ".", "Directory to write the bundle into")
+		keyPath := fs.String("signing-key", os.Getenv("BUNDLE_SIGNING_KEY"), "PEM encoded ed25519 private key to sign the bundle with")
+		initialDir := fs.String("initial-dir", os.Getenv("INITIAL_DATA_DIRECTORY"), "Directory of files to bundle instead of downloading them")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		path, err := createBundle(logger, *keyPath, *initialDir, *output)
+		if err != nil {
+			logger.LogErrorf("ERROR: creating bundle: %v", err)
+			return 1
+		}
+		logger.Logf("wrote bundle %s", path)
+
+	case "keygen":
+		fs := flag.NewFlagSet("bundle keygen", flag.ContinueOnError)
+		output := fs.String("output", ".", "Directory to write bundle.key and bundle.pub into")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if err := writeBundleKeys(*output); err != nil {
+			logger.LogErrorf("ERROR: generating keys: %v", err)
+			return 1
+		}
+		logger.Logf("wrote bundle.key and bundle.pub to %s", *output)
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	return 0
+}
+
+// createBundle downloads every list (or reads them from initialDir), checks they all
+// parse and writes a signed bundle of their files into output.
+func createBundle(logger log.Logger, keyPath, initialDir, output string) (string, error) {
+	if keyPath == "" {
+		return "", errors.New("missing -signing-key")
+	}
+	key, err := bundle.ReadPrivateKey(keyPath)
+	if err != nil {
+		return "", err
+	}
+
+	s := newSearcher(logger, newPipeliner(log.NewNopLogger()), 1)
+	files := make(sourceFiles)
+	parsed := s.readLists(initialDir, false, files)
+	defer removeDownloads(initialDir, files)
+
+	var errs []error
+	for _, list := range parsed.lists {
+		if err := parsed.errors[list]; err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", list, err))
+		}
+	}
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+	if _, err := s.indexLists(parsed, time.Now()); err != nil {
+		return "", err
+	}
+
+	fd, err := os.CreateTemp(output, ".bundle-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(fd.Name())
+
+	manifest, err := bundle.Write(fd, key, watchman.Version, time.Now(), files)
+	if cerr := fd.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	where := filepath.Join(output, bundle.Filename(manifest.ID))
+	if err := os.Rename(fd.Name(), where); err != nil {
+		return "", err
+	}
+	return where, nil
+}
+
+// removeDownloads cleans up the temporary directories files were downloaded into
+func removeDownloads(initialDir string, files sourceFiles) {
+	for _, paths := range files {
+		for _, path := range paths {
+			dir := filepath.Dir(path)
+			if dir != filepath.Clean(initialDir) && strings.HasPrefix(filepath.Base(dir), "downloader") {
+				os.RemoveAll(dir)
+			}
+		}
+	}
+}
+
+func writeBundleKeys(dir string) error {
+	pub, key, err := bundle.GenerateKey()
+	if err != nil {
+		return err
+	}
+	keyBytes, err := bundle.MarshalPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	pubBytes, err := bundle.MarshalPublicKey(pub)
+	if err != nil {
+		return err
+	}
+	if err := writeNewFile(filepath.Join(dir, "bundle.key"), keyBytes, 0600); err != nil {
+		return err
+	}
+	return writeNewFile(filepath.Join(dir, "bundle.pub"), pubBytes, 0644)
+}
+
+// writeNewFile writes a file which must not already exist
+func writeNewFile(path string, data []byte, perm os.FileMode) error {
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	_, err = fd.Write(data)
+	if cerr := fd.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
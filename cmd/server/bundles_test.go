@@ -0,0 +1,201 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/bundle"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestBundleKeys(t *testing.T) (string, ed25519.PrivateKey) {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, writeBundleKeys(dir))
+
+	key, err := bundle.ReadPrivateKey(filepath.Join(dir, "bundle.key"))
+	require.NoError(t, err)
+	return filepath.Join(dir, "bundle.pub"), key
+}
+
+func writeTestBundle(t *testing.T, dir string, key ed25519.PrivateKey, createdAt time.Time) string {
+	t.Helper()
+
+	files := make(sourceFiles)
+	files.add("SDNs", copyTestData(t, t.TempDir(), createdAt, "sdn.csv", "add.csv", "alt.csv", "sdn_comments.csv")...)
+	files.add("DPs", copyTestData(t, t.TempDir(), createdAt, "dpl.txt")...)
+	return writeTestBundleFiles(t, dir, key, createdAt, files)
+}
+
+func writeTestBundleFiles(t *testing.T, dir string, key ed25519.PrivateKey, createdAt time.Time, files sourceFiles) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	manifest, err := bundle.Write(&buf, key, "v0.0.0", createdAt, files)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, bundle.Filename(manifest.ID))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	require.NoError(t, os.Chtimes(path, createdAt, createdAt))
+	return path
+}
+
+func TestBundles__config(t *testing.T) {
+	t.Setenv("BUNDLE_PUBLIC_KEY", "")
+	t.Setenv("BUNDLE_DIRECTORY", "")
+	loader, err := newBundleLoader(log.NewNopLogger(), nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, loader)
+
+	t.Setenv("BUNDLE_DIRECTORY", t.TempDir())
+	_, err = newBundleLoader(log.NewNopLogger(), nil, nil)
+	require.ErrorContains(t, err, "BUNDLE_DIRECTORY requires BUNDLE_PUBLIC_KEY")
+
+	pub, _ := writeTestBundleKeys(t)
+	t.Setenv("BUNDLE_PUBLIC_KEY", pub)
+	loader, err = newBundleLoader(log.NewNopLogger(), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, defaultBundlePollInterval, loader.interval)
+
+	t.Setenv("BUNDLE_POLL_INTERVAL", "often")
+	_, err = newBundleLoader(log.NewNopLogger(), nil, nil)
+	require.ErrorContains(t, err, "invalid BUNDLE_POLL_INTERVAL")
+}
+
+func TestBundles__handler(t *testing.T) {
+	updates := make(chan *DownloadStats, 1)
+
+	// bundles aren't enabled
+	w := httptest.NewRecorder()
+	loadBundleHandler(log.NewNopLogger(), nil, updates)(w, httptest.NewRequest("POST", bundlesPath, nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	pub, _ := writeTestBundleKeys(t)
+	t.Setenv("BUNDLE_PUBLIC_KEY", pub)
+	t.Setenv("BUNDLE_DIRECTORY", t.TempDir())
+	loader, err := newBundleLoader(log.NewNopLogger(), nil, nil)
+	require.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	loadBundleHandler(log.NewNopLogger(), loader, updates)(w, httptest.NewRequest("GET", bundlesPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	// bundles signed by another key are rejected
+	_, otherKey := writeTestBundleKeys(t)
+	body, err := os.ReadFile(writeTestBundle(t, t.TempDir(), otherKey, time.Now()))
+	require.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	loadBundleHandler(log.NewNopLogger(), loader, updates)(w, httptest.NewRequest("POST", bundlesPath, bytes.NewReader(body)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "invalid signature")
+
+	entries, err := os.ReadDir(loader.dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestBundles__loadNewest(t *testing.T) {
+	pub, key := writeTestBundleKeys(t)
+	dir := t.TempDir()
+	t.Setenv("BUNDLE_PUBLIC_KEY", pub)
+	t.Setenv("BUNDLE_DIRECTORY", dir)
+
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteDownloadRepository{db.DB, log.NewNopLogger()}
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	loader, err := newBundleLoader(log.NewNopLogger(), s, repo)
+	require.NoError(t, err)
+
+	// nothing to load
+	_, stats, err := loader.loadNewest()
+	require.NoError(t, err)
+	require.Nil(t, stats)
+
+	when := time.Date(2023, time.March, 3, 9, 4, 0, 0, time.UTC)
+	writeTestBundle(t, dir, key, when)
+
+	manifest, stats, err := loader.loadNewest()
+	require.NoError(t, err)
+	require.Equal(t, "20230303T090400Z", manifest.ID)
+	require.NotZero(t, stats.SDNs)
+	require.Equal(t, when, stats.Freshness["SDNs"].RefreshedAt.In(time.UTC))
+
+	// the same bundle isn't loaded twice
+	_, stats, err = loader.loadNewest()
+	require.NoError(t, err)
+	require.Nil(t, stats)
+
+	// nor are older bundles
+	writeTestBundle(t, dir, key, when.Add(-time.Hour))
+	_, stats, err = loader.loadNewest()
+	require.NoError(t, err)
+	require.Nil(t, stats)
+	require.Equal(t, "20230303T090400Z", loader.current.ID)
+}
+
+func TestBundles__upload(t *testing.T) {
+	pub, key := writeTestBundleKeys(t)
+	t.Setenv("BUNDLE_PUBLIC_KEY", pub)
+	t.Setenv("BUNDLE_DIRECTORY", t.TempDir())
+	t.Setenv("PEP_DATA_FILE", filepath.Join(t.TempDir(), "missing.json"))
+
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteDownloadRepository{db.DB, log.NewNopLogger()}
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	loader, err := newBundleLoader(log.NewNopLogger(), s, repo)
+	require.NoError(t, err)
+
+	upload := func(path string) (*bundle.Manifest, *DownloadStats, error) {
+		fd, err := os.Open(path)
+		require.NoError(t, err)
+		defer fd.Close()
+		return loader.upload(fd)
+	}
+
+	// the bundle's PEP dataset is read instead of PEP_DATA_FILE
+	when := time.Date(2023, time.March, 3, 9, 4, 0, 0, time.UTC)
+	files := make(sourceFiles)
+	files.add("PEPs", copyTestData(t, t.TempDir(), when, "pep.json")...)
+
+	manifest, stats, err := upload(writeTestBundleFiles(t, t.TempDir(), key, when, files))
+	require.NoError(t, err)
+	require.Empty(t, stats.Errors)
+	require.NotZero(t, stats.PoliticallyExposedPersons)
+	require.Equal(t, manifest.ID, loader.current.ID)
+
+	// older bundles can't be uploaded
+	_, _, err = upload(writeTestBundle(t, t.TempDir(), key, when.Add(-time.Hour)))
+	require.ErrorContains(t, err, "isn't newer")
+	require.Equal(t, manifest.ID, loader.current.ID)
+
+	// partially loaded bundles aren't the one being served
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pep.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0644))
+	files = make(sourceFiles)
+	files.add("PEPs", path)
+
+	_, stats, err = upload(writeTestBundleFiles(t, t.TempDir(), key, when.Add(time.Hour), files))
+	require.NoError(t, err)
+	require.NotEmpty(t, stats.Errors)
+	require.Equal(t, manifest.ID, loader.current.ID)
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/csl"
+
+	"github.com/gorilla/mux"
+)
+
+// diffedLists are the lists with stable IDs which are compared between refreshes
+var diffedLists = []string{"SDNs", "EUCSL", "UKCSL", "UKSanctionsList"}
+
+// ListChanges are the entities added, removed or modified on a list since the previous refresh
+type ListChanges struct {
+	Added    []EntityChange `json:"added,omitempty"`
+	Removed  []EntityChange `json:"removed,omitempty"`
+	Modified []EntityChange `json:"modified,omitempty"`
+}
+
+// EntityChange identifies an entity by its ID on the list
+type EntityChange struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ChangeSummary counts the changes to a list
+type ChangeSummary struct {
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+}
+
+func (c *ListChanges) summary() ChangeSummary {
+	return ChangeSummary{
+		Added:    len(c.Added),
+		Removed:  len(c.Removed),
+		Modified: len(c.Modified),
+	}
+}
+
+// changed reports if c counts any change, nil summaries are of lists which weren't compared
+func (c *ChangeSummary) changed() bool {
+	return c != nil && (c.Added > 0 || c.Removed > 0 || c.Modified > 0)
+}
+
+func (c *ListChanges) empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0
+}
+
+// listEntity is an entity's name and a digest of its records
+type listEntity struct {
+	name string
+	sum  [sha256.Size]byte
+}
+
+// listEntities are the entities of a list keyed by their stable ID
+type listEntities map[string]listEntity
+
+// add includes a record in the entity's digest. Records sharing an ID (e.g. an SDN's
+// addresses) are combined in the order they're added.
+func (e listEntities) add(id, name string, record any) {
+	bs, _ := json.Marshal(record)
+	entity, exists := e[id]
+	if !exists {
+		entity.name = name
+	}
+	h := sha256.New()
+	h.Write(entity.sum[:])
+	h.Write(bs)
+	copy(entity.sum[:], h.Sum(nil))
+	e[id] = entity
+}
+
+// diff returns the changes from prev to e
+func (e listEntities) diff(prev listEntities) *ListChanges {
+	changes := &ListChanges{}
+	for id, entity := range e {
+		old, exists := prev[id]
+		switch {
+		case !exists:
+			changes.Added = append(changes.Added, EntityChange{ID: id, Name: entity.name})
+		case old.sum != entity.sum:
+			changes.Modified = append(changes.Modified, EntityChange{ID: id, Name: entity.name})
+		}
+	}
+	for id, entity := range prev {
+		if _, exists := e[id]; !exists {
+			changes.Removed = append(changes.Removed, EntityChange{ID: id, Name: entity.name})
+		}
+	}
+	for _, c := range [][]EntityChange{changes.Added, changes.Removed, changes.Modified} {
+		sort.Slice(c, func(i, j int) bool { return c[i].ID < c[j].ID })
+	}
+	return changes
+}
+
+func sdnEntities(sdns []*SDN, addrs []*Address, alts []*Alt) listEntities {
+	out := make(listEntities)
+	for _, sdn := range sdns {
+		if sdn == nil {
+			continue
+		}
+		out.add(sdn.EntityID, sdn.SDNName, sdn.SDN)
+	}
+	for _, addr := range addrs {
+		if addr == nil {
+			continue
+		}
+		if entity, exists := out[addr.Address.EntityID]; exists {
+			out.add(addr.Address.EntityID, entity.name, addr.Address)
+		}
+	}
+	for _, alt := range alts {
+		if alt == nil {
+			continue
+		}
+		if entity, exists := out[alt.AlternateIdentity.EntityID]; exists {
+			out.add(alt.AlternateIdentity.EntityID, entity.name, alt.AlternateIdentity)
+		}
+	}
+	return out
+}
+
+func euCSLEntities(records []*Result[csl.EUCSLRecord]) listEntities {
+	out := make(listEntities)
+	for _, r := range records {
+		if r == nil {
+			continue
+		}
+		record := r.Data
+		record.FileGenerationDate = "" // changes with every export
+		out.add(strconv.Itoa(record.EntityLogicalID), firstOf(record.NameAliasWholeNames), record)
+	}
+	return out
+}
+
+func ukCSLEntities(records []*Result[csl.UKCSLRecord]) listEntities {
+	out := make(listEntities)
+	for _, r := range records {
+		if r == nil {
+			continue
+		}
+		out.add(strconv.Itoa(r.Data.GroupID), firstOf(r.Data.Names), r.Data)
+	}
+	return out
+}
+
+func ukSanctionsListEntities(records []*Result[csl.UKSanctionsListRecord]) listEntities {
+	out := make(listEntities)
+	for _, r := range records {
+		if r == nil {
+			continue
+		}
+		out.add(r.Data.UniqueID, firstOf(r.Data.Names), r.Data)
+	}
+	return out
+}
+
+func firstOf(values []string) string {
+	if len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// diffLists compares the entities of each list against their previous refresh. Lists which
+// haven't loaded before or which kept their previous data are skipped, so a list without
+// changes is one which wasn't compared.
+func diffLists(prev, next map[string]listEntities, kept func(list string) bool) map[string]*ListChanges {
+	out := make(map[string]*ListChanges)
+	for _, list := range diffedLists {
+		if prev[list] == nil || kept(list) {
+			continue
+		}
+		out[list] = next[list].diff(prev[list])
+	}
+	return out
+}
+
+func summarizeChanges(changes map[string]*ListChanges) map[string]ChangeSummary {
+	out := make(map[string]ChangeSummary)
+	for list, c := range changes {
+		if !c.empty() {
+			out[list] = c.summary()
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+type downloadChangesResponse struct {
+	DownloadID string                  `json:"downloadID"`
+	Lists      map[string]*ListChanges `json:"lists"`
+}
+
+func getDownloadChanges(logger log.Logger, repo downloadRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		downloadID := mux.Vars(r)["downloadID"]
+		changes, err := repo.downloadChanges(downloadID)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if changes == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID":  log.String(moovhttp.GetRequestID(r)),
+			"downloadID": log.String(downloadID),
+		}).Log("get download changes")
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(downloadChangesResponse{
+			DownloadID: downloadID,
+			Lists:      changes,
+		}); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+	}
+}
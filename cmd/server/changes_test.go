@@ -0,0 +1,133 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/ofac"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanges__diff(t *testing.T) {
+	prev := make(listEntities)
+	prev.add("1", "NICOLAS MADURO MOROS", map[string]string{"program": "VENEZUELA"})
+	prev.add("2", "VLADIMIR PUTIN", map[string]string{"program": "RUSSIA-EO14024"})
+	prev.add("3", "AL NASER WINGS AIRLINES", nil)
+
+	next := make(listEntities)
+	next.add("1", "NICOLAS MADURO MOROS", map[string]string{"program": "VENEZUELA"})
+	next.add("2", "VLADIMIR PUTIN", map[string]string{"program": "RUSSIA-EO14024", "remarks": "DOB 07 Oct 1952"})
+	next.add("5", "MOHAMMAD JAN KHAN MANGAL", nil)
+	next.add("4", "ZHAO WEI", nil)
+
+	changes := next.diff(prev)
+	require.Equal(t, []EntityChange{{ID: "4", Name: "ZHAO WEI"}, {ID: "5", Name: "MOHAMMAD JAN KHAN MANGAL"}}, changes.Added)
+	require.Equal(t, []EntityChange{{ID: "3", Name: "AL NASER WINGS AIRLINES"}}, changes.Removed)
+	require.Equal(t, []EntityChange{{ID: "2", Name: "VLADIMIR PUTIN"}}, changes.Modified)
+	require.Equal(t, ChangeSummary{Added: 2, Removed: 1, Modified: 1}, changes.summary())
+
+	require.True(t, next.diff(next).empty())
+}
+
+func TestChanges__entities(t *testing.T) {
+	sdns := []*SDN{{SDN: &ofac.SDN{EntityID: "22790", SDNName: "MADURO MOROS, Nicolas"}}}
+	addrs := []*Address{{Address: &ofac.Address{EntityID: "22790", Country: "Venezuela"}}}
+	before := sdnEntities(sdns, addrs, nil)
+
+	// an SDN's addresses are part of the entity
+	moved := sdnEntities(sdns, []*Address{{Address: &ofac.Address{EntityID: "22790", Country: "Cuba"}}}, nil)
+	require.Equal(t, []EntityChange{{ID: "22790", Name: "MADURO MOROS, Nicolas"}}, moved.diff(before).Modified)
+
+	// the EU export date isn't a change to the entity
+	first := []*Result[csl.EUCSLRecord]{{Data: csl.EUCSLRecord{FileGenerationDate: "28/10/2022", EntityLogicalID: 13, NameAliasWholeNames: []string{"Saddam Hussein Al-Tikriti"}}}}
+	second := []*Result[csl.EUCSLRecord]{{Data: csl.EUCSLRecord{FileGenerationDate: "29/10/2022", EntityLogicalID: 13, NameAliasWholeNames: []string{"Saddam Hussein Al-Tikriti"}}}}
+	require.True(t, euCSLEntities(second).diff(euCSLEntities(first)).empty())
+}
+
+func TestChanges__indexLists(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	when := time.Date(2023, time.March, 3, 9, 0, 0, 0, time.UTC)
+
+	// the first refresh has nothing to compare against
+	parsed, _ := testParsedLists(t, "NICOLAS MADURO MOROS")
+	stats, err := s.indexLists(parsed, when)
+	require.NoError(t, err)
+	require.NotEmpty(t, stats.ID)
+	require.Nil(t, stats.Changes)
+
+	parsed, _ = testParsedLists(t, "NICOLAS MADURO MOROS")
+	parsed.OFAC.SDNs = append(parsed.OFAC.SDNs, &ofac.SDN{EntityID: "2", SDNName: "VLADIMIR PUTIN", SDNType: "individual"})
+	stats, err = s.indexLists(parsed, when.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, map[string]ChangeSummary{"SDNs": {Added: 1}}, stats.Changes)
+	require.Equal(t, []EntityChange{{ID: "2", Name: "VLADIMIR PUTIN"}}, stats.changes["SDNs"].Added)
+
+	// lists which keep their previous data haven't changed
+	parsed, _ = testParsedLists(t, "NICOLAS MADURO MOROS")
+	parsed.OFAC = nil
+	parsed.errors["SDNs"] = errors.New("download: connection refused")
+	stats, err = s.indexLists(parsed, when.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Nil(t, stats.Changes)
+}
+
+func TestChanges__route(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, repo *sqliteDownloadRepository) {
+		stats := &DownloadStats{
+			SDNs: 2,
+			Changes: map[string]ChangeSummary{
+				"SDNs": {Added: 1, Modified: 1},
+			},
+			changes: map[string]*ListChanges{
+				"SDNs": {
+					Added:    []EntityChange{{ID: "2", Name: "VLADIMIR PUTIN"}},
+					Modified: []EntityChange{{ID: "1", Name: "NICOLAS MADURO MOROS"}},
+				},
+			},
+		}
+		require.NoError(t, repo.recordStats(stats))
+		require.NotEmpty(t, stats.ID)
+
+		router := mux.NewRouter()
+		addDownloadRoutes(log.NewNopLogger(), router, repo)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/downloads/"+stats.ID+"/changes", nil))
+		w.Flush()
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp downloadChangesResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Equal(t, stats.ID, resp.DownloadID)
+		require.Equal(t, stats.changes, resp.Lists)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/downloads/missing/changes", nil))
+		w.Flush()
+		require.Equal(t, http.StatusNotFound, w.Code)
+	}
+
+	// SQLite tests
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+	check(t, &sqliteDownloadRepository{sqliteDB.DB, log.NewNopLogger()})
+
+	// MySQL tests
+	mysqlDB := database.TestMySQLConnection(t)
+	check(t, &sqliteDownloadRepository{mysqlDB, log.NewNopLogger()})
+}
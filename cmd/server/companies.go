@@ -57,7 +57,8 @@ type CompanyStatus struct {
 }
 
 type companyWatchResponse struct {
-	WatchID string `json:"watchID"`
+	WatchID       string `json:"watchID"`
+	SigningSecret string `json:"signingSecret,omitempty"`
 }
 
 func addCompanyRoutes(logger log.Logger, r *mux.Router, searcher *searcher, companyRepo companyRepository, watchRepo *sqliteWatchRepository) {
@@ -263,12 +264,12 @@ func addCompanyWatch(logger log.Logger, searcher *searcher, repo watchRepository
 			moovhttp.Problem(w, err)
 			return
 		}
-		if req.AuthToken == "" {
-			moovhttp.Problem(w, errNoAuthToken)
+		webhook, err := req.validateDestination()
+		if err != nil {
+			moovhttp.Problem(w, err)
 			return
 		}
-		webhook, err := validateWebhook(req.Webhook)
-		if err != nil {
+		if err := req.validateOptions(false); err != nil {
 			moovhttp.Problem(w, err)
 			return
 		}
@@ -283,6 +284,13 @@ func addCompanyWatch(logger log.Logger, searcher *searcher, repo watchRepository
 			moovhttp.Problem(w, err)
 			return
 		}
+		secret, err := completeWatch(repo, watchID, req)
+		if err != nil {
+			// the client never gets the watch's ID, so don't leave it notifying without its options or secret
+			repo.removeCompanyWatch(companyID, watchID)
+			moovhttp.Problem(w, err)
+			return
+		}
 
 		logger.Info().With(log.Fields{
 			"requestID": log.String(moovhttp.GetRequestID(r)),
@@ -290,7 +298,7 @@ func addCompanyWatch(logger log.Logger, searcher *searcher, repo watchRepository
 
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(companyWatchResponse{watchID})
+		json.NewEncoder(w).Encode(companyWatchResponse{WatchID: watchID, SigningSecret: secret.SigningSecret})
 	}
 }
 
@@ -330,12 +338,12 @@ func addCompanyNameWatch(logger log.Logger, searcher *searcher, repo watchReposi
 			moovhttp.Problem(w, err)
 			return
 		}
-		if req.AuthToken == "" {
-			moovhttp.Problem(w, errNoAuthToken)
+		webhook, err := req.validateDestination()
+		if err != nil {
+			moovhttp.Problem(w, err)
 			return
 		}
-		webhook, err := validateWebhook(req.Webhook)
-		if err != nil {
+		if err := req.validateOptions(true); err != nil {
 			moovhttp.Problem(w, err)
 			return
 		}
@@ -344,6 +352,13 @@ func addCompanyNameWatch(logger log.Logger, searcher *searcher, repo watchReposi
 			moovhttp.Problem(w, err)
 			return
 		}
+		secret, err := completeWatch(repo, watchID, req)
+		if err != nil {
+			// the client never gets the watch's ID, so don't leave it notifying without its options or secret
+			repo.removeCompanyNameWatch(watchID)
+			moovhttp.Problem(w, err)
+			return
+		}
 
 		logger.Info().With(log.Fields{
 			"requestID": log.String(moovhttp.GetRequestID(r)),
@@ -351,7 +366,7 @@ func addCompanyNameWatch(logger log.Logger, searcher *searcher, repo watchReposi
 
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(companyWatchResponse{watchID})
+		json.NewEncoder(w).Encode(companyWatchResponse{WatchID: watchID, SigningSecret: secret.SigningSecret})
 	}
 }
 
@@ -60,7 +60,8 @@ type CustomerStatus struct {
 }
 
 type customerWatchResponse struct {
-	WatchID string `json:"watchID"`
+	WatchID       string `json:"watchID"`
+	SigningSecret string `json:"signingSecret,omitempty"`
 }
 
 func addCustomerRoutes(logger log.Logger, r *mux.Router, searcher *searcher, custRepo *sqliteCustomerRepository, watchRepo *sqliteWatchRepository) {
@@ -222,12 +223,12 @@ func addCustomerNameWatch(logger log.Logger, searcher *searcher, repo *sqliteWat
 			moovhttp.Problem(w, err)
 			return
 		}
-		if req.AuthToken == "" {
-			moovhttp.Problem(w, errNoAuthToken)
+		webhook, err := req.validateDestination()
+		if err != nil {
+			moovhttp.Problem(w, err)
 			return
 		}
-		webhook, err := validateWebhook(req.Webhook)
-		if err != nil {
+		if err := req.validateOptions(true); err != nil {
 			moovhttp.Problem(w, err)
 			return
 		}
@@ -236,6 +237,13 @@ func addCustomerNameWatch(logger log.Logger, searcher *searcher, repo *sqliteWat
 			moovhttp.Problem(w, err)
 			return
 		}
+		secret, err := completeWatch(repo, watchID, req)
+		if err != nil {
+			// the client never gets the watch's ID, so don't leave it notifying without its options or secret
+			repo.removeCustomerNameWatch(watchID)
+			moovhttp.Problem(w, err)
+			return
+		}
 
 		logger.With(log.Fields{
 			"requestID": log.String(moovhttp.GetRequestID(r)),
@@ -244,7 +252,7 @@ func addCustomerNameWatch(logger log.Logger, searcher *searcher, repo *sqliteWat
 
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(customerWatchResponse{watchID}); err != nil {
+		if err := json.NewEncoder(w).Encode(customerWatchResponse{WatchID: watchID, SigningSecret: secret.SigningSecret}); err != nil {
 			moovhttp.Problem(w, err)
 			return
 		}
@@ -260,12 +268,12 @@ func addCustomerWatch(logger log.Logger, searcher *searcher, repo *sqliteWatchRe
 			moovhttp.Problem(w, err)
 			return
 		}
-		if req.AuthToken == "" {
-			moovhttp.Problem(w, errNoAuthToken)
+		webhook, err := req.validateDestination()
+		if err != nil {
+			moovhttp.Problem(w, err)
 			return
 		}
-		webhook, err := validateWebhook(req.Webhook)
-		if err != nil {
+		if err := req.validateOptions(false); err != nil {
 			moovhttp.Problem(w, err)
 			return
 		}
@@ -277,6 +285,13 @@ func addCustomerWatch(logger log.Logger, searcher *searcher, repo *sqliteWatchRe
 			moovhttp.Problem(w, err)
 			return
 		}
+		secret, err := completeWatch(repo, watchID, req)
+		if err != nil {
+			// the client never gets the watch's ID, so don't leave it notifying without its options or secret
+			repo.removeCustomerWatch(customerID, watchID)
+			moovhttp.Problem(w, err)
+			return
+		}
 
 		logger.With(log.Fields{
 			"requestID": log.String(moovhttp.GetRequestID(r)),
@@ -285,7 +300,7 @@ func addCustomerWatch(logger log.Logger, searcher *searcher, repo *sqliteWatchRe
 
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(customerWatchResponse{watchID}); err != nil {
+		if err := json.NewEncoder(w).Encode(customerWatchResponse{WatchID: watchID, SigningSecret: secret.SigningSecret}); err != nil {
 			moovhttp.Problem(w, err)
 			return
 		}
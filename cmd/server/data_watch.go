@@ -0,0 +1,193 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/ftm"
+	"github.com/moov-io/watchman/pkg/pep"
+)
+
+const defaultDataWatchInterval = 30 * time.Second
+
+// dataFileLists maps the (lowercased) name of each file in a data directory to the list it's read into
+var dataFileLists = map[string]string{
+	"add.csv":               "SDNs",
+	"alt.csv":               "SDNs",
+	"sdn.csv":               "SDNs",
+	"sdn_comments.csv":      "SDNs",
+	"dpl.txt":               "DPs",
+	"csl.csv":               "CSL",
+	"eu_csl.csv":            "EUCSL",
+	"eu_csl.xml":            "EUCSL",
+	"conlist.csv":           "UKCSL",
+	"uk_sanctions_list.csv": "UKSanctionsList",
+	"uk_sanctions_list.xml": "UKSanctionsList",
+	"uk_sanctions_list.ods": "UKSanctionsList",
+	pep.DefaultFilename:     "PEPs",
+	ftm.DefaultFilename:     "FtM",
+}
+
+// dataFile is what's compared to tell if a file changed
+type dataFile struct {
+	size    int64
+	modTime time.Time
+}
+
+func (f dataFile) same(other dataFile) bool {
+	return f.size == other.size && f.modTime.Equal(other.modTime)
+}
+
+// dataWatcher polls a data directory and refreshes just the lists whose files changed
+type dataWatcher struct {
+	searcher *searcher
+	repo     downloadRepository
+	logger   log.Logger
+
+	dir      string
+	interval time.Duration
+
+	// files are the files which have been read
+	files map[string]dataFile
+	// pending are files which changed during the last poll. They're read once they
+	// stay the same for a poll so files which are still being written are skipped.
+	pending map[string]dataFile
+}
+
+// newDataWatcher reads the watch config from environment variables. Watching is
+// disabled (and nil is returned) when DATA_WATCH_DIRECTORY is unset.
+func newDataWatcher(logger log.Logger, searcher *searcher, repo downloadRepository) (*dataWatcher, error) {
+	dir := os.Getenv("DATA_WATCH_DIRECTORY")
+	if dir == "" {
+		return nil, nil
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("DATA_WATCH_DIRECTORY=%s is not a directory", dir)
+	}
+
+	interval := defaultDataWatchInterval
+	if v := os.Getenv("DATA_WATCH_INTERVAL"); v != "" {
+		dur, err := time.ParseDuration(v)
+		if err != nil || dur <= 0 {
+			return nil, fmt.Errorf("invalid DATA_WATCH_INTERVAL=%q", v)
+		}
+		interval = dur
+	}
+	logger.Logf("watching %s for data files every %v", dir, interval)
+
+	return &dataWatcher{
+		searcher: searcher,
+		repo:     repo,
+		logger:   logger,
+		dir:      dir,
+		interval: interval,
+		files:    make(map[string]dataFile),
+		pending:  make(map[string]dataFile),
+	}, nil
+}
+
+// scan returns the data files in dir
+func (w *dataWatcher) scan() (map[string]dataFile, error) {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]dataFile)
+	for _, entry := range entries {
+		if _, exists := dataFileLists[strings.ToLower(entry.Name())]; !exists {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		out[entry.Name()] = dataFile{size: info.Size(), modTime: info.ModTime()}
+	}
+	return out, nil
+}
+
+// markRead records the files currently in dir as read, which is done after they're loaded on startup
+func (w *dataWatcher) markRead() error {
+	files, err := w.scan()
+	if err != nil {
+		return err
+	}
+	w.files = files
+	return nil
+}
+
+// poll returns the lists whose files changed and have stayed the same since the previous poll
+func (w *dataWatcher) poll() ([]string, error) {
+	current, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]bool)
+	for name, file := range current {
+		if read, exists := w.files[name]; exists && read.same(file) {
+			delete(w.pending, name)
+			continue
+		}
+		if pending, exists := w.pending[name]; exists && pending.same(file) {
+			delete(w.pending, name)
+			w.files[name] = file
+			changed[dataFileLists[strings.ToLower(name)]] = true
+			continue
+		}
+		w.pending[name] = file
+	}
+	// removed files don't change what's served, but are read again if they come back
+	for name := range w.files {
+		if _, exists := current[name]; !exists {
+			delete(w.files, name)
+		}
+	}
+	for name := range w.pending {
+		if _, exists := current[name]; !exists {
+			delete(w.pending, name)
+		}
+	}
+
+	var lists []string
+	for _, list := range allLists {
+		if changed[list] {
+			lists = append(lists, list)
+		}
+	}
+	return lists, nil
+}
+
+// watch polls dir and sends the stats of each refresh to updates
+func (w *dataWatcher) watch(updates chan *DownloadStats) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		lists, err := w.poll()
+		if err != nil {
+			w.logger.Error().LogErrorf("problem watching %s: %v", w.dir, err)
+			continue
+		}
+		if len(lists) == 0 {
+			continue
+		}
+
+		stats, err := w.searcher.refreshLists(w.dir, lists)
+		if err != nil {
+			w.logger.Error().LogErrorf("problem refreshing %s: %v", strings.Join(lists, ", "), err)
+			continue
+		}
+		if err := w.repo.recordStats(stats); err != nil {
+			w.logger.Error().LogErrorf("problem recording download stats: %v", err)
+		}
+		updates <- stats
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/dpl"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataWatcher__config(t *testing.T) {
+	t.Setenv("DATA_WATCH_DIRECTORY", "")
+	w, err := newDataWatcher(log.NewNopLogger(), nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, w)
+
+	t.Setenv("DATA_WATCH_DIRECTORY", filepath.Join(t.TempDir(), "missing"))
+	_, err = newDataWatcher(log.NewNopLogger(), nil, nil)
+	require.ErrorContains(t, err, "is not a directory")
+
+	t.Setenv("DATA_WATCH_DIRECTORY", t.TempDir())
+	w, err = newDataWatcher(log.NewNopLogger(), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, defaultDataWatchInterval, w.interval)
+
+	t.Setenv("DATA_WATCH_INTERVAL", "soon")
+	_, err = newDataWatcher(log.NewNopLogger(), nil, nil)
+	require.ErrorContains(t, err, "invalid DATA_WATCH_INTERVAL")
+}
+
+func TestDataWatcher__poll(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DATA_WATCH_DIRECTORY", dir)
+	w, err := newDataWatcher(log.NewNopLogger(), nil, nil)
+	require.NoError(t, err)
+
+	write := func(name, contents string, modTime time.Time) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	when := time.Date(2023, time.March, 3, 9, 4, 0, 0, time.UTC)
+	write("sdn.csv", "1,NICOLAS MADURO MOROS", when)
+	require.NoError(t, w.markRead())
+
+	lists, err := w.poll()
+	require.NoError(t, err)
+	require.Empty(t, lists)
+
+	// changed files are read once they stop changing
+	write("sdn.csv", "1,NICOLAS MADURO MOROS\n2,VLADIMIR PUTIN", when.Add(time.Hour))
+	write("DPL.txt", "Name", when.Add(time.Hour))
+	write("notes.txt", "not a list", when.Add(time.Hour))
+
+	lists, err = w.poll()
+	require.NoError(t, err)
+	require.Empty(t, lists)
+
+	write("sdn.csv", "1,NICOLAS MADURO MOROS\n2,VLADIMIR PUTIN\n3,AL NASER WINGS AIRLINES", when.Add(2*time.Hour))
+	lists, err = w.poll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"DPs"}, lists)
+
+	lists, err = w.poll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"SDNs"}, lists)
+
+	lists, err = w.poll()
+	require.NoError(t, err)
+	require.Empty(t, lists)
+}
+
+// copyTestData copies the first few records of each test file into dir
+func copyTestData(t *testing.T, dir string, modTime time.Time, names ...string) []string {
+	t.Helper()
+
+	var out []string
+	for _, name := range names {
+		bs, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", name))
+		require.NoError(t, err)
+		lines := bytes.SplitAfterN(bs, []byte("\n"), 11)
+		bs = bytes.Join(lines[:len(lines)-1], nil)
+
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, bs, 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+		out = append(out, path)
+	}
+	return out
+}
+
+func TestSearcher__refreshLists(t *testing.T) {
+	dir := t.TempDir()
+	when := time.Date(2023, time.March, 3, 9, 4, 0, 0, time.UTC)
+	copyTestData(t, dir, when, "sdn.csv", "add.csv", "alt.csv", "sdn_comments.csv")
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	parsed := newParsedLists()
+	parsed.DPL = []*dpl.DPL{{Name: "AL NASER WINGS AIRLINES"}}
+	parsed.read("DPs", nil)
+	_, err := s.indexLists(parsed, when.Add(-time.Hour))
+	require.NoError(t, err)
+
+	stats, err := s.refreshLists(dir, []string{"SDNs"})
+	require.NoError(t, err)
+	require.Empty(t, stats.Errors)
+	require.NotZero(t, stats.SDNs)
+
+	// lists which weren't read keep serving their data
+	require.Equal(t, 1, stats.DeniedPersons)
+	require.Len(t, s.DPs, 1)
+	require.Equal(t, when.Add(-time.Hour), stats.Freshness["DPs"].RefreshedAt)
+	require.Equal(t, 1, stats.Quality["DPs"].RowsRead)
+
+	// the refreshed list is as fresh as its files
+	require.Equal(t, when, stats.Freshness["SDNs"].RefreshedAt)
+}
+
+func TestSearcher__refreshListsWaitsForRefresh(t *testing.T) {
+	dir := t.TempDir()
+	when := time.Date(2023, time.March, 3, 9, 4, 0, 0, time.UTC)
+	copyTestData(t, dir, when, "sdn.csv", "add.csv", "alt.csv", "sdn_comments.csv")
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	// a full refresh is in progress
+	s.refreshMu.Lock()
+	done := make(chan error)
+	go func() {
+		_, err := s.refreshLists(dir, []string{"SDNs"})
+		done <- err
+	}()
+
+	parsed := newParsedLists()
+	parsed.DPL = []*dpl.DPL{{Name: "AL NASER WINGS AIRLINES"}, {Name: "MAHAN AIR"}}
+	parsed.read("DPs", nil)
+	_, err := s.indexLists(parsed, when)
+	require.NoError(t, err)
+	s.refreshMu.Unlock()
+
+	// the partial refresh keeps the lists swapped in by the full refresh
+	require.NoError(t, <-done)
+	require.Len(t, s.DPs, 2)
+	require.NotEmpty(t, s.SDNs)
+}
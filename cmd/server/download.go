@@ -12,14 +12,19 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/moov-io/base"
 	moovhttp "github.com/moov-io/base/http"
 	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
 	"github.com/moov-io/watchman/pkg/csl"
 	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ftm"
 	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/pep"
 
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
@@ -81,10 +86,36 @@ type DownloadStats struct {
 	// UK Sanctions List
 	UKSanctionsList int `json:"ukSanctionsList"`
 
+	// Politically Exposed Persons
+	PoliticallyExposedPersons int `json:"politicallyExposedPersons"`
+
+	// FollowTheMoney entities
+	FtMEntities int `json:"ftmEntities"`
+
+	// Quality describes the rows read and skipped for each list
+	Quality QualityReport `json:"quality,omitempty"`
+
+	// Freshness is when each list's data was last refreshed successfully
+	Freshness map[string]ListFreshness `json:"freshness,omitempty"`
+
+	// Changes counts the entities added, removed or modified on each list since the
+	// previous refresh. The entities are read from /downloads/{downloadID}/changes
+	Changes map[string]ChangeSummary `json:"changes,omitempty"`
+	changes map[string]*ListChanges
+
+	ID          string    `json:"id,omitempty"`
 	Errors      []error   `json:"-"`
 	RefreshedAt time.Time `json:"timestamp"`
 }
 
+// ListFreshness is when a list's data was last refreshed successfully. A list which fails
+// to refresh keeps serving its previous data, so RefreshedAt stays put and Error is set.
+type ListFreshness struct {
+	// RefreshedAt is zero if the list has never been loaded
+	RefreshedAt time.Time `json:"refreshedAt"`
+	Error       string    `json:"error,omitempty"`
+}
+
 func (ss *DownloadStats) Error() string {
 	var buf bytes.Buffer
 	for i := range ss.Errors {
@@ -148,6 +179,8 @@ func (s *searcher) periodicDataRefresh(interval time.Duration, downloadRepo down
 					"NS_MBS":           log.Int(stats.NonSDNMenuBasedSanctions),
 					"EU_CSL":           log.Int(stats.EUCSL),
 					"UK_CSL":           log.Int(stats.UKCSL),
+					"PEP":              log.Int(stats.PoliticallyExposedPersons),
+					"FtM":              log.Int(stats.FtMEntities),
 				}).Logf("data refreshed %v ago", time.Since(stats.RefreshedAt))
 			}
 			updates <- stats // send stats for re-search and watch notifications
@@ -155,7 +188,16 @@ func (s *searcher) periodicDataRefresh(interval time.Duration, downloadRepo down
 	}
 }
 
-func ofacRecords(logger log.Logger, initialDir string) (*ofac.Results, error) {
+// sourceFiles collects the files each list was read from, keyed by list name
+type sourceFiles map[string][]string
+
+func (f sourceFiles) add(list string, paths ...string) {
+	if f != nil {
+		f[list] = append(f[list], paths...)
+	}
+}
+
+func ofacRecords(logger log.Logger, initialDir string, sources sourceFiles) (*ofac.Results, error) {
 	files, err := ofac.Download(logger, initialDir)
 	if err != nil {
 		return nil, fmt.Errorf("download: %v", err)
@@ -163,8 +205,10 @@ func ofacRecords(logger log.Logger, initialDir string) (*ofac.Results, error) {
 	if len(files) == 0 {
 		return nil, errors.New("no OFAC Results")
 	}
+	sources.add("SDNs", files...)
 
 	var res *ofac.Results
+	skipped := make(map[string]int)
 
 	for i := range files {
 		if i == 0 {
@@ -174,6 +218,7 @@ func ofacRecords(logger log.Logger, initialDir string) (*ofac.Results, error) {
 			}
 			if rr != nil {
 				res = rr
+				addSkipped(skipped, files[i], rr.Skipped)
 			}
 		} else {
 			rr, err := ofac.Read(files[i])
@@ -185,26 +230,38 @@ func ofacRecords(logger log.Logger, initialDir string) (*ofac.Results, error) {
 				res.AlternateIdentities = append(res.AlternateIdentities, rr.AlternateIdentities...)
 				res.SDNs = append(res.SDNs, rr.SDNs...)
 				res.SDNComments = append(res.SDNComments, rr.SDNComments...)
+				addSkipped(skipped, files[i], rr.Skipped)
 			}
 		}
 	}
+	if res != nil {
+		res.Skipped = skipped
+	}
 	return res, err
 }
 
-func dplRecords(logger log.Logger, initialDir string) ([]*dpl.DPL, error) {
+// addSkipped merges a file's skipped rows into skipped, keyed by the file they came from
+func addSkipped(skipped map[string]int, path string, reasons map[string]int) {
+	for reason, n := range reasons {
+		skipped[fmt.Sprintf("%s: %s", filepath.Base(path), reason)] += n
+	}
+}
+
+func dplRecords(logger log.Logger, initialDir string, sources sourceFiles) ([]*dpl.DPL, *dpl.ReadReport, error) {
 	file, err := dpl.Download(logger, initialDir)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return dpl.Read(file)
+	sources.add("DPs", file)
+	return dpl.ReadWithReport(file)
 }
 
-func cslRecords(logger log.Logger, initialDir string) (*csl.CSL, error) {
+func cslRecords(logger log.Logger, initialDir string, sources sourceFiles) (*csl.CSL, error) {
 	file, err := csl.Download(logger, initialDir)
 	if err != nil {
-		logger.Warn().Logf("skipping CSL download: %v", err)
-		return &csl.CSL{}, nil
+		return nil, fmt.Errorf("download: %v", err)
 	}
+	sources.add("CSL", file)
 	cslRecords, err := csl.ReadFile(file)
 	if err != nil {
 		return nil, err
@@ -212,27 +269,37 @@ func cslRecords(logger log.Logger, initialDir string) (*csl.CSL, error) {
 	return cslRecords, err
 }
 
-func euCSLRecords(logger log.Logger, initialDir string) ([]*csl.EUCSLRecord, error) {
+func euCSLRecords(logger log.Logger, initialDir string, sources sourceFiles) ([]*csl.EUCSLRecord, *csl.EUParseReport, error) {
 	file, err := csl.DownloadEU(logger, initialDir)
 	if err != nil {
-		logger.Warn().Logf("skipping EU CSL download: %v", err)
-		// no error to return because we skip the download
-		return nil, nil
+		return nil, nil, fmt.Errorf("download: %v", err)
 	}
-	cslRecords, _, err := csl.ReadEUFile(file)
+	sources.add("EUCSL", file)
+	cslRecords, _, report, err := csl.ReadEUFileWithReport(file)
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
-	return cslRecords, err
+	if report != nil && len(report.Skipped) > 0 {
+		logger.Warn().With(log.Fields{
+			"format":  log.String(report.Format),
+			"skipped": log.Int(len(report.Skipped)),
+		}).Logf("skipped %d unparsable EU CSL rows", len(report.Skipped))
+		for _, row := range report.Skipped {
+			logger.Warn().With(log.Fields{
+				"line":   log.Int(row.Line),
+				"reason": log.String(row.Reason),
+			}).Log("skipped EU CSL row")
+		}
+	}
+	return cslRecords, report, err
 }
 
-func ukCSLRecords(logger log.Logger, initialDir string) ([]*csl.UKCSLRecord, error) {
+func ukCSLRecords(logger log.Logger, initialDir string, sources sourceFiles) ([]*csl.UKCSLRecord, error) {
 	file, err := csl.DownloadUKCSL(logger, initialDir)
 	if err != nil {
-		logger.Warn().Logf("skipping UK CSL download: %v", err)
-		// no error to return because we skip the download
-		return nil, nil
+		return nil, fmt.Errorf("download: %v", err)
 	}
+	sources.add("UKCSL", file)
 	cslRecords, _, err := csl.ReadUKCSLFile(file)
 	if err != nil {
 		return nil, err
@@ -240,13 +307,12 @@ func ukCSLRecords(logger log.Logger, initialDir string) ([]*csl.UKCSLRecord, err
 	return cslRecords, err
 }
 
-func ukSanctionsListRecords(logger log.Logger, initialDir string) ([]*csl.UKSanctionsListRecord, error) {
+func ukSanctionsListRecords(logger log.Logger, initialDir string, sources sourceFiles) ([]*csl.UKSanctionsListRecord, error) {
 	file, err := csl.DownloadUKSanctionsList(logger, initialDir)
 	if err != nil {
-		logger.Warn().Logf("skipping UK Sanctions List download: %v", err)
-		// no error to return because we skip the download
-		return nil, nil
+		return nil, fmt.Errorf("download: %v", err)
 	}
+	sources.add("UKSanctionsList", file)
 
 	records, _, err := csl.ReadUKSanctionsListFile(file)
 	if err != nil {
@@ -255,9 +321,50 @@ func ukSanctionsListRecords(logger log.Logger, initialDir string) ([]*csl.UKSanc
 	return records, err
 }
 
+func pepRecords(logger log.Logger, initialDir string, preferDir bool, sources sourceFiles) ([]*pep.PEP, error) {
+	path := pep.LocateFile(initialDir)
+	if found := pep.FileIn(initialDir); preferDir && found != "" {
+		path = found
+	}
+	if path == "" {
+		// no PEP dataset configured
+		return nil, nil
+	}
+	sources.add("PEPs", path)
+	logger.Logf("reading PEP dataset from %s", path)
+
+	return pep.ReadFile(path)
+}
+
+func ftmRecords(logger log.Logger, initialDir string, sources sourceFiles) ([]*ftm.Entity, error) {
+	path := ftm.LocateFile(initialDir)
+	if path == "" {
+		// no FtM entities to import
+		return nil, nil
+	}
+	sources.add("FtM", path)
+	logger.Logf("importing FtM entities from %s", path)
+
+	entities, err := ftm.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ftm.Filter(entities, ftm.SearchableSchemas...), nil
+}
+
+// requiredLists must load for the searcher to serve anything useful
+var requiredLists = []string{"SDNs", "DPs"}
+
 // refreshData reaches out to the various websites to download the latest
 // files, runs each list's parser, and index data for searches.
+//
+// Each list is swapped in independently. A list which fails to download or parse keeps
+// serving its previous data and the failure is recorded in stats.Errors. An error is only
+// returned when a required list has never loaded.
 func (s *searcher) refreshData(initialDir string) (*DownloadStats, error) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
 	if s.logger != nil {
 		s.logger.Log("Starting refresh of data")
 
@@ -266,76 +373,319 @@ func (s *searcher) refreshData(initialDir string) (*DownloadStats, error) {
 		}
 	}
 
-	stats := &DownloadStats{
-		RefreshedAt: lastRefresh(initialDir),
+	refreshedAt := lastRefresh(initialDir)
+	files := make(sourceFiles)
+	parsed := s.readLists(initialDir, false, files)
+
+	return s.swapLists(parsed, files, refreshedAt)
+}
+
+// refreshLists reads the files of only some lists from dir and swaps them in. Every other
+// list keeps serving its data. Each list is refreshed as of when its oldest file was modified.
+// A PEP dataset in dir is read instead of PEP_DATA_FILE.
+func (s *searcher) refreshLists(dir string, lists []string) (*DownloadStats, error) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	if s.logger != nil {
+		s.logger.Logf("Starting refresh of %s from %s", strings.Join(lists, ", "), dir)
+	}
+
+	files := make(sourceFiles)
+	parsed := s.readLists(dir, true, files, lists...)
+	for list, paths := range files {
+		if at := oldestModTime(paths); !at.IsZero() {
+			parsed.refreshedAt[list] = at
+		}
 	}
 
-	lastDataRefreshFailure.WithLabelValues("SDNs").Set(float64(time.Now().Unix()))
+	return s.swapLists(parsed, files, time.Now().In(time.UTC))
+}
 
-	results, err := ofacRecords(s.logger, initialDir)
+// swapLists indexes the lists which were read and keeps a snapshot of them
+func (s *searcher) swapLists(parsed *parsedLists, files sourceFiles, refreshedAt time.Time) (*DownloadStats, error) {
+	stats, err := s.indexLists(parsed, refreshedAt)
+	recordRefreshMetrics(stats)
 	if err != nil {
-		lastDataRefreshFailure.WithLabelValues("SDNs").Set(float64(time.Now().Unix()))
-		stats.Errors = append(stats.Errors, fmt.Errorf("OFAC: %v", err))
+		return stats, err
 	}
 
-	sdns := precomputeSDNs(results.SDNs, results.Addresses, s.pipe)
-	adds := precomputeAddresses(results.Addresses)
-	alts := precomputeAlts(results.AlternateIdentities, s.pipe)
+	if s.snapshots != nil {
+		s.snapshots.save(stats, parsed, files)
+	}
+	return stats, nil
+}
 
-	deniedPersons, err := dplRecords(s.logger, initialDir)
-	if err != nil {
-		lastDataRefreshFailure.WithLabelValues("DPs").Set(float64(time.Now().Unix()))
-		stats.Errors = append(stats.Errors, fmt.Errorf("DPL: %v", err))
+// allLists are the lists which are read and swapped in independently
+var allLists = []string{"SDNs", "DPs", "CSL", "EUCSL", "UKCSL", "UKSanctionsList", "PEPs", "FtM"}
+
+// listErrorLabels prefixes the errors in DownloadStats of lists with another name
+var listErrorLabels = map[string]string{
+	"SDNs": "OFAC",
+	"DPs":  "DPL",
+	"PEPs": "PEP",
+}
+
+// parsedLists holds the records read from each list's files before they're indexed for search
+type parsedLists struct {
+	OFAC            *ofac.Results
+	DPL             []*dpl.DPL
+	CSL             *csl.CSL
+	EUCSL           []*csl.EUCSLRecord
+	UKCSL           []*csl.UKCSLRecord
+	UKSanctionsList []*csl.UKSanctionsListRecord
+	PEPs            []*pep.PEP
+	FtM             []*ftm.Entity
+
+	dplReport *dpl.ReadReport
+	euReport  *csl.EUParseReport
+
+	// lists which were read, whether or not they failed
+	lists []string
+	// errors holds why each failed list couldn't be downloaded or read
+	errors map[string]error
+	// refreshedAt is when each list was downloaded if it differs from the refresh
+	refreshedAt map[string]time.Time
+}
+
+func newParsedLists() *parsedLists {
+	return &parsedLists{
+		errors:      make(map[string]error),
+		refreshedAt: make(map[string]time.Time),
+	}
+}
+
+// records returns a pointer to the records of each list
+func (p *parsedLists) records() map[string]any {
+	return map[string]any{
+		"SDNs":            &p.OFAC,
+		"DPs":             &p.DPL,
+		"CSL":             &p.CSL,
+		"EUCSL":           &p.EUCSL,
+		"UKCSL":           &p.UKCSL,
+		"UKSanctionsList": &p.UKSanctionsList,
+		"PEPs":            &p.PEPs,
+		"FtM":             &p.FtM,
 	}
-	dps := precomputeDPs(deniedPersons, s.pipe)
+}
 
-	euConsolidatedList, err := euCSLRecords(s.logger, initialDir)
+// read records that the list was read and why it failed, if it did
+func (p *parsedLists) read(list string, err error) {
+	p.lists = append(p.lists, list)
 	if err != nil {
-		lastDataRefreshFailure.WithLabelValues("EUCSL").Set(float64(time.Now().Unix()))
-		stats.Errors = append(stats.Errors, fmt.Errorf("EUCSL: %v", err))
+		lastDataRefreshFailure.WithLabelValues(list).Set(float64(time.Now().Unix()))
+		p.errors[list] = err
 	}
+}
 
-	euCSLs := precomputeCSLEntities[csl.EUCSLRecord](euConsolidatedList, s.pipe)
+func (p *parsedLists) has(list string) bool {
+	for i := range p.lists {
+		if p.lists[i] == list {
+			return true
+		}
+	}
+	return false
+}
 
-	ukConsolidatedList, err := ukCSLRecords(s.logger, initialDir)
-	if err != nil {
-		lastDataRefreshFailure.WithLabelValues("UKCSL").Set(float64(time.Now().Unix()))
-		stats.Errors = append(stats.Errors, fmt.Errorf("UKCSL: %v", err))
+// enabledLists are the lists read by a full refresh
+func enabledLists() []string {
+	withSanctionsList := strx.Yes(os.Getenv("WITH_UK_SANCTIONS_LIST"))
+
+	out := make([]string, 0, len(allLists))
+	for _, list := range allLists {
+		if list == "UKSanctionsList" && !withSanctionsList {
+			continue
+		}
+		out = append(out, list)
+	}
+	return out
+}
+
+// readLists downloads (or finds in initialDir) each list's files and parses them. Only
+// the given lists are read, or every enabled list when none are given. preferDir reads the
+// PEP dataset in initialDir (e.g. one from a bundle) instead of PEP_DATA_FILE.
+func (s *searcher) readLists(initialDir string, preferDir bool, files sourceFiles, lists ...string) *parsedLists {
+	parsed := newParsedLists()
+	if len(lists) == 0 {
+		lists = enabledLists()
 	}
+	for _, list := range lists {
+		s.readList(parsed, list, initialDir, preferDir, files)
+	}
+	return parsed
+}
 
-	ukCSLs := precomputeCSLEntities[csl.UKCSLRecord](ukConsolidatedList, s.pipe)
+func (s *searcher) readList(parsed *parsedLists, list, initialDir string, preferDir bool, files sourceFiles) {
+	var err error
+	switch list {
+	case "SDNs":
+		lastDataRefreshFailure.WithLabelValues("SDNs").Set(float64(time.Now().Unix()))
+		parsed.OFAC, err = ofacRecords(s.logger, initialDir, files)
 
-	var ukSLs []*Result[csl.UKSanctionsListRecord]
-	withSanctionsList := os.Getenv("WITH_UK_SANCTIONS_LIST")
-	if strings.ToLower(withSanctionsList) == "true" {
-		ukSanctionsList, err := ukSanctionsListRecords(s.logger, initialDir)
-		if err != nil {
-			lastDataRefreshFailure.WithLabelValues("UKSanctionsList").Set(float64(time.Now().Unix()))
-			stats.Errors = append(stats.Errors, fmt.Errorf("UKSanctionsList: %v", err))
+	case "DPs":
+		parsed.DPL, parsed.dplReport, err = dplRecords(s.logger, initialDir, files)
+
+	case "CSL":
+		// csl records from US downloaded here
+		parsed.CSL, err = cslRecords(s.logger, initialDir, files)
+
+	case "EUCSL":
+		parsed.EUCSL, parsed.euReport, err = euCSLRecords(s.logger, initialDir, files)
+
+	case "UKCSL":
+		parsed.UKCSL, err = ukCSLRecords(s.logger, initialDir, files)
+
+	case "UKSanctionsList":
+		parsed.UKSanctionsList, err = ukSanctionsListRecords(s.logger, initialDir, files)
+
+	case "PEPs":
+		parsed.PEPs, err = pepRecords(s.logger, initialDir, preferDir, files)
+
+	case "FtM":
+		parsed.FtM, err = ftmRecords(s.logger, initialDir, files)
+
+	default:
+		err = fmt.Errorf("unknown list %q", list)
+	}
+	parsed.read(list, err)
+}
+
+// indexLists precomputes each list's records for search and swaps them in. Lists which
+// failed to read keep serving their previous data. Refreshes of a shared searcher hold
+// s.refreshMu across reading, indexing and swapping.
+func (s *searcher) indexLists(parsed *parsedLists, refreshedAt time.Time) (*DownloadStats, error) {
+	stats := &DownloadStats{
+		ID:          base.ID(),
+		RefreshedAt: refreshedAt,
+	}
+
+	quality := make(QualityReport)
+	for _, list := range parsed.lists {
+		if err := parsed.errors[list]; err != nil {
+			quality.keepPrevious(list, err)
+			stats.Errors = append(stats.Errors, fmt.Errorf("%s: %v", strx.Or(listErrorLabels[list], list), err))
 		}
-		ukSLs = precomputeCSLEntities[csl.UKSanctionsListRecord](ukSanctionsList, s.pipe)
+	}
+	// lists which failed or weren't read keep serving their previous data
+	kept := func(list string) bool {
+		return quality.kept(list) || !parsed.has(list)
+	}
 
-		stats.UKSanctionsList = len(ukSLs)
-		lastDataRefreshCount.WithLabelValues("UKSL").Set(float64(len(ukSLs)))
+	results := parsed.OFAC
+	if results == nil {
+		results = &ofac.Results{}
 	}
 
-	// csl records from US downloaded here
-	consolidatedLists, err := cslRecords(s.logger, initialDir)
-	if err != nil {
-		lastDataRefreshFailure.WithLabelValues("CSL").Set(float64(time.Now().Unix()))
-		stats.Errors = append(stats.Errors, fmt.Errorf("CSL: %v", err))
-	}
-	els := precomputeCSLEntities[csl.EL](consolidatedLists.ELs, s.pipe)
-	meus := precomputeCSLEntities[csl.MEU](consolidatedLists.MEUs, s.pipe)
-	ssis := precomputeCSLEntities[csl.SSI](consolidatedLists.SSIs, s.pipe)
-	uvls := precomputeCSLEntities[csl.UVL](consolidatedLists.UVLs, s.pipe)
-	isns := precomputeCSLEntities[csl.ISN](consolidatedLists.ISNs, s.pipe)
-	fses := precomputeCSLEntities[csl.FSE](consolidatedLists.FSEs, s.pipe)
-	plcs := precomputeCSLEntities[csl.PLC](consolidatedLists.PLCs, s.pipe)
-	caps := precomputeCSLEntities[csl.CAP](consolidatedLists.CAPs, s.pipe)
-	dtcs := precomputeCSLEntities[csl.DTC](consolidatedLists.DTCs, s.pipe)
-	cmics := precomputeCSLEntities[csl.CMIC](consolidatedLists.CMICs, s.pipe)
-	ns_mbss := precomputeCSLEntities[csl.NS_MBS](consolidatedLists.NS_MBSs, s.pipe)
+	sdnQuality, sdns := checkList(quality, "SDNs", results.SDNs, precomputeSDNs(results.SDNs, results.Addresses, s.pipe), func(sdn *SDN) string { return sdn.name })
+	sdnQuality.skipAll(results.Skipped)
+	adds := precomputeAddresses(results.Addresses)
+	_, alts := checkList(quality, "Alts", results.AlternateIdentities, precomputeAlts(results.AlternateIdentities, s.pipe), func(alt *Alt) string { return alt.name })
+
+	dpQuality, dps := checkList(quality, "DPs", parsed.DPL, precomputeDPs(parsed.DPL, s.pipe), func(dp *DP) string { return dp.name })
+	if parsed.dplReport != nil {
+		dpQuality.skipAll(parsed.dplReport.Skipped)
+		dpQuality.HeaderDrift = parsed.dplReport.HeaderDrift
+	}
+
+	euQuality, euCSLs := checkList(quality, "EUCSL", parsed.EUCSL, precomputeCSLEntities[csl.EUCSLRecord](parsed.EUCSL, s.pipe), resultName[csl.EUCSLRecord])
+	if parsed.euReport != nil {
+		for _, row := range parsed.euReport.Skipped {
+			euQuality.skip(row.Reason, 1)
+		}
+		euQuality.HeaderDrift = parsed.euReport.HeaderDrift
+	}
+
+	_, ukCSLs := checkList(quality, "UKCSL", parsed.UKCSL, precomputeCSLEntities[csl.UKCSLRecord](parsed.UKCSL, s.pipe), resultName[csl.UKCSLRecord])
+
+	_, ukSLs := checkList(quality, "UKSanctionsList", parsed.UKSanctionsList, precomputeCSLEntities[csl.UKSanctionsListRecord](parsed.UKSanctionsList, s.pipe), resultName[csl.UKSanctionsListRecord])
+
+	_, peps := checkList(quality, "PEPs", parsed.PEPs, precomputeCSLEntities[pep.PEP](parsed.PEPs, s.pipe), resultName[pep.PEP])
+
+	_, ftms := checkList(quality, "FtM", parsed.FtM, precomputeCSLEntities[ftm.Entity](parsed.FtM, s.pipe), resultName[ftm.Entity])
+
+	consolidatedLists := parsed.CSL
+	if consolidatedLists == nil {
+		consolidatedLists = &csl.CSL{}
+	}
+	_, els := checkList(quality, "BISEntities", consolidatedLists.ELs, precomputeCSLEntities[csl.EL](consolidatedLists.ELs, s.pipe), resultName[csl.EL])
+	_, meus := checkList(quality, "MilitaryEndUsers", consolidatedLists.MEUs, precomputeCSLEntities[csl.MEU](consolidatedLists.MEUs, s.pipe), resultName[csl.MEU])
+	_, ssis := checkList(quality, "SSIs", consolidatedLists.SSIs, precomputeCSLEntities[csl.SSI](consolidatedLists.SSIs, s.pipe), resultName[csl.SSI])
+	_, uvls := checkList(quality, "UVLs", consolidatedLists.UVLs, precomputeCSLEntities[csl.UVL](consolidatedLists.UVLs, s.pipe), resultName[csl.UVL])
+	_, isns := checkList(quality, "ISNs", consolidatedLists.ISNs, precomputeCSLEntities[csl.ISN](consolidatedLists.ISNs, s.pipe), resultName[csl.ISN])
+	_, fses := checkList(quality, "FSEs", consolidatedLists.FSEs, precomputeCSLEntities[csl.FSE](consolidatedLists.FSEs, s.pipe), resultName[csl.FSE])
+	_, plcs := checkList(quality, "PLCs", consolidatedLists.PLCs, precomputeCSLEntities[csl.PLC](consolidatedLists.PLCs, s.pipe), resultName[csl.PLC])
+	_, caps := checkList(quality, "CAPs", consolidatedLists.CAPs, precomputeCSLEntities[csl.CAP](consolidatedLists.CAPs, s.pipe), resultName[csl.CAP])
+	_, dtcs := checkList(quality, "DTCs", consolidatedLists.DTCs, precomputeCSLEntities[csl.DTC](consolidatedLists.DTCs, s.pipe), resultName[csl.DTC])
+	_, cmics := checkList(quality, "CMICs", consolidatedLists.CMICs, precomputeCSLEntities[csl.CMIC](consolidatedLists.CMICs, s.pipe), resultName[csl.CMIC])
+	_, ns_mbss := checkList(quality, "NS_MBSs", consolidatedLists.NS_MBSs, precomputeCSLEntities[csl.NS_MBS](consolidatedLists.NS_MBSs, s.pipe), resultName[csl.NS_MBS])
+	if len(consolidatedLists.Skipped) > 0 || len(consolidatedLists.HeaderDrift) > 0 {
+		// rows which were skipped before their source list was known
+		cslQuality := quality.list("CSL")
+		cslQuality.skipAll(consolidatedLists.Skipped)
+		cslQuality.HeaderDrift = consolidatedLists.HeaderDrift
+	}
+
+	s.RLock()
+	if kept("SDNs") {
+		sdns, adds, alts = s.SDNs, s.Addresses, s.Alts
+	}
+	if kept("DPs") {
+		dps = s.DPs
+	}
+	if kept("EUCSL") {
+		euCSLs = s.EUCSL
+	}
+	if kept("UKCSL") {
+		ukCSLs = s.UKCSL
+	}
+	if kept("UKSanctionsList") {
+		ukSLs = s.UKSanctionsList
+	}
+	if kept("CSL") {
+		els, meus, ssis, uvls, isns = s.BISEntities, s.MilitaryEndUsers, s.SSIs, s.UVLs, s.ISNs
+		fses, plcs, caps, dtcs, cmics, ns_mbss = s.FSEs, s.PLCs, s.CAPs, s.DTCs, s.CMICs, s.NS_MBSs
+	}
+	if kept("PEPs") {
+		peps = s.PEPs
+	}
+	if kept("FtM") {
+		ftms = s.FtMEntities
+	}
+	for _, list := range allLists {
+		if parsed.has(list) {
+			continue
+		}
+		// lists which weren't read keep their previous quality report
+		for _, name := range qualityLists(list) {
+			if q := s.quality[name]; q != nil {
+				quality[name] = q
+			} else {
+				delete(quality, name)
+			}
+		}
+	}
+	freshness := nextFreshness(s.freshness, quality, parsed.lists, stats.RefreshedAt)
+	prevEntities := s.entities
+	s.RUnlock()
+	for list, at := range parsed.refreshedAt {
+		if f, exists := freshness[list]; exists && f.Error == "" {
+			f.RefreshedAt = at
+			freshness[list] = f
+		}
+	}
+
+	entities := map[string]listEntities{
+		"SDNs":            sdnEntities(sdns, adds, alts),
+		"EUCSL":           euCSLEntities(euCSLs),
+		"UKCSL":           ukCSLEntities(ukCSLs),
+		"UKSanctionsList": ukSanctionsListEntities(ukSLs),
+	}
+	stats.changes = diffLists(prevEntities, entities, kept)
+	stats.Changes = summarizeChanges(stats.changes)
+
+	s.recordQuality(quality)
+	stats.Quality = quality
+	stats.Freshness = freshness
 
 	// OFAC
 	stats.SDNs = len(sdns)
@@ -360,29 +710,13 @@ func (s *searcher) refreshData(initialDir string) (*DownloadStats, error) {
 
 	// UK - CSL
 	stats.UKCSL = len(ukCSLs)
+	stats.UKSanctionsList = len(ukSLs)
 
-	// record prometheus metrics
-	lastDataRefreshCount.WithLabelValues("SDNs").Set(float64(len(sdns)))
-	lastDataRefreshCount.WithLabelValues("SSIs").Set(float64(len(ssis)))
-	lastDataRefreshCount.WithLabelValues("BISEntities").Set(float64(len(els)))
-	lastDataRefreshCount.WithLabelValues("MilitaryEndUsers").Set(float64(len(meus)))
-	lastDataRefreshCount.WithLabelValues("DPs").Set(float64(len(dps)))
-	lastDataRefreshCount.WithLabelValues("UVLs").Set(float64(len(uvls)))
-	lastDataRefreshCount.WithLabelValues("ISNs").Set(float64(len(isns)))
-	lastDataRefreshCount.WithLabelValues("FSEs").Set(float64(len(fses)))
-	lastDataRefreshCount.WithLabelValues("PLCs").Set(float64(len(plcs)))
-	lastDataRefreshCount.WithLabelValues("CAPs").Set(float64(len(caps)))
-	lastDataRefreshCount.WithLabelValues("DTCs").Set(float64(len(dtcs)))
-	lastDataRefreshCount.WithLabelValues("CMICs").Set(float64(len(cmics)))
-	lastDataRefreshCount.WithLabelValues("NS_MBSs").Set(float64(len(ns_mbss)))
-	// EU CSL
-	lastDataRefreshCount.WithLabelValues("EUCSL").Set(float64(len(euCSLs)))
-	// UK CSL
-	lastDataRefreshCount.WithLabelValues("UKCSL").Set(float64(len(ukCSLs)))
+	// PEP
+	stats.PoliticallyExposedPersons = len(peps)
 
-	if len(stats.Errors) > 0 {
-		return stats, stats
-	}
+	// FtM
+	stats.FtMEntities = len(ftms)
 
 	// Set new records after precomputation (to minimize lock contention)
 	s.Lock()
@@ -409,18 +743,84 @@ func (s *searcher) refreshData(initialDir string) (*DownloadStats, error) {
 	//UKCSL
 	s.UKCSL = ukCSLs
 	s.UKSanctionsList = ukSLs
+	// PEP
+	s.PEPs = peps
+	// FtM
+	s.FtMEntities = ftms
 	// metadata
 	s.lastRefreshedAt = stats.RefreshedAt
+	s.freshness = freshness
+	s.entities = entities
+	s.snapshotID = ""
 	s.Unlock()
 
+	for _, list := range requiredLists {
+		if f := freshness[list]; f.Error != "" && f.RefreshedAt.IsZero() {
+			return stats, stats
+		}
+	}
+
 	if s.logger != nil {
-		s.logger.Log("Finished refresh of data")
+		if len(stats.Errors) > 0 {
+			s.logger.Warn().Logf("Finished refresh of data, keeping previous data for %d lists", len(stats.Errors))
+		} else {
+			s.logger.Log("Finished refresh of data")
+		}
 	}
 
+	return stats, nil
+}
+
+// recordRefreshMetrics reports the record counts of a refresh which the searcher is serving
+func recordRefreshMetrics(stats *DownloadStats) {
+	lastDataRefreshCount.WithLabelValues("SDNs").Set(float64(stats.SDNs))
+	lastDataRefreshCount.WithLabelValues("SSIs").Set(float64(stats.SectoralSanctions))
+	lastDataRefreshCount.WithLabelValues("BISEntities").Set(float64(stats.BISEntities))
+	lastDataRefreshCount.WithLabelValues("MilitaryEndUsers").Set(float64(stats.MilitaryEndUsers))
+	lastDataRefreshCount.WithLabelValues("DPs").Set(float64(stats.DeniedPersons))
+	lastDataRefreshCount.WithLabelValues("UVLs").Set(float64(stats.Unverified))
+	lastDataRefreshCount.WithLabelValues("ISNs").Set(float64(stats.NonProliferationSanctions))
+	lastDataRefreshCount.WithLabelValues("FSEs").Set(float64(stats.ForeignSanctionsEvaders))
+	lastDataRefreshCount.WithLabelValues("PLCs").Set(float64(stats.PalestinianLegislativeCouncil))
+	lastDataRefreshCount.WithLabelValues("CAPs").Set(float64(stats.CAPTA))
+	lastDataRefreshCount.WithLabelValues("DTCs").Set(float64(stats.ITARDebarred))
+	lastDataRefreshCount.WithLabelValues("CMICs").Set(float64(stats.ChineseMilitaryIndustrialComplex))
+	lastDataRefreshCount.WithLabelValues("NS_MBSs").Set(float64(stats.NonSDNMenuBasedSanctions))
+	// EU CSL
+	lastDataRefreshCount.WithLabelValues("EUCSL").Set(float64(stats.EUCSL))
+	// UK CSL
+	lastDataRefreshCount.WithLabelValues("UKCSL").Set(float64(stats.UKCSL))
+	lastDataRefreshCount.WithLabelValues("UKSL").Set(float64(stats.UKSanctionsList))
+	// PEP
+	lastDataRefreshCount.WithLabelValues("PEPs").Set(float64(stats.PoliticallyExposedPersons))
+	// FtM
+	lastDataRefreshCount.WithLabelValues("FtM").Set(float64(stats.FtMEntities))
+
 	// record successful data refresh
-	lastDataRefreshSuccess.WithLabelValues().Set(float64(time.Now().Unix()))
+	if len(stats.Errors) == 0 {
+		lastDataRefreshSuccess.WithLabelValues().Set(float64(time.Now().Unix()))
+	}
+}
 
-	return stats, nil
+// nextFreshness marks the lists which refreshed as of refreshedAt and carries forward
+// the previous timestamp of lists which failed and kept their data. Lists which weren't
+// read keep their previous freshness.
+func nextFreshness(prev map[string]ListFreshness, quality QualityReport, lists []string, refreshedAt time.Time) map[string]ListFreshness {
+	out := make(map[string]ListFreshness, len(prev))
+	for list, f := range prev {
+		out[list] = f
+	}
+	for _, list := range lists {
+		if quality.kept(list) {
+			out[list] = ListFreshness{
+				RefreshedAt: prev[list].RefreshedAt,
+				Error:       quality[list].Error,
+			}
+			continue
+		}
+		out[list] = ListFreshness{RefreshedAt: refreshedAt}
+	}
+	return out
 }
 
 // lastRefresh returns a time.Time for the oldest file in dir or the current time if empty.
@@ -447,8 +847,24 @@ func lastRefresh(dir string) time.Time {
 	return oldest.In(time.UTC)
 }
 
+// oldestModTime returns the modification time of the oldest file in paths
+func oldestModTime(paths []string) time.Time {
+	var oldest time.Time
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if t := info.ModTime(); oldest.IsZero() || t.Before(oldest) {
+			oldest = t
+		}
+	}
+	return oldest.In(time.UTC)
+}
+
 func addDownloadRoutes(logger log.Logger, r *mux.Router, repo downloadRepository) {
 	r.Methods("GET").Path("/downloads").HandlerFunc(getLatestDownloads(logger, repo))
+	r.Methods("GET").Path("/downloads/{downloadID}/changes").HandlerFunc(getDownloadChanges(logger, repo))
 }
 
 func getLatestDownloads(logger log.Logger, repo downloadRepository) http.HandlerFunc {
@@ -478,6 +894,9 @@ func getLatestDownloads(logger log.Logger, repo downloadRepository) http.Handler
 type downloadRepository interface {
 	latestDownloads(limit int) ([]DownloadStats, error)
 	recordStats(stats *DownloadStats) error
+
+	// downloadChanges returns the changes of each list for a download, or nil if the download doesn't exist
+	downloadChanges(downloadID string) (map[string]*ListChanges, error)
 }
 
 type sqliteDownloadRepository struct {
@@ -494,19 +913,75 @@ func (r *sqliteDownloadRepository) recordStats(stats *DownloadStats) error {
 		return errors.New("recordStats: nil downloadStats")
 	}
 
-	query := `insert into download_stats (downloaded_at, sdns, alt_names, addresses, sectoral_sanctions, denied_persons, bis_entities) values (?, ?, ?, ?, ?, ?, ?);`
-	stmt, err := r.db.Prepare(query)
+	var quality sql.NullString
+	if len(stats.Quality) > 0 {
+		bs, err := json.Marshal(stats.Quality)
+		if err != nil {
+			return fmt.Errorf("recordStats: quality: %v", err)
+		}
+		quality = sql.NullString{String: string(bs), Valid: true}
+	}
+	var freshness sql.NullString
+	if len(stats.Freshness) > 0 {
+		bs, err := json.Marshal(stats.Freshness)
+		if err != nil {
+			return fmt.Errorf("recordStats: freshness: %v", err)
+		}
+		freshness = sql.NullString{String: string(bs), Valid: true}
+	}
+	var changes sql.NullString
+	if len(stats.Changes) > 0 {
+		bs, err := json.Marshal(stats.Changes)
+		if err != nil {
+			return fmt.Errorf("recordStats: changes: %v", err)
+		}
+		changes = sql.NullString{String: string(bs), Valid: true}
+	}
+	if stats.ID == "" {
+		stats.ID = base.ID()
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `insert into download_stats (download_id, downloaded_at, sdns, alt_names, addresses, sectoral_sanctions, denied_persons, bis_entities, quality, freshness, changes) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	_, err = tx.Exec(query, stats.ID, stats.RefreshedAt, stats.SDNs, stats.Alts, stats.Addresses, stats.SectoralSanctions, stats.DeniedPersons, stats.BISEntities, quality, freshness, changes)
+	if err != nil {
+		return err
+	}
+	if err := recordChanges(tx, stats.ID, stats.changes); err != nil {
+		return fmt.Errorf("recordStats: changes: %v", err)
+	}
+	return tx.Commit()
+}
+
+func recordChanges(tx *sql.Tx, downloadID string, changes map[string]*ListChanges) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	stmt, err := tx.Prepare(`insert into download_changes (download_id, list, change_type, entity_id, name) values (?, ?, ?, ?, ?);`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(stats.RefreshedAt, stats.SDNs, stats.Alts, stats.Addresses, stats.SectoralSanctions, stats.DeniedPersons, stats.BISEntities)
-	return err
+	for list, c := range changes {
+		for changeType, entities := range map[string][]EntityChange{"added": c.Added, "removed": c.Removed, "modified": c.Modified} {
+			for _, entity := range entities {
+				if _, err := stmt.Exec(downloadID, list, changeType, entity.ID, entity.Name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
 }
 
 func (r *sqliteDownloadRepository) latestDownloads(limit int) ([]DownloadStats, error) {
-	query := `select downloaded_at, sdns, alt_names, addresses, sectoral_sanctions, denied_persons, bis_entities from download_stats order by downloaded_at desc limit ?;`
+	query := `select download_id, downloaded_at, sdns, alt_names, addresses, sectoral_sanctions, denied_persons, bis_entities, quality, freshness, changes from download_stats order by downloaded_at desc limit ?;`
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return nil, err
@@ -522,9 +997,65 @@ func (r *sqliteDownloadRepository) latestDownloads(limit int) ([]DownloadStats,
 	var downloads []DownloadStats
 	for rows.Next() {
 		var dl DownloadStats
-		if err := rows.Scan(&dl.RefreshedAt, &dl.SDNs, &dl.Alts, &dl.Addresses, &dl.SectoralSanctions, &dl.DeniedPersons, &dl.BISEntities); err == nil {
+		var id, quality, freshness, changes sql.NullString
+		if err := rows.Scan(&id, &dl.RefreshedAt, &dl.SDNs, &dl.Alts, &dl.Addresses, &dl.SectoralSanctions, &dl.DeniedPersons, &dl.BISEntities, &quality, &freshness, &changes); err == nil {
+			dl.ID = id.String
+			if quality.Valid && quality.String != "" {
+				if err := json.Unmarshal([]byte(quality.String), &dl.Quality); err != nil {
+					r.logger.Warn().Logf("reading download quality: %v", err)
+				}
+			}
+			if freshness.Valid && freshness.String != "" {
+				if err := json.Unmarshal([]byte(freshness.String), &dl.Freshness); err != nil {
+					r.logger.Warn().Logf("reading download freshness: %v", err)
+				}
+			}
+			if changes.Valid && changes.String != "" {
+				if err := json.Unmarshal([]byte(changes.String), &dl.Changes); err != nil {
+					r.logger.Warn().Logf("reading download changes: %v", err)
+				}
+			}
 			downloads = append(downloads, dl)
 		}
 	}
 	return downloads, rows.Err()
 }
+
+func (r *sqliteDownloadRepository) downloadChanges(downloadID string) (map[string]*ListChanges, error) {
+	var n int
+	if err := r.db.QueryRow(`select count(*) from download_stats where download_id = ?;`, downloadID).Scan(&n); err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(`select list, change_type, entity_id, name from download_changes where download_id = ? order by list, entity_id;`, downloadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[string]*ListChanges)
+	for rows.Next() {
+		var list, changeType string
+		var entity EntityChange
+		if err := rows.Scan(&list, &changeType, &entity.ID, &entity.Name); err != nil {
+			return nil, err
+		}
+		c, exists := out[list]
+		if !exists {
+			c = &ListChanges{}
+			out[list] = c
+		}
+		switch changeType {
+		case "added":
+			c.Added = append(c.Added, entity)
+		case "removed":
+			c.Removed = append(c.Removed, entity)
+		case "modified":
+			c.Modified = append(c.Modified, entity)
+		}
+	}
+	return out, rows.Err()
+}
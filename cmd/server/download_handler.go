@@ -53,6 +53,8 @@ func manualRefreshHandler(logger log.Logger, searcher *searcher, updates chan *D
 				"EUCSL":           log.Int(stats.EUCSL),
 				"UKCSL":           log.Int(stats.UKCSL),
 				"UKSanctionsList": log.Int(stats.UKSanctionsList),
+				"PEP":             log.Int(stats.PoliticallyExposedPersons),
+				"FtM":             log.Int(stats.FtMEntities),
 			}).Logf("admin: finished data refresh %v ago", time.Since(stats.RefreshedAt))
 
 			json.NewEncoder(w).Encode(stats)
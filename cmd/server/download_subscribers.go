@@ -0,0 +1,489 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base"
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+
+	"github.com/gorilla/mux"
+)
+
+var (
+	errNoSubscriberID      = errors.New("no subscriberID found")
+	errSubscriberNotFound  = errors.New("download subscriber not found")
+	errNoSubscriberWebhook = errors.New("no webhook provided for download subscriber")
+)
+
+// downloadSubscriber is a webhook called after data refreshes. Subscribers with lists are only
+// called when one of those lists changed or failed to refresh.
+type downloadSubscriber struct {
+	ID        string    `json:"subscriberID"`
+	Webhook   string    `json:"webhook"`
+	authToken string    // never returned
+	Lists     []string  `json:"lists,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// SigningSecret is only returned when the subscriber is created
+	SigningSecret string `json:"signingSecret,omitempty"`
+}
+
+type downloadSubscriberRequest struct {
+	Webhook   string   `json:"webhook"`
+	AuthToken string   `json:"authToken"`
+	Lists     []string `json:"lists"`
+}
+
+// downloadSubscriberSecret is the HMAC key a download subscriber's webhooks are signed with
+type downloadSubscriberSecret struct {
+	SubscriberID      string     `json:"subscriberID"`
+	SigningSecret     string     `json:"signingSecret"`
+	PreviousExpiresAt *time.Time `json:"previousExpiresAt,omitempty"`
+}
+
+// subscribed reports if sub is notified of a refresh which changed or failed the given lists
+func (sub *downloadSubscriber) subscribed(lists map[string]downloadListDetail) bool {
+	if len(sub.Lists) == 0 {
+		return true
+	}
+	for _, list := range sub.Lists {
+		if d, ok := lists[list]; ok && (d.Error != "" || d.Changes.changed()) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSubscriberLists returns lists as they're written in allLists. Only the lists which are
+// compared between refreshes can be subscribed to, as the others are never seen changing.
+func validateSubscriberLists(lists []string) ([]string, error) {
+	out, err := validateWatchLists(lists)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range out {
+		diffed := false
+		for _, name := range diffedLists {
+			diffed = diffed || name == list
+		}
+		if !diffed {
+			return nil, fmt.Errorf("list %s isn't compared between refreshes, expected one of %s", list, strings.Join(diffedLists, ", "))
+		}
+	}
+	return out, nil
+}
+
+// downloadNotification is the body download subscribers are sent after a refresh
+type downloadNotification struct {
+	SubscriberID string                        `json:"subscriberID"`
+	DownloadID   string                        `json:"downloadID"`
+	Lists        map[string]downloadListDetail `json:"lists"`
+	Timestamp    time.Time                     `json:"timestamp"`
+}
+
+// downloadListDetail is how a list fared in a refresh
+type downloadListDetail struct {
+	// Entities is how many entities the list holds after the refresh
+	Entities int `json:"entities"`
+
+	// Changes is only set for lists which were compared against the previous refresh
+	Changes *ChangeSummary `json:"changes,omitempty"`
+
+	// Error is set when the list failed to refresh and kept its previous data
+	Error string `json:"error,omitempty"`
+}
+
+// downloadListDetails describes every list in stats
+func downloadListDetails(stats *DownloadStats) map[string]downloadListDetail {
+	out := make(map[string]downloadListDetail, len(allLists))
+	for _, list := range allLists {
+		detail := downloadListDetail{
+			Entities: listEntityCount(stats, list),
+		}
+		if changes, ok := stats.changes[list]; ok && changes != nil {
+			summary := changes.summary()
+			detail.Changes = &summary
+		}
+		if q := stats.Quality[list]; q != nil && q.Error != "" {
+			detail.Error = q.Error
+		}
+		out[list] = detail
+	}
+	return out
+}
+
+// listEntityCount returns the entities on list, as they're counted in DownloadStats
+func listEntityCount(stats *DownloadStats, list string) int {
+	switch list {
+	case "SDNs":
+		return stats.SDNs
+	case "DPs":
+		return stats.DeniedPersons
+	case "CSL":
+		return stats.BISEntities + stats.MilitaryEndUsers + stats.SectoralSanctions + stats.Unverified +
+			stats.NonProliferationSanctions + stats.ForeignSanctionsEvaders + stats.PalestinianLegislativeCouncil +
+			stats.CAPTA + stats.ITARDebarred + stats.ChineseMilitaryIndustrialComplex + stats.NonSDNMenuBasedSanctions
+	case "EUCSL":
+		return stats.EUCSL
+	case "UKCSL":
+		return stats.UKCSL
+	case "UKSanctionsList":
+		return stats.UKSanctionsList
+	case "PEPs":
+		return stats.PoliticallyExposedPersons
+	case "FtM":
+		return stats.FtMEntities
+	}
+	return 0
+}
+
+// notifyDownloadSubscribers queues a webhook for every subscriber interested in the refresh.
+// Like watches, only the replica holding the watch leader lease notifies subscribers.
+func notifyDownloadSubscribers(logger log.Logger, stats *DownloadStats, repo downloadSubscriberRepository, queue *webhookQueue, leader *leaderElection) {
+	if stats == nil || !leader.isLeader() {
+		return
+	}
+	subscribers, err := repo.listSubscribers()
+	if err != nil {
+		logger.Error().LogErrorf("problem reading download subscribers: %v", err)
+		return
+	}
+
+	lists := downloadListDetails(stats)
+	for _, sub := range subscribers {
+		if !sub.subscribed(lists) {
+			continue
+		}
+		note := downloadNotification{
+			SubscriberID: sub.ID,
+			DownloadID:   stats.ID,
+			Lists:        lists,
+			Timestamp:    stats.RefreshedAt,
+		}
+		if len(sub.Lists) > 0 {
+			note.Lists = make(map[string]downloadListDetail, len(sub.Lists))
+			for _, list := range sub.Lists {
+				note.Lists[list] = lists[list]
+			}
+		}
+
+		var body bytes.Buffer
+		if err := json.NewEncoder(&body).Encode(note); err != nil {
+			logger.Error().LogErrorf("problem encoding download subscriber %s body: %v", sub.ID, err)
+			continue
+		}
+		if _, err := queue.enqueueSubscriber(sub, &body); err != nil {
+			logger.Error().LogErrorf("problem queueing download subscriber %s webhook: %v", sub.ID, err)
+		}
+	}
+}
+
+// HTTP routes
+
+func getSubscriberID(w http.ResponseWriter, r *http.Request) string {
+	v, ok := mux.Vars(r)["subscriberID"]
+	if !ok || v == "" {
+		moovhttp.Problem(w, errNoSubscriberID)
+		return ""
+	}
+	return v
+}
+
+func addDownloadSubscriberRoutes(logger log.Logger, r *mux.Router, repo downloadSubscriberRepository) {
+	r.Methods("GET").Path("/downloads/subscribers").HandlerFunc(listDownloadSubscribers(logger, repo))
+	r.Methods("POST").Path("/downloads/subscribers").HandlerFunc(addDownloadSubscriber(logger, repo))
+	r.Methods("GET").Path("/downloads/subscribers/{subscriberID}").HandlerFunc(getDownloadSubscriber(logger, repo))
+	r.Methods("DELETE").Path("/downloads/subscribers/{subscriberID}").HandlerFunc(removeDownloadSubscriber(logger, repo))
+	r.Methods("POST").Path("/downloads/subscribers/{subscriberID}/secret").HandlerFunc(rotateDownloadSubscriberSecret(logger, repo))
+	r.Methods("DELETE").Path("/downloads/subscribers/{subscriberID}/secret/previous").HandlerFunc(expirePreviousDownloadSubscriberSecret(logger, repo))
+}
+
+func addDownloadSubscriber(logger log.Logger, repo downloadSubscriberRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		var req downloadSubscriberRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if req.Webhook == "" {
+			moovhttp.Problem(w, errNoSubscriberWebhook)
+			return
+		}
+		webhook, err := validateWebhook(req.Webhook)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		var lists []string
+		if len(req.Lists) > 0 {
+			if lists, err = validateSubscriberLists(req.Lists); err != nil {
+				moovhttp.Problem(w, err)
+				return
+			}
+		}
+
+		sub := &downloadSubscriber{
+			ID:        base.ID(),
+			Webhook:   webhook,
+			authToken: req.AuthToken,
+			Lists:     lists,
+			CreatedAt: time.Now(),
+		}
+		if err := repo.addSubscriber(sub); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		secret, err := repo.rotateSigningSecret(sub.ID, time.Now())
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		sub.SigningSecret = secret.SigningSecret
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+		}).Logf("added download subscriber=%s", sub.ID)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(sub)
+	}
+}
+
+func listDownloadSubscribers(logger log.Logger, repo downloadSubscriberRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		subscribers, err := repo.listSubscribers()
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if subscribers == nil {
+			subscribers = []*downloadSubscriber{}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(subscribers)
+	}
+}
+
+func getDownloadSubscriber(logger log.Logger, repo downloadSubscriberRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		subscriberID := getSubscriberID(w, r)
+		if subscriberID == "" {
+			return
+		}
+		sub, err := repo.getSubscriber(subscriberID)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if sub == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(sub)
+	}
+}
+
+func removeDownloadSubscriber(logger log.Logger, repo downloadSubscriberRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		subscriberID := getSubscriberID(w, r)
+		if subscriberID == "" {
+			return
+		}
+		if err := repo.removeSubscriber(subscriberID); err != nil {
+			if errors.Is(err, errSubscriberNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+		}).Logf("removed download subscriber=%s", subscriberID)
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func rotateDownloadSubscriberSecret(logger log.Logger, repo downloadSubscriberRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		subscriberID := getSubscriberID(w, r)
+		if subscriberID == "" {
+			return
+		}
+		if sub, err := repo.getSubscriber(subscriberID); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		} else if sub == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		secret, err := repo.rotateSigningSecret(subscriberID, time.Now().Add(watchSecretRotationGrace))
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+		}).Logf("rotated signing secret for download subscriber=%s", subscriberID)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(secret)
+	}
+}
+
+func expirePreviousDownloadSubscriberSecret(logger log.Logger, repo downloadSubscriberRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		subscriberID := getSubscriberID(w, r)
+		if subscriberID == "" {
+			return
+		}
+		if err := repo.expirePreviousSigningSecret(subscriberID); err != nil {
+			if errors.Is(err, errSubscriberNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+		}).Logf("expired previous signing secret for download subscriber=%s", subscriberID)
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// Repository
+
+type downloadSubscriberRepository interface {
+	addSubscriber(sub *downloadSubscriber) error
+	removeSubscriber(subscriberID string) error
+	getSubscriber(subscriberID string) (*downloadSubscriber, error)
+	listSubscribers() ([]*downloadSubscriber, error)
+
+	rotateSigningSecret(subscriberID string, previousExpiresAt time.Time) (*downloadSubscriberSecret, error)
+	expirePreviousSigningSecret(subscriberID string) error
+	getSigningSecrets(subscriberID string, now time.Time) ([]string, error)
+}
+
+type sqliteDownloadSubscriberRepository struct {
+	db *sql.DB
+}
+
+func (r *sqliteDownloadSubscriberRepository) close() error {
+	return r.db.Close()
+}
+
+func (r *sqliteDownloadSubscriberRepository) addSubscriber(sub *downloadSubscriber) error {
+	query := `insert into download_subscribers (id, webhook, auth_token, lists, created_at) values (?, ?, ?, ?, ?);`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(sub.ID, sub.Webhook, sub.authToken, strings.Join(sub.Lists, ","), sub.CreatedAt)
+	return err
+}
+
+func (r *sqliteDownloadSubscriberRepository) removeSubscriber(subscriberID string) error {
+	query := `update download_subscribers set deleted_at = ? where id = ? and deleted_at is null;`
+	res, err := r.db.Exec(query, time.Now(), subscriberID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errSubscriberNotFound
+	}
+	return nil
+}
+
+// getSubscriber returns nil if subscriberID doesn't exist or was removed
+func (r *sqliteDownloadSubscriberRepository) getSubscriber(subscriberID string) (*downloadSubscriber, error) {
+	subscribers, err := r.querySubscribers(`select id, webhook, auth_token, lists, created_at from download_subscribers where id = ? and deleted_at is null limit 1;`, subscriberID)
+	if err != nil || len(subscribers) == 0 {
+		return nil, err
+	}
+	return subscribers[0], nil
+}
+
+// listSubscribers returns every subscriber, oldest first
+func (r *sqliteDownloadSubscriberRepository) listSubscribers() ([]*downloadSubscriber, error) {
+	return r.querySubscribers(`select id, webhook, auth_token, lists, created_at from download_subscribers where deleted_at is null order by created_at asc;`)
+}
+
+func (r *sqliteDownloadSubscriberRepository) querySubscribers(query string, args ...interface{}) ([]*downloadSubscriber, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*downloadSubscriber
+	for rows.Next() {
+		var sub downloadSubscriber
+		var authToken, lists sql.NullString
+		if err := rows.Scan(&sub.ID, &sub.Webhook, &authToken, &lists, &sub.CreatedAt); err != nil {
+			return nil, err
+		}
+		sub.authToken = authToken.String
+		sub.Lists = splitWatchLists(lists.String)
+		out = append(out, &sub)
+	}
+	return out, rows.Err()
+}
+
+func (r *sqliteDownloadSubscriberRepository) rotateSigningSecret(subscriberID string, previousExpiresAt time.Time) (*downloadSubscriberSecret, error) {
+	secret, expiresAt, err := rotateSigningSecret(r.db, subscriberID, previousExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &downloadSubscriberSecret{SubscriberID: subscriberID, SigningSecret: secret, PreviousExpiresAt: expiresAt}, nil
+}
+
+func (r *sqliteDownloadSubscriberRepository) expirePreviousSigningSecret(subscriberID string) error {
+	found, err := expirePreviousSigningSecret(r.db, subscriberID)
+	if err == nil && !found {
+		return errSubscriberNotFound
+	}
+	return err
+}
+
+func (r *sqliteDownloadSubscriberRepository) getSigningSecrets(subscriberID string, now time.Time) ([]string, error) {
+	return getSigningSecrets(r.db, subscriberID, now)
+}
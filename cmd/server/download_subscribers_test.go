@@ -0,0 +1,205 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/client"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadSubscribers__routes(t *testing.T) {
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteDownloadSubscriberRepository{db.DB}
+
+	router := mux.NewRouter()
+	addDownloadSubscriberRoutes(log.NewNopLogger(), router, repo)
+
+	for _, body := range []string{`{}`, `{"webhook":"http://example.com"}`, `{"webhook":"https://example.com","lists":["OFAC"]}`, `{"webhook":"https://example.com","lists":["EUCSL","DPs"]}`} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/downloads/subscribers", bytes.NewBufferString(body)))
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	w := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"webhook":"https://example.com/refreshed","authToken":"authToken","lists":["eucsl"]}`)
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/downloads/subscribers", body))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var created downloadSubscriber
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	require.NotEmpty(t, created.SigningSecret)
+	require.Equal(t, []string{"EUCSL"}, created.Lists)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/downloads/subscribers/"+created.ID, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "authToken")
+	require.NotContains(t, w.Body.String(), "signingSecret")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/downloads/subscribers", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var subscribers []downloadSubscriber
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&subscribers))
+	require.Len(t, subscribers, 1)
+	require.Equal(t, "https://example.com/refreshed", subscribers[0].Webhook)
+
+	// rotate the secret, both sign until the previous one is expired
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/downloads/subscribers/"+created.ID+"/secret", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var secret downloadSubscriberSecret
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&secret))
+	require.NotNil(t, secret.PreviousExpiresAt)
+
+	secrets, err := repo.getSigningSecrets(created.ID, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, []string{secret.SigningSecret, created.SigningSecret}, secrets)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/downloads/subscribers/"+created.ID+"/secret/previous", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	secrets, err = repo.getSigningSecrets(created.ID, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, []string{secret.SigningSecret}, secrets)
+
+	// remove the subscriber
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/downloads/subscribers/"+created.ID, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest("GET", "/downloads/subscribers/"+created.ID, nil),
+		httptest.NewRequest("DELETE", "/downloads/subscribers/"+created.ID, nil),
+		httptest.NewRequest("POST", "/downloads/subscribers/"+created.ID+"/secret", nil),
+		httptest.NewRequest("DELETE", "/downloads/subscribers/missing/secret/previous", nil),
+	} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code, req.URL.Path)
+	}
+}
+
+func TestDownloadSubscribers__notify(t *testing.T) {
+	queue := createTestWebhookQueue(t)
+	repo := queue.subscriberRepo
+
+	add := func(t *testing.T, webhook string, lists ...string) *downloadSubscriber {
+		t.Helper()
+		sub := &downloadSubscriber{ID: webhook, Webhook: "https://example.com/" + webhook, Lists: lists, CreatedAt: time.Now()}
+		require.NoError(t, repo.addSubscriber(sub))
+		return sub
+	}
+	everything := add(t, "everything")
+	eu := add(t, "eu", "EUCSL")
+	uk := add(t, "uk", "UKCSL")
+	sdns := add(t, "sdns", "SDNs")
+
+	stats := &DownloadStats{
+		ID:    "downloadID",
+		SDNs:  10,
+		EUCSL: 4,
+		changes: map[string]*ListChanges{
+			"SDNs":  {},
+			"EUCSL": {Added: []EntityChange{{ID: "1"}}, Removed: []EntityChange{{ID: "2"}}},
+		},
+		Quality:     QualityReport{"UKCSL": &ListQuality{Error: "download: connection refused"}},
+		RefreshedAt: time.Now(),
+	}
+
+	// followers don't notify subscribers
+	follower := &leaderElection{}
+	notifyDownloadSubscribers(log.NewNopLogger(), stats, repo, queue, follower)
+
+	deliveries, err := queue.repo.listDeliveries(deliveryFilter{limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	notifyDownloadSubscribers(log.NewNopLogger(), stats, repo, queue, nil)
+
+	read := func(t *testing.T, sub *downloadSubscriber) *downloadNotification {
+		t.Helper()
+		deliveries, err := queue.repo.listDeliveries(deliveryFilter{subscriberID: sub.ID, limit: 10})
+		require.NoError(t, err)
+		if len(deliveries) == 0 {
+			return nil
+		}
+		require.Len(t, deliveries, 1)
+		require.Empty(t, deliveries[0].WatchID)
+
+		d, err := queue.repo.getDelivery(deliveries[0].ID)
+		require.NoError(t, err)
+
+		var note downloadNotification
+		require.NoError(t, json.Unmarshal(d.Body, &note))
+		require.Equal(t, sub.ID, note.SubscriberID)
+		require.Equal(t, "downloadID", note.DownloadID)
+		return &note
+	}
+
+	note := read(t, everything)
+	require.NotNil(t, note)
+	require.Len(t, note.Lists, len(allLists))
+	require.Equal(t, downloadListDetail{Entities: 10, Changes: &ChangeSummary{}}, note.Lists["SDNs"])
+	require.Nil(t, note.Lists["DPs"].Changes)
+
+	note = read(t, eu)
+	require.NotNil(t, note)
+	require.Len(t, note.Lists, 1)
+	require.Equal(t, downloadListDetail{Entities: 4, Changes: &ChangeSummary{Added: 1, Removed: 1}}, note.Lists["EUCSL"])
+
+	note = read(t, uk)
+	require.NotNil(t, note)
+	require.Equal(t, "download: connection refused", note.Lists["UKCSL"].Error)
+
+	// nothing changed on the SDNs
+	require.Nil(t, read(t, sdns))
+}
+
+func TestDownloadSubscribers__delivery(t *testing.T) {
+	var received []byte
+	var header http.Header
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		header = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	trustTestServer(t, server)
+
+	queue := createTestWebhookQueue(t)
+	sub := &downloadSubscriber{ID: "subscriberID", Webhook: server.URL, authToken: "authToken", CreatedAt: time.Now()}
+	require.NoError(t, queue.subscriberRepo.addSubscriber(sub))
+	secret, err := queue.subscriberRepo.rotateSigningSecret(sub.ID, time.Now())
+	require.NoError(t, err)
+
+	d, err := queue.enqueueSubscriber(sub, bytes.NewBufferString(`{"downloadID":"downloadID"}`))
+	require.NoError(t, err)
+	require.NoError(t, queue.deliverDue(time.Now()))
+
+	found, err := queue.repo.getDelivery(d.ID)
+	require.NoError(t, err)
+	require.Equal(t, deliveryDelivered, found.Status)
+	require.Equal(t, "subscriberID", found.SubscriberID)
+
+	require.Equal(t, "authToken", header.Get("Authorization"))
+	require.NoError(t, client.VerifyWebhook(secret.SigningSecret, header, received, time.Minute))
+}
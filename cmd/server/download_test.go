@@ -84,16 +84,60 @@ func TestSearcher__refreshData(t *testing.T) {
 	if len(s.BISEntities) == 0 || stats.BISEntities == 0 {
 		t.Errorf("empty searcher.BISEntities=%d or stats.BISEntities=%d", len(s.BISEntities), stats.BISEntities)
 	}
+	if q := stats.Quality["SDNs"]; q == nil || q.RowsRead < stats.SDNs {
+		t.Errorf("unexpected SDN quality report: %#v", q)
+	}
+	if f := stats.Freshness["SDNs"]; f.RefreshedAt.IsZero() || f.Error != "" {
+		t.Errorf("unexpected SDN freshness: %#v", f)
+	}
+}
+
+func TestDownload__nextFreshness(t *testing.T) {
+	first := time.Date(2022, time.May, 21, 9, 4, 0, 0, time.UTC)
+	second := first.Add(12 * time.Hour)
+	lists := []string{"SDNs", "EUCSL"}
+
+	// the EU list fails before it has ever loaded
+	quality := make(QualityReport)
+	quality.keepPrevious("EUCSL", errors.New("download: connection refused"))
+	freshness := nextFreshness(nil, quality, lists, first)
+	require.Equal(t, first, freshness["SDNs"].RefreshedAt)
+	require.True(t, freshness["EUCSL"].RefreshedAt.IsZero())
+	require.Equal(t, "download: connection refused", freshness["EUCSL"].Error)
+
+	// both lists load
+	freshness = nextFreshness(freshness, make(QualityReport), lists, first)
+	require.Equal(t, first, freshness["EUCSL"].RefreshedAt)
+	require.Empty(t, freshness["EUCSL"].Error)
+
+	// OFAC fails and keeps its first timestamp while the EU list moves on
+	quality = make(QualityReport)
+	quality.keepPrevious("SDNs", errors.New("read: bad file"))
+	freshness = nextFreshness(freshness, quality, lists, second)
+	require.Equal(t, first, freshness["SDNs"].RefreshedAt)
+	require.Equal(t, "read: bad file", freshness["SDNs"].Error)
+	require.Equal(t, second, freshness["EUCSL"].RefreshedAt)
 }
 
 func TestDownload_record(t *testing.T) {
 	t.Parallel()
 
 	check := func(t *testing.T, repo *sqliteDownloadRepository) {
+		refreshedAt := time.Date(2022, time.May, 21, 9, 4, 0, 0, time.UTC)
 		stats := &DownloadStats{
 			SDNs: 1, Alts: 12, Addresses: 42,
 			DeniedPersons: 13,
 			BISEntities:   32, SectoralSanctions: 39,
+			Freshness: map[string]ListFreshness{
+				"SDNs":  {RefreshedAt: refreshedAt},
+				"EUCSL": {Error: "download: connection refused"},
+			},
+			Changes: map[string]ChangeSummary{
+				"SDNs": {Added: 1, Removed: 2},
+			},
+			Quality: QualityReport{
+				"SDNs": {RowsRead: 10, RowsSkipped: 1, SkipReasons: map[string]int{"no name": 1}},
+			},
 		}
 		if err := repo.recordStats(stats); err != nil {
 			t.Fatal(err)
@@ -125,6 +169,12 @@ func TestDownload_record(t *testing.T) {
 		if dl.BISEntities != stats.BISEntities {
 			t.Errorf("dl.BISEntities=%d stats.BISEntities=%d", dl.BISEntities, stats.BISEntities)
 		}
+		require.True(t, refreshedAt.Equal(dl.Freshness["SDNs"].RefreshedAt))
+		require.Equal(t, "download: connection refused", dl.Freshness["EUCSL"].Error)
+		require.NotEmpty(t, dl.ID)
+		require.Equal(t, stats.ID, dl.ID)
+		require.Equal(t, stats.Changes, dl.Changes)
+		require.Equal(t, stats.Quality, dl.Quality)
 	}
 
 	// SQLite tests
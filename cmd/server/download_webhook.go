@@ -16,6 +16,7 @@ import (
 func callDownloadWebook(logger log.Logger, stats *DownloadStats) {
 	webhookURL := strings.TrimSpace(os.Getenv("DOWNLOAD_WEBHOOK_URL"))
 	webhookAuthToken := strings.TrimSpace(os.Getenv("DOWNLOAD_WEBHOOK_AUTH_TOKEN"))
+	webhookSecret := strings.TrimSpace(os.Getenv("DOWNLOAD_WEBHOOK_SECRET"))
 
 	if webhookURL == "" {
 		return
@@ -25,7 +26,11 @@ func callDownloadWebook(logger log.Logger, stats *DownloadStats) {
 	var body bytes.Buffer
 	json.NewEncoder(&body).Encode(stats)
 
-	statusCode, err := callWebhook(&body, webhookURL, webhookAuthToken)
+	var secrets []string
+	if webhookSecret != "" {
+		secrets = append(secrets, webhookSecret)
+	}
+	statusCode, err := callWebhook(&body, webhookURL, webhookAuthToken, secrets...)
 	if err != nil {
 		logger.Error().LogErrorf("problem calling download webhook: %v", err)
 	} else {
@@ -7,13 +7,28 @@ package main
 import (
 	"net/url"
 	"strings"
+	"unicode"
+
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/pep"
 )
 
 type filterRequest struct {
 	sdnType     string
 	ofacProgram string
+
+	// pepStatus only applies to Politically Exposed Persons
+	pepStatus pep.Status
+
+	// The following filters only apply to the US Consolidated Screening List
+	citizenship  string
+	nationality  string
+	dateOfBirth  string
+	placeOfBirth string
+	idNumber     string
 }
 
+// empty returns true when no OFAC SDN filters are set
 func (req filterRequest) empty() bool {
 	return req.sdnType == "" && req.ofacProgram == ""
 }
@@ -22,7 +37,89 @@ func buildFilterRequest(u *url.URL) filterRequest {
 	return filterRequest{
 		sdnType:     u.Query().Get("sdnType"),
 		ofacProgram: u.Query().Get("ofacProgram"),
+		pepStatus:   pep.ParseStatus(u.Query().Get("pepStatus")),
+
+		citizenship:  strings.TrimSpace(u.Query().Get("citizenship")),
+		nationality:  strings.TrimSpace(u.Query().Get("nationality")),
+		dateOfBirth:  strings.TrimSpace(u.Query().Get("dateOfBirth")),
+		placeOfBirth: strings.TrimSpace(u.Query().Get("placeOfBirth")),
+		idNumber:     strings.TrimSpace(u.Query().Get("idNumber")),
+	}
+}
+
+// cslEmpty returns true when no US Consolidated Screening List filters are set
+func (req filterRequest) cslEmpty() bool {
+	return req.citizenship == "" && req.nationality == "" && req.dateOfBirth == "" &&
+		req.placeOfBirth == "" && req.idNumber == ""
+}
+
+// filterCSL returns the records matching every CSL filter which is set.
+// Records without the filtered data (e.g. a company without any nationalities) are excluded.
+func filterCSL[T csl.Detailed](data []*Result[T], req filterRequest) []*Result[T] {
+	if req.cslEmpty() {
+		// short-circuit and return if we have no filters
+		return data
+	}
+
+	var out []*Result[T]
+	for i := range data {
+		if keepCSL(data[i].Data.CSLDetails(), req) {
+			out = append(out, data[i])
+		}
+	}
+	return out
+}
+
+// keepCSL checks the structured fields every US CSL record type carries
+func keepCSL(details csl.Details, req filterRequest) bool {
+	if req.citizenship != "" && !anyMatch(details.Citizenships, req.citizenship, strings.EqualFold) {
+		return false
 	}
+	if req.nationality != "" && !anyMatch(details.Nationalities, req.nationality, strings.EqualFold) {
+		return false
+	}
+	if req.dateOfBirth != "" && !anyMatch(details.DatesOfBirth, req.dateOfBirth, strings.HasPrefix) {
+		return false
+	}
+	if req.placeOfBirth != "" && !anyMatch(details.PlacesOfBirth, req.placeOfBirth, containsFold) {
+		return false
+	}
+	if req.idNumber != "" {
+		found := false
+		for j := range details.Identifications {
+			if normalizeIDNumber(details.Identifications[j].Number) == normalizeIDNumber(req.idNumber) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func anyMatch(values []string, needle string, match func(s, needle string) bool) bool {
+	for i := range values {
+		if match(values[i], needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(s, needle string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(needle))
+}
+
+// normalizeIDNumber drops formatting characters so "CHE-427.006.032" matches "CHE427006032"
+func normalizeIDNumber(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, s)
 }
 
 func filterSDNs(sdns []*SDN, req filterRequest) []*SDN {
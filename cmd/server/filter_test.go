@@ -8,7 +8,10 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/moov-io/watchman/pkg/csl"
 	"github.com/moov-io/watchman/pkg/ofac"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestFilter__buildFilterRequest(t *testing.T) {
@@ -193,3 +196,54 @@ func TestFilter__missing(t *testing.T) {
 		t.Errorf("sdns=%#v", sdns)
 	}
 }
+
+func TestFilter__CSL(t *testing.T) {
+	u, _ := url.Parse("/search?q=jane+doe&citizenship=ch&dateOfBirth=1966&idNumber=x0906223")
+	req := buildFilterRequest(u)
+	require.True(t, req.empty()) // no SDN filters
+	require.False(t, req.cslEmpty())
+
+	fses := precomputeCSLEntities[csl.FSE]([]*csl.FSE{
+		{
+			EntityID: "17526",
+			Name:     "BEKTAS, Halis",
+			Details: csl.Details{
+				Citizenships:    []string{"CH"},
+				DatesOfBirth:    []string{"1966-02-13"},
+				Identifications: []csl.CSLIdentification{{Country: "CH", Number: "X0906223", Type: "Passport"}},
+			},
+		},
+		{
+			EntityID: "17527",
+			Name:     "BEKTAS, Mehmet",
+			Details: csl.Details{
+				Citizenships: []string{"TR"},
+				DatesOfBirth: []string{"1966-03-01"},
+			},
+		},
+		{
+			EntityID: "17528",
+			Name:     "BEKTAS TRADING LTD",
+		},
+	}, noLogPipeliner)
+
+	out := filterCSL(fses, req)
+	require.Len(t, out, 1)
+	require.Equal(t, "17526", out[0].Data.EntityID)
+
+	// Date of birth prefix matches both individuals
+	out = filterCSL(fses, filterRequest{dateOfBirth: "1966"})
+	require.Len(t, out, 2)
+
+	// no filters keeps everything
+	require.Len(t, filterCSL(fses, filterRequest{sdnType: "individual"}), 3)
+
+	// records without the field are excluded
+	els := precomputeCSLEntities[csl.EL]([]*csl.EL{{ID: "1", Name: "Acme"}}, noLogPipeliner)
+	require.Empty(t, filterCSL(els, filterRequest{placeOfBirth: "damascus"}))
+}
+
+func TestFilter__normalizeIDNumber(t *testing.T) {
+	require.Equal(t, "CHE427006032", normalizeIDNumber("CHE-427.006.032"))
+	require.Equal(t, "X0906223", normalizeIDNumber(" x0906223 "))
+}
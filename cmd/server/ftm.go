@@ -0,0 +1,410 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ftm"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/pep"
+
+	"github.com/gorilla/mux"
+)
+
+func addFtMRoutes(logger log.Logger, r *mux.Router, searcher *searcher) {
+	r.Methods("GET").Path("/export/ftm").HandlerFunc(exportFtM(logger, searcher))
+}
+
+// TopFtMEntities searches imported FollowTheMoney entities by Name and Alias
+func (s *searcher) TopFtMEntities(limit int, minMatch float64, name string) []*Result[ftm.Entity] {
+	s.RLock()
+	defer s.RUnlock()
+
+	s.Gate.Start()
+	defer s.Gate.Done()
+
+	return topResults[ftm.Entity](limit, minMatch, name, s.FtMEntities)
+}
+
+// exportFtM streams every loaded record as FollowTheMoney entities in JSON Lines format.
+func exportFtM(logger log.Logger, searcher *searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+		requestID := moovhttp.GetRequestID(r)
+
+		w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+
+		count, err := searcher.exportFtM(ftm.NewWriter(w))
+		if err != nil {
+			// headers have been written, so we can only log the failure
+			logger.Warn().With(log.Fields{
+				"requestID": log.String(requestID),
+			}).LogErrorf("problem exporting FtM entities after %d records: %v", count, err)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"entities":  log.Int(count),
+			"requestID": log.String(requestID),
+		}).Log("exported FtM entities")
+	}
+}
+
+// exportFtM writes each loaded record from every list into w and returns how many entities were written.
+// The lock is only held to read the lists, as refreshes swap in new slices rather than changing them,
+// so slow clients don't hold up refreshes.
+func (s *searcher) exportFtM(w *ftm.Writer) (int, error) {
+	s.RLock()
+	sdns, sdnAddresses, sdnAlts, dps := s.SDNs, s.Addresses, s.Alts, s.DPs
+	bisEntities, meus, ssis, uvls, isns, fses, plcs := s.BISEntities, s.MilitaryEndUsers, s.SSIs, s.UVLs, s.ISNs, s.FSEs, s.PLCs
+	caps, dtcs, cmics, nsmbss := s.CAPs, s.DTCs, s.CMICs, s.NS_MBSs
+	euCSL, ukCSL, ukSanctionsList, peps, ftmEntities := s.EUCSL, s.UKCSL, s.UKSanctionsList, s.PEPs, s.FtMEntities
+	s.RUnlock()
+
+	count := 0
+	write := func(e *ftm.Entity) error {
+		if err := w.Write(e); err != nil {
+			return err
+		}
+		count++
+		return nil
+	}
+
+	steps := []func() error{
+		// OFAC
+		func() error {
+			addresses := make(map[string][]*ofac.Address)
+			for i := range sdnAddresses {
+				addr := sdnAddresses[i].Address
+				addresses[addr.EntityID] = append(addresses[addr.EntityID], addr)
+			}
+			alts := make(map[string][]*ofac.AlternateIdentity)
+			for i := range sdnAlts {
+				alt := sdnAlts[i].AlternateIdentity
+				alts[alt.EntityID] = append(alts[alt.EntityID], alt)
+			}
+			for i := range sdns {
+				if err := write(sdnToFtM(sdns[i].SDN, addresses[sdns[i].EntityID], alts[sdns[i].EntityID])); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+
+		// BIS
+		func() error {
+			for i := range dps {
+				if err := write(dplToFtM(dps[i].DeniedPerson)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+
+		// US CSL
+		func() error { return exportResults(bisEntities, write, elToFtM) },
+		func() error { return exportResults(meus, write, meuToFtM) },
+		func() error {
+			return exportResults(ssis, write, func(v *csl.SSI) *ftm.Entity {
+				return cslToFtM("us_trade_csl_ssi", v.EntityID, v.Type, v.Name, v.AlternateNames, v.Addresses, v.Programs, v.Remarks, v.IDsOnRecord, v.SourceInfoURL)
+			})
+		},
+		func() error {
+			return exportResults(uvls, write, func(v *csl.UVL) *ftm.Entity {
+				return cslToFtM("us_trade_csl_uvl", v.EntityID, "", v.Name, nil, v.Addresses, nil, nil, nil, v.SourceInfoURL)
+			})
+		},
+		func() error {
+			return exportResults(isns, write, func(v *csl.ISN) *ftm.Entity {
+				return cslToFtM("us_trade_csl_isn", v.EntityID, "", v.Name, v.AlternateNames, nil, v.Programs, v.Remarks, nil, v.SourceInfoURL)
+			})
+		},
+		func() error {
+			return exportResults(fses, write, func(v *csl.FSE) *ftm.Entity {
+				e := cslToFtM("us_trade_csl_fse", v.EntityID, v.Type, v.Name, nil, v.Addresses, v.Programs, nil, v.IDs, v.SourceInfoURL)
+				e.Add("birthDate", v.DatesOfBirth...)
+				e.Add("citizenship", v.Citizenships...)
+				return e
+			})
+		},
+		func() error {
+			return exportResults(plcs, write, func(v *csl.PLC) *ftm.Entity {
+				e := cslToFtM("us_trade_csl_plc", v.EntityID, v.Type, v.Name, v.AlternateNames, v.Addresses, v.Programs, []string{v.Remarks}, nil, v.SourceInfoURL)
+				e.Add("birthDate", v.DatesOfBirth...)
+				e.Add("birthPlace", v.PlacesOfBirth...)
+				return e
+			})
+		},
+		func() error {
+			return exportResults(caps, write, func(v *csl.CAP) *ftm.Entity {
+				return cslToFtM("us_trade_csl_cap", v.EntityID, v.Type, v.Name, v.AlternateNames, v.Addresses, v.Programs, v.Remarks, v.IDs, v.SourceInfoURL)
+			})
+		},
+		func() error {
+			return exportResults(dtcs, write, func(v *csl.DTC) *ftm.Entity {
+				return cslToFtM("us_trade_csl_dtc", v.EntityID, "", v.Name, v.AlternateNames, nil, nil, nil, nil, v.SourceInfoURL)
+			})
+		},
+		func() error {
+			return exportResults(cmics, write, func(v *csl.CMIC) *ftm.Entity {
+				return cslToFtM("us_trade_csl_cmic", v.EntityID, v.Type, v.Name, v.AlternateNames, v.Addresses, v.Programs, v.Remarks, v.IDs, v.SourceInfoURL)
+			})
+		},
+		func() error {
+			return exportResults(nsmbss, write, func(v *csl.NS_MBS) *ftm.Entity {
+				return cslToFtM("us_trade_csl_ns_mbs", v.EntityID, v.Type, v.Name, v.AlternateNames, v.Addresses, v.Programs, v.Remarks, v.IDs, v.SourceInfoURL)
+			})
+		},
+
+		// EU CSL
+		func() error { return exportResults(euCSL, write, euCSLToFtM) },
+
+		// UK CSL and Sanctions List
+		func() error { return exportResults(ukCSL, write, ukCSLToFtM) },
+		func() error { return exportResults(ukSanctionsList, write, ukSanctionsListToFtM) },
+
+		// PEP
+		func() error { return exportResults(peps, write, pepToFtM) },
+
+		// Imported entities are exported as-is
+		func() error {
+			return exportResults(ftmEntities, write, func(e *ftm.Entity) *ftm.Entity { return e })
+		},
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// exportResults converts each record with convert and writes it
+func exportResults[T any](records []*Result[T], write func(*ftm.Entity) error, convert func(*T) *ftm.Entity) error {
+	for i := range records {
+		if err := write(convert(&records[i].Data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newFtMEntity(dataset, id, schema string) *ftm.Entity {
+	return &ftm.Entity{
+		ID:       fmt.Sprintf("%s-%s", dataset, id),
+		Schema:   schema,
+		Datasets: []string{dataset},
+		Target:   true,
+	}
+}
+
+// ftmSchema maps the entity types used across lists onto FtM schemas
+func ftmSchema(entityType string) string {
+	switch strings.ToLower(strings.TrimSpace(entityType)) {
+	case "individual", "person", "p":
+		return ftm.SchemaPerson
+	case "vessel", "ship":
+		return ftm.SchemaVessel
+	case "aircraft":
+		return ftm.SchemaAirplane
+	case "entity", "enterprise", "e":
+		return ftm.SchemaOrganization
+	}
+	return ftm.SchemaLegalEntity
+}
+
+// ftmID returns a stable identifier for records which don't carry their own
+func ftmID(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func setFtMCaption(e *ftm.Entity) *ftm.Entity {
+	if names := e.Get("name"); len(names) > 0 {
+		e.Caption = names[0]
+	}
+	e.Add("topics", "sanction")
+	return e
+}
+
+func joinAddress(parts ...string) string {
+	var out []string
+	for i := range parts {
+		if p := strings.TrimSpace(parts[i]); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, ", ")
+}
+
+func sdnToFtM(sdn *ofac.SDN, addrs []*ofac.Address, alts []*ofac.AlternateIdentity) *ftm.Entity {
+	e := newFtMEntity("us_ofac_sdn", sdn.EntityID, ftmSchema(sdn.SDNType))
+	e.Add("name", sdn.SDNName)
+	for i := range alts {
+		e.Add("alias", alts[i].AlternateName)
+	}
+	for i := range addrs {
+		e.Add("address", joinAddress(addrs[i].Address, addrs[i].CityStateProvincePostalCode, addrs[i].Country))
+		e.Add("country", addrs[i].Country)
+	}
+	e.Add("program", sdn.Programs...)
+	e.Add("title", sdn.Title)
+	e.Add("notes", sdn.Remarks)
+	if e.IsA(ftm.SchemaVessel) {
+		e.Add("callSign", sdn.CallSign)
+		e.Add("type", sdn.VesselType)
+		e.Add("tonnage", sdn.Tonnage)
+		e.Add("grossRegisteredTonnage", sdn.GrossRegisteredTonnage)
+		e.Add("flag", sdn.VesselFlag)
+		e.Add("owner", sdn.VesselOwner)
+	}
+	return setFtMCaption(e)
+}
+
+func dplToFtM(dp *dpl.DPL) *ftm.Entity {
+	e := newFtMEntity("us_bis_denied", ftmID(dp.Name, dp.StreetAddress, dp.EffectiveDate), ftm.SchemaLegalEntity)
+	e.Add("name", dp.Name)
+	e.Add("address", joinAddress(dp.StreetAddress, dp.City, dp.State, dp.PostalCode, dp.Country))
+	e.Add("country", dp.Country)
+	e.Add("notes", dp.Action, dp.FRCitation)
+	return setFtMCaption(e)
+}
+
+func elToFtM(el *csl.EL) *ftm.Entity {
+	e := newFtMEntity("us_trade_csl_el", el.ID, ftm.SchemaLegalEntity)
+	e.Add("name", el.Name)
+	e.Add("alias", el.AlternateNames...)
+	e.Add("address", el.Addresses...)
+	e.Add("notes", el.LicenseRequirement, el.LicensePolicy, el.FRNotice)
+	e.Add("sourceUrl", el.SourceInfoURL)
+	return setFtMCaption(e)
+}
+
+func meuToFtM(meu *csl.MEU) *ftm.Entity {
+	e := newFtMEntity("us_trade_csl_meu", meu.EntityID, ftm.SchemaLegalEntity)
+	e.Add("name", meu.Name)
+	e.Add("address", meu.Addresses)
+	e.Add("notes", meu.FRNotice)
+	return setFtMCaption(e)
+}
+
+func cslToFtM(dataset, id, entityType, name string, altNames, addresses, programs, remarks, ids []string, sourceURL string) *ftm.Entity {
+	e := newFtMEntity(dataset, id, ftmSchema(entityType))
+	e.Add("name", name)
+	e.Add("alias", altNames...)
+	e.Add("address", addresses...)
+	e.Add("program", programs...)
+	e.Add("notes", remarks...)
+	e.Add("idNumber", ids...)
+	e.Add("sourceUrl", sourceURL)
+	return setFtMCaption(e)
+}
+
+func euCSLToFtM(record *csl.EUCSLRecord) *ftm.Entity {
+	e := newFtMEntity("eu_fsf", strconv.Itoa(record.EntityLogicalID), ftmSchema(record.EntitySubjectType))
+	if len(record.NameAliasWholeNames) > 0 {
+		e.Add("name", record.NameAliasWholeNames[0])
+		e.Add("alias", record.NameAliasWholeNames[1:]...)
+	}
+	e.Add("address", record.AddressStreets...)
+	e.Add("country", record.AddressCountryDescriptions...)
+	if e.IsA(ftm.SchemaPerson) {
+		e.Add("title", record.NameAliasTitles...)
+		e.Add("birthDate", record.BirthDates...)
+		e.Add("birthPlace", record.BirthCities...)
+		for _, cit := range record.Citizenships {
+			e.Add("citizenship", cit.CountryDescription)
+		}
+	}
+	for _, id := range record.Identifications {
+		e.Add("idNumber", id.Number)
+	}
+	if record.EntityRegulation != nil {
+		e.Add("program", record.EntityRegulation.Programme)
+	}
+	e.Add("notes", record.EntityRemark)
+	e.Add("sourceUrl", record.EntityPublicationURL)
+	return setFtMCaption(e)
+}
+
+func ukCSLToFtM(record *csl.UKCSLRecord) *ftm.Entity {
+	e := newFtMEntity("gb_hmt_sanctions", strconv.Itoa(record.GroupID), ftmSchema(record.GroupType))
+	if len(record.Names) > 0 {
+		e.Add("name", record.Names[0])
+		e.Add("alias", record.Names[1:]...)
+	}
+	e.Add("address", record.Addresses...)
+	e.Add("country", record.Countries...)
+	if e.IsA(ftm.SchemaPerson) {
+		e.Add("title", record.Titles...)
+		e.Add("birthDate", record.DatesOfBirth...)
+		e.Add("birthPlace", record.TownsOfBirth...)
+		e.Add("nationality", record.Nationalities...)
+	}
+	e.Add("notes", record.OtherInfos...)
+	return setFtMCaption(e)
+}
+
+func ukSanctionsListToFtM(record *csl.UKSanctionsListRecord) *ftm.Entity {
+	var entityType string
+	if record.EntityType != nil {
+		entityType = string(*record.EntityType)
+	}
+	e := newFtMEntity("gb_fcdo_sanctions", record.UniqueID, ftmSchema(entityType))
+	if len(record.Names) > 0 {
+		e.Add("name", record.Names[0])
+		e.Add("alias", record.Names[1:]...)
+	}
+	e.Add("alias", record.NonLatinScriptNames...)
+	e.Add("address", record.Addresses...)
+	e.Add("country", record.AddressCountries...)
+	if e.IsA(ftm.SchemaPerson) {
+		e.Add("title", record.Titles...)
+		e.Add("birthDate", record.DatesOfBirth...)
+		e.Add("birthPlace", record.TownsOfBirth...)
+		e.Add("nationality", record.Nationalities...)
+		e.Add("position", record.Positions...)
+		for _, passport := range record.Passports {
+			e.Add("passportNumber", passport.Number)
+		}
+	}
+	for _, id := range record.NationalIdentifiers {
+		e.Add("idNumber", id.Number)
+	}
+	e.Add("program", record.RegimeName)
+	e.Add("notes", record.OtherInformation)
+	return setFtMCaption(e)
+}
+
+func pepToFtM(p *pep.PEP) *ftm.Entity {
+	e := &ftm.Entity{
+		ID:       p.EntityID,
+		Schema:   ftm.SchemaPerson,
+		Caption:  p.Name,
+		Datasets: p.Datasets,
+	}
+	e.Add("name", p.Name)
+	e.Add("alias", p.AlternateNames...)
+	e.Add("birthDate", p.BirthDates...)
+	e.Add("country", p.Countries...)
+	e.Add("nationality", p.Nationalities...)
+	for i := range p.Positions {
+		e.Add("position", p.Positions[i].Name)
+	}
+	e.Add("sourceUrl", p.SourceURL)
+	e.Add("topics", ftm.TopicPEP)
+	return e
+}
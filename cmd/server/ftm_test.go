@@ -0,0 +1,143 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/ftm"
+	"github.com/moov-io/watchman/pkg/ofac"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFtM__Records(t *testing.T) {
+	dir := t.TempDir()
+
+	// nothing to import
+	entities, err := ftmRecords(log.NewNopLogger(), dir, nil)
+	require.NoError(t, err)
+	require.Empty(t, entities)
+
+	input, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "pep.json"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ftm.DefaultFilename), input, 0600))
+
+	// only Person entities are searchable, Positions and Occupancies are skipped
+	entities, err = ftmRecords(log.NewNopLogger(), dir, nil)
+	require.NoError(t, err)
+	require.Len(t, entities, 4)
+	for i := range entities {
+		require.Equal(t, ftm.SchemaPerson, entities[i].Schema)
+	}
+}
+
+func createFtMSearcher(t *testing.T) *searcher {
+	t.Helper()
+
+	acme := &ftm.Entity{ID: "acme", Schema: ftm.SchemaCompany, Caption: "Acme Shipping Holdings"}
+	acme.Add("name", "Acme Shipping Holdings")
+	acme.Add("alias", "Acme Maritime")
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.FtMEntities = precomputeCSLEntities[ftm.Entity]([]*ftm.Entity{acme}, noLogPipeliner)
+	return s
+}
+
+func TestSearch__FtM(t *testing.T) {
+	s := createFtMSearcher(t)
+
+	results := s.TopFtMEntities(1, 0.00, "acme maritime")
+	require.Len(t, results, 1)
+	require.Equal(t, "acme", results[0].Data.ID)
+	require.InDelta(t, 1.0, results[0].match, 0.001)
+}
+
+func TestExportFtM(t *testing.T) {
+	s := createFtMSearcher(t)
+	s.SDNs = precomputeSDNs([]*ofac.SDN{{
+		EntityID: "2676",
+		SDNName:  "HABBASH, George",
+		SDNType:  "individual",
+		Programs: []string{"SDT"},
+	}}, nil, noLogPipeliner)
+	s.Alts = precomputeAlts([]*ofac.AlternateIdentity{{
+		EntityID:      "2676",
+		AlternateName: "AL-HAKIM",
+	}}, noLogPipeliner)
+	s.PEPs = createPEPSearcher(t).PEPs
+
+	router := mux.NewRouter()
+	addFtMRoutes(log.NewNopLogger(), router, s)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/export/ftm", nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Type"), "application/x-ndjson")
+
+	entities, err := ftm.Read(w.Body)
+	require.NoError(t, err)
+	require.Len(t, entities, 1+3+1) // SDN, PEPs, imported entity
+
+	sdn := entities[0]
+	require.Equal(t, "us_ofac_sdn-2676", sdn.ID)
+	require.Equal(t, ftm.SchemaPerson, sdn.Schema)
+	require.Equal(t, []string{"HABBASH, George", "AL-HAKIM"}, sdn.Names())
+	require.Equal(t, []string{"SDT"}, sdn.Get("program"))
+	require.True(t, sdn.Target)
+
+	require.True(t, entities[1].HasTopic(ftm.TopicPEP))
+	require.Equal(t, "acme", entities[4].ID)
+
+	// every line is a standalone JSON object
+	bs, err := json.Marshal(entities[4])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"schema":"Company"`)
+}
+
+func TestExportFtM__doesNotHoldLock(t *testing.T) {
+	s := createFtMSearcher(t)
+	s.PEPs = createPEPSearcher(t).PEPs
+
+	// a client which doesn't read the export
+	r, w := io.Pipe()
+	defer r.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.exportFtM(ftm.NewWriter(w))
+		w.Close()
+		done <- err
+	}()
+
+	locked := make(chan struct{})
+	go func() {
+		s.Lock()
+		defer s.Unlock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("refresh blocked by FtM export")
+	}
+
+	entities, err := ftm.Read(r)
+	require.NoError(t, err)
+	require.Len(t, entities, 3+1) // PEPs, imported entity
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+)
+
+var (
+	// watchLeaseDuration is how long a replica holds the watch leader lease, or a claimed webhook
+	// delivery, without renewing it. Work held by a replica which dies is taken over after this long.
+	watchLeaseDuration = time.Minute
+
+	// instanceID identifies this replica as the owner of leases
+	instanceID = ""
+)
+
+// watchLeaderLease is held by the replica which re-screens watches after each refresh
+const watchLeaderLease = "watch-rescreen"
+
+func init() {
+	watchLeaseDuration = readLeaseDuration(os.Getenv("WATCH_LEASE_DURATION"))
+	instanceID = readInstanceID(os.Getenv("WATCH_INSTANCE_ID"))
+}
+
+func readLeaseDuration(str string) time.Duration {
+	if str == "" {
+		return watchLeaseDuration
+	}
+	dur, _ := time.ParseDuration(str)
+	if dur > 0 {
+		return dur
+	}
+	return watchLeaseDuration
+}
+
+// readInstanceID defaults to the hostname (the pod name on Kubernetes) with a random suffix,
+// so a restarted replica never renews the lease of the process it replaced.
+func readInstanceID(str string) string {
+	if str = strings.TrimSpace(str); str != "" {
+		return str
+	}
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "watchman"
+	}
+	return fmt.Sprintf("%s-%s", host, base.ID()[:8])
+}
+
+// leaseRepository stores named leases shared by every replica connected to the database
+type leaseRepository interface {
+	// acquireLease takes (or renews) name for owner until now+ttl and returns false
+	// when another owner holds an unexpired lease.
+	acquireLease(name, owner string, now time.Time, ttl time.Duration) (bool, error)
+
+	// releaseLease expires name if it's held by owner so another replica can take it over
+	releaseLease(name, owner string, now time.Time) error
+}
+
+type sqliteLeaseRepository struct {
+	db *sql.DB
+}
+
+func (r *sqliteLeaseRepository) close() error {
+	return r.db.Close()
+}
+
+func (r *sqliteLeaseRepository) acquireLease(name, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	query := `update leases set owner = ?, expires_at = ? where name = ? and (owner = ? or expires_at < ?);`
+	res, err := r.db.Exec(query, owner, now.Add(ttl), name, owner, now)
+	if err != nil {
+		return false, err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return true, nil
+	}
+
+	// Nobody has held the lease before. The insert fails if another replica beat us to it.
+	query = `insert into leases (name, owner, expires_at) values (?, ?, ?);`
+	if _, err := r.db.Exec(query, name, owner, now.Add(ttl)); err == nil {
+		return true, nil
+	}
+
+	// MySQL doesn't count rows an update left unchanged, so read who holds the lease
+	var holder string
+	var expiresAt time.Time
+	query = `select owner, expires_at from leases where name = ? limit 1;`
+	if err := r.db.QueryRow(query, name).Scan(&holder, &expiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("lease %s wasn't created", name)
+		}
+		return false, err
+	}
+	return holder == owner && expiresAt.After(now), nil
+}
+
+func (r *sqliteLeaseRepository) releaseLease(name, owner string, now time.Time) error {
+	query := `update leases set expires_at = ? where name = ? and owner = ?;`
+	_, err := r.db.Exec(query, now, name, owner)
+	return err
+}
+
+// leaderElection campaigns for a lease so only one replica does some work at a time.
+// The lease is renewed three times per ttl and replicas take it over once it expires.
+type leaderElection struct {
+	repo   leaseRepository
+	name   string
+	owner  string
+	ttl    time.Duration
+	logger log.Logger
+
+	mu        sync.RWMutex
+	expiresAt time.Time // zero when another replica leads
+}
+
+func newLeaderElection(logger log.Logger, repo leaseRepository, name string) *leaderElection {
+	return &leaderElection{
+		repo:   repo,
+		name:   name,
+		owner:  instanceID,
+		ttl:    watchLeaseDuration,
+		logger: logger,
+	}
+}
+
+// isLeader reports if this replica holds the lease. A nil election always leads.
+//
+// Leadership ends when the lease expires locally, even if it couldn't be renewed,
+// since another replica is free to take it over then.
+func (e *leaderElection) isLeader() bool {
+	if e == nil {
+		return true
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return time.Now().Before(e.expiresAt)
+}
+
+// campaign acquires or renews the lease
+func (e *leaderElection) campaign(now time.Time) error {
+	acquired, err := e.repo.acquireLease(e.name, e.owner, now, e.ttl)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	leading := now.Before(e.expiresAt)
+	switch {
+	case err != nil:
+		return err
+
+	case acquired:
+		if !leading {
+			e.logger.Logf("leases: %s acquired %s lease", e.owner, e.name)
+		}
+		e.expiresAt = now.Add(e.ttl)
+
+	default:
+		if leading {
+			e.logger.Warn().Logf("leases: %s lost %s lease", e.owner, e.name)
+		}
+		e.expiresAt = time.Time{}
+	}
+	return nil
+}
+
+// run campaigns for the lease until stop is closed
+func (e *leaderElection) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		if err := e.campaign(time.Now()); err != nil {
+			e.logger.Error().LogErrorf("leases: problem campaigning for %s lease: %v", e.name, err)
+		}
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// resign releases the lease when this replica holds it
+func (e *leaderElection) resign() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.expiresAt.IsZero() {
+		return nil
+	}
+	e.expiresAt = time.Time{}
+	return e.repo.releaseLease(e.name, e.owner, time.Now())
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeases__repository(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, repo *sqliteLeaseRepository) {
+		name, ttl := base.ID(), time.Minute
+		now := time.Now().Truncate(time.Millisecond)
+
+		acquired, err := repo.acquireLease(name, "first", now, ttl)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		// the lease is held until it expires
+		acquired, err = repo.acquireLease(name, "second", now.Add(time.Second), ttl)
+		require.NoError(t, err)
+		require.False(t, acquired)
+
+		// renewing within the same millisecond changes nothing
+		acquired, err = repo.acquireLease(name, "first", now, ttl)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		// expired leases are taken over
+		later := now.Add(2 * ttl)
+		acquired, err = repo.acquireLease(name, "second", later, ttl)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		acquired, err = repo.acquireLease(name, "first", later, ttl)
+		require.NoError(t, err)
+		require.False(t, acquired)
+
+		// only the holder releases a lease
+		require.NoError(t, repo.releaseLease(name, "first", later))
+		acquired, err = repo.acquireLease(name, "first", later.Add(time.Second), ttl)
+		require.NoError(t, err)
+		require.False(t, acquired)
+
+		require.NoError(t, repo.releaseLease(name, "second", later.Add(time.Second)))
+		acquired, err = repo.acquireLease(name, "first", later.Add(2*time.Second), ttl)
+		require.NoError(t, err)
+		require.True(t, acquired)
+	}
+
+	// SQLite tests
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+	check(t, &sqliteLeaseRepository{sqliteDB.DB})
+
+	// MySQL tests
+	mysqlDB := database.TestMySQLConnection(t)
+	check(t, &sqliteLeaseRepository{mysqlDB})
+}
+
+func TestLeaderElection(t *testing.T) {
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteLeaseRepository{db.DB}
+
+	first := newLeaderElection(log.NewNopLogger(), repo, watchLeaderLease)
+	first.owner = "first"
+	second := newLeaderElection(log.NewNopLogger(), repo, watchLeaderLease)
+	second.owner = "second"
+
+	now := time.Now()
+	require.NoError(t, first.campaign(now))
+	require.NoError(t, second.campaign(now))
+	require.True(t, first.isLeader())
+	require.False(t, second.isLeader())
+
+	// the lease is taken over once the leader stops renewing it
+	require.NoError(t, second.campaign(now.Add(2*watchLeaseDuration)))
+	require.True(t, second.isLeader())
+	require.NoError(t, first.campaign(now.Add(2*watchLeaseDuration)))
+	require.False(t, first.isLeader())
+
+	// and right away when the leader resigns
+	require.NoError(t, second.resign())
+	require.False(t, second.isLeader())
+	require.NoError(t, first.campaign(time.Now()))
+	require.True(t, first.isLeader())
+
+	var nobody *leaderElection
+	require.True(t, nobody.isLeader())
+}
+
+func TestLeases__spawnResearching(t *testing.T) {
+	s := createTestWatchListsSearcher(t)
+	companyRepo := createTestCompanyRepository(t)
+	defer companyRepo.close()
+	customerRepo := createTestCustomerRepository(t)
+	defer customerRepo.close()
+	watchRepo := createTestWatchRepository(t)
+	defer watchRepo.close()
+	queue := createTestWebhookQueue(t)
+
+	watchID, err := watchRepo.addCustomerWatch("306", watchRequest{AuthToken: "authToken", Webhook: "https://example.com"})
+	require.NoError(t, err)
+
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteLeaseRepository{db.DB}
+	acquired, err := repo.acquireLease(watchLeaderLease, "leader", time.Now(), time.Hour)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// followers don't re-screen watches
+	follower := newLeaderElection(log.NewNopLogger(), repo, watchLeaderLease)
+	require.NoError(t, follower.campaign(time.Now()))
+	s.spawnResearching(log.NewNopLogger(), nil, companyRepo, customerRepo, watchRepo, queue, follower)
+
+	deliveries, err := queue.repo.listDeliveries(deliveryFilter{watchID: watchID, limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	// the leader does
+	s.spawnResearching(log.NewNopLogger(), nil, companyRepo, customerRepo, watchRepo, queue, nil)
+
+	deliveries, err = queue.repo.listDeliveries(deliveryFilter{watchID: watchID, limit: 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+}
+
+func TestLeases__deliveries(t *testing.T) {
+	queue := createTestWebhookQueue(t)
+
+	var buf bytes.Buffer
+	queue.notifiers[fileNotifierType] = &jsonLinesNotifier{w: &buf}
+
+	d, err := queue.enqueue(watch{id: "watchID", notifier: fileNotifierType}, bytes.NewBufferString(`{"id":"306"}`))
+	require.NoError(t, err)
+
+	// another replica claims the delivery
+	now := time.Now()
+	claimed, err := queue.repo.claimDelivery(d.ID, now, now.Add(queue.lease))
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = queue.repo.claimDelivery(d.ID, now, now.Add(queue.lease))
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	require.NoError(t, queue.deliverDue(now))
+	require.Empty(t, buf.String())
+
+	// and dies, so the delivery is attempted once its claim expires
+	require.NoError(t, queue.deliverDue(now.Add(queue.lease+time.Second)))
+	require.Contains(t, buf.String(), d.ID)
+
+	found, err := queue.repo.getDelivery(d.ID)
+	require.NoError(t, err)
+	require.Equal(t, deliveryDelivered, found.Status)
+}
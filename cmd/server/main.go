@@ -56,6 +56,10 @@ func main() {
 		logger = log.NewDefaultLogger()
 	}
 
+	if flag.Arg(0) == "bundle" {
+		os.Exit(bundleCommand(logger, flag.Args()[1:]))
+	}
+
 	logger.Logf("Starting watchman server version %s", watchman.Version)
 
 	// Channel for errors
@@ -151,44 +155,122 @@ func main() {
 	}
 	searcher := newSearcher(logger, pipeline, *flagWorkers)
 
-	// Add debug routes
-	adminServer.AddHandler(debugSDNPath, debugSDNHandler(logger, searcher))
+	// Setup snapshots of each refresh
+	snapshots, err := newSnapshotRepository(logger)
+	if err != nil {
+		logger.LogErrorf("ERROR: snapshots: %v", err)
+		os.Exit(1)
+	}
+	searcher.snapshots = snapshots
 
-	// Initial download of data
-	if stats, err := searcher.refreshData(os.Getenv("INITIAL_DATA_DIRECTORY")); err != nil {
-		logger.LogErrorf("ERROR: failed to download/parse initial data: %v", err)
+	// Setup loading of offline data bundles
+	bundles, err := newBundleLoader(logger, searcher, downloadRepo)
+	if err != nil {
+		logger.LogErrorf("ERROR: bundles: %v", err)
 		os.Exit(1)
-	} else {
-		if err := downloadRepo.recordStats(stats); err != nil {
-			logger.LogErrorf("ERROR: failed to record download stats: %v", err)
+	}
+
+	// Setup watching a directory of data files
+	dataWatcher, err := newDataWatcher(logger, searcher, downloadRepo)
+	if err != nil {
+		logger.LogErrorf("ERROR: data watch: %v", err)
+		os.Exit(1)
+	}
+	initialDir := os.Getenv("INITIAL_DATA_DIRECTORY")
+	if initialDir == "" && dataWatcher != nil {
+		initialDir = dataWatcher.dir
+	}
+
+	// Add debug routes
+	adminServer.AddHandler(debugSDNPath, debugSDNHandler(logger, searcher))
+	adminServer.AddHandler(dataQualityPath, dataQualityHandler(logger, searcher))
+	adminServer.AddHandler(snapshotsPath, listSnapshotsHandler(logger, snapshots))
+	adminServer.AddHandler(snapshotPath, getSnapshotHandler(logger, snapshots))
+
+	// Initial download of data, or the data of a snapshot
+	var stats *DownloadStats
+	if id := os.Getenv("SNAPSHOT_ID"); id != "" {
+		if snapshots == nil {
+			logger.LogErrorf("ERROR: SNAPSHOT_ID=%s requires SNAPSHOT_DIRECTORY", id)
+			os.Exit(1)
+		}
+		stats, err = snapshots.load(searcher, id)
+		if err != nil {
+			logger.LogErrorf("ERROR: failed to load snapshot %s: %v", id, err)
 			os.Exit(1)
 		}
-		logger.Info().With(log.Fields{
-			"SDNs":             log.Int(stats.SDNs),
-			"AltNames":         log.Int(stats.Alts),
-			"Addresses":        log.Int(stats.Addresses),
-			"SSI":              log.Int(stats.SectoralSanctions),
-			"DPL":              log.Int(stats.DeniedPersons),
-			"BISEntities":      log.Int(stats.BISEntities),
-			"UVL":              log.Int(stats.Unverified),
-			"ISN":              log.Int(stats.NonProliferationSanctions),
-			"FSE":              log.Int(stats.ForeignSanctionsEvaders),
-			"PLC":              log.Int(stats.PalestinianLegislativeCouncil),
-			"CAP":              log.Int(stats.CAPTA),
-			"DTC":              log.Int(stats.ITARDebarred),
-			"CMIC":             log.Int(stats.ChineseMilitaryIndustrialComplex),
-			"NS_MBS":           log.Int(stats.NonSDNMenuBasedSanctions),
-			"EU_CSL":           log.Int(stats.EUCSL),
-			"UK_CSL":           log.Int(stats.UKCSL),
-			"UK_SanctionsList": log.Int(stats.UKSanctionsList),
-		}).Logf("data refreshed %v ago", time.Since(stats.RefreshedAt))
+		recordRefreshMetrics(stats)
+		logger.Logf("serving snapshot %s, data will not be refreshed", id)
+	} else {
+		if bundles != nil && bundles.dir != "" {
+			_, stats, err = bundles.loadNewest()
+			if err != nil {
+				logger.LogErrorf("ERROR: failed to load bundle: %v", err)
+			}
+		}
+		if stats == nil {
+			stats, err = searcher.refreshData(initialDir)
+			if err != nil {
+				logger.LogErrorf("ERROR: failed to download/parse initial data: %v", err)
+				os.Exit(1)
+			}
+			if err := downloadRepo.recordStats(stats); err != nil {
+				logger.LogErrorf("ERROR: failed to record download stats: %v", err)
+				os.Exit(1)
+			}
+		}
 	}
+	logger.Info().With(log.Fields{
+		"SDNs":             log.Int(stats.SDNs),
+		"AltNames":         log.Int(stats.Alts),
+		"Addresses":        log.Int(stats.Addresses),
+		"SSI":              log.Int(stats.SectoralSanctions),
+		"DPL":              log.Int(stats.DeniedPersons),
+		"BISEntities":      log.Int(stats.BISEntities),
+		"UVL":              log.Int(stats.Unverified),
+		"ISN":              log.Int(stats.NonProliferationSanctions),
+		"FSE":              log.Int(stats.ForeignSanctionsEvaders),
+		"PLC":              log.Int(stats.PalestinianLegislativeCouncil),
+		"CAP":              log.Int(stats.CAPTA),
+		"DTC":              log.Int(stats.ITARDebarred),
+		"CMIC":             log.Int(stats.ChineseMilitaryIndustrialComplex),
+		"NS_MBS":           log.Int(stats.NonSDNMenuBasedSanctions),
+		"EU_CSL":           log.Int(stats.EUCSL),
+		"UK_CSL":           log.Int(stats.UKCSL),
+		"UK_SanctionsList": log.Int(stats.UKSanctionsList),
+		"PEP":              log.Int(stats.PoliticallyExposedPersons),
+		"FtM":              log.Int(stats.FtMEntities),
+	}).Logf("data refreshed %v ago", time.Since(stats.RefreshedAt))
 
 	// Setup Watch and Webhook database wrapper
 	watchRepo := &sqliteWatchRepository{db, logger}
 	defer watchRepo.close()
 	webhookRepo := &sqliteWebhookRepository{db}
 	defer webhookRepo.close()
+	subscriberRepo := &sqliteDownloadSubscriberRepository{db}
+	defer subscriberRepo.close()
+	webhookQueue, err := newWebhookQueue(logger, webhookRepo, watchRepo, subscriberRepo)
+	if err != nil {
+		logger.LogErrorf("ERROR: webhook queue: %v", err)
+		os.Exit(1)
+	}
+	go webhookQueue.run()
+
+	// Only one replica sharing the database re-screens watches
+	leaseRepo := &sqliteLeaseRepository{db}
+	defer leaseRepo.close()
+	watchLeader := newLeaderElection(logger, leaseRepo, watchLeaderLease)
+	stopCampaign := make(chan struct{})
+	go watchLeader.run(stopCampaign)
+	defer func() {
+		close(stopCampaign)
+		if err := watchLeader.resign(); err != nil {
+			logger.LogErrorf("problem releasing watch leader lease: %v", err)
+		}
+	}()
+	adminServer.AddHandler(webhookDeliveriesPath, listWebhookDeliveriesHandler(logger, webhookRepo))
+	adminServer.AddHandler(webhookDeliveryPath, getWebhookDeliveryHandler(logger, webhookRepo))
+	adminServer.AddHandler(webhookDeliveryReplayPath, replayWebhookDeliveryHandler(logger, webhookQueue))
 
 	// Setup company / customer repositories
 	companyRepo := &sqliteCompanyRepository{db, logger}
@@ -196,25 +278,57 @@ func main() {
 	custRepo := &sqliteCustomerRepository{db, logger}
 	defer custRepo.close()
 
+	// Setup the monitored customers / companies and their alerts
+	monitoredRepo := &sqliteMonitoredRepository{db}
+	defer monitoredRepo.close()
+	alertRepo := &sqliteAlertRepository{db}
+	defer alertRepo.close()
+
 	// Setup periodic download and re-search
 	updates := make(chan *DownloadStats)
 	dataRefreshInterval = getDataRefreshInterval(logger, os.Getenv("DATA_REFRESH_INTERVAL"))
+	if searcher.snapshotID != "" {
+		dataRefreshInterval = 0 // keep serving the snapshot
+	}
 	go searcher.periodicDataRefresh(dataRefreshInterval, downloadRepo, updates)
 	go handleDownloadStats(updates, func(stats *DownloadStats) {
 		callDownloadWebook(logger, stats)
-		searcher.spawnResearching(logger, companyRepo, custRepo, watchRepo, webhookRepo)
+		notifyDownloadSubscribers(logger, stats, subscriberRepo, webhookQueue, watchLeader)
+		searcher.spawnResearching(logger, stats, companyRepo, custRepo, watchRepo, webhookQueue, watchLeader)
+		searcher.screenMonitored(logger, stats, monitoredRepo, alertRepo, watchLeader)
 	})
 
 	// Add manual data refresh endpoint
 	adminServer.AddHandler(manualRefreshPath, manualRefreshHandler(logger, searcher, updates, downloadRepo))
 
+	// Load bundles as they're uploaded or copied into BUNDLE_DIRECTORY
+	adminServer.AddHandler(bundlesPath, loadBundleHandler(logger, bundles, updates))
+	if bundles != nil && bundles.dir != "" {
+		go bundles.watch(updates)
+	}
+
+	// Refresh lists as their files change in DATA_WATCH_DIRECTORY
+	if dataWatcher != nil {
+		if err := dataWatcher.markRead(); err != nil {
+			logger.LogErrorf("ERROR: data watch: %v", err)
+			os.Exit(1)
+		}
+		go dataWatcher.watch(updates)
+	}
+
 	// Add searcher for HTTP routes
 	addCompanyRoutes(logger, router, searcher, companyRepo, watchRepo)
 	addCustomerRoutes(logger, router, searcher, custRepo, watchRepo)
+	addWatchRoutes(logger, router, watchRepo, webhookRepo)
+	addWatchSecretRoutes(logger, router, watchRepo)
+	addDownloadSubscriberRoutes(logger, router, subscriberRepo)
+	addMonitoringRoutes(logger, router, searcher, monitoredRepo, alertRepo)
+	addAlertRoutes(logger, router, alertRepo)
 	addSDNRoutes(logger, router, searcher)
-	addSearchRoutes(logger, router, searcher)
+	addSearchRoutes(logger, router, searcher, alertRepo)
 	addDownloadRoutes(logger, router, downloadRepo)
 	addValuesRoutes(logger, router, searcher)
+	addFtMRoutes(logger, router, searcher)
 
 	// Setup our web UI to be served as well
 	setupWebui(logger, router, *flagBasePath)
@@ -0,0 +1,446 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+
+	"github.com/gorilla/mux"
+)
+
+var (
+	// monitorLists are the lists monitored customers and companies are screened against
+	monitorLists = append([]string{}, allLists...)
+
+	// monitorMinMatch is the lowest match which raises an alert for a monitored customer or company
+	monitorMinMatch = 0.90
+)
+
+var (
+	errNoMonitoredID      = errors.New("no monitoredID found")
+	errMonitoredNotFound  = errors.New("monitored entity not found")
+	errNoMonitoredName    = errors.New("no name provided for monitored entity")
+	errCompanyDateOfBirth = errors.New("dateOfBirth is only supported on monitored customers")
+	errInvalidDateOfBirth = errors.New("dateOfBirth must be formatted as YYYY-MM-DD, YYYY-MM or YYYY")
+	errNoIdentifierNumber = errors.New("identifiers need a number")
+)
+
+// maxMonitoredIdentifiers is the most identifiers a monitored entity can have
+const maxMonitoredIdentifiers = 20
+
+func init() {
+	if lists, err := readWatchLists(os.Getenv("MONITOR_LISTS")); err == nil && len(lists) > 0 {
+		monitorLists = lists
+	}
+	monitorMinMatch = readMonitorMinMatch(os.Getenv("MONITOR_MIN_MATCH"))
+}
+
+func readMonitorMinMatch(str string) float64 {
+	if str == "" {
+		return monitorMinMatch
+	}
+	f, _ := strconv.ParseFloat(str, 64)
+	if f > 0 && f <= 1 {
+		return f
+	}
+	return monitorMinMatch
+}
+
+// monitoredType is the kind of entity registered for continuous monitoring
+type monitoredType string
+
+const (
+	monitoredCustomer monitoredType = "customer"
+	monitoredCompany  monitoredType = "company"
+)
+
+func (t monitoredType) valid() bool {
+	return t == monitoredCustomer || t == monitoredCompany
+}
+
+// sdnType is the OFAC SDN type monitored entities are searched for
+func (t monitoredType) sdnType() string {
+	if t == monitoredCustomer {
+		return "individual"
+	}
+	return "entity"
+}
+
+// monitoredEntity is one of our own customers or companies, identified by our ID, which is
+// screened against every list after each refresh.
+type monitoredEntity struct {
+	ID          string                `json:"monitoredID"`
+	Type        monitoredType         `json:"type"`
+	Name        string                `json:"name"`
+	DateOfBirth string                `json:"dateOfBirth,omitempty"`
+	Country     string                `json:"country,omitempty"`
+	Identifiers []monitoredIdentifier `json:"identifiers,omitempty"`
+
+	// Matches are the hits found the last time the entity was screened
+	Matches    []watchResult `json:"matches"`
+	ScreenedAt *time.Time    `json:"screenedAt,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// monitoredIdentifier is a passport, national ID, tax ID, etc of a monitored entity
+type monitoredIdentifier struct {
+	Type    string `json:"type,omitempty"`
+	Number  string `json:"number"`
+	Country string `json:"country,omitempty"`
+}
+
+type monitoredRequest struct {
+	Name        string                `json:"name"`
+	DateOfBirth string                `json:"dateOfBirth"`
+	Country     string                `json:"country"`
+	Identifiers []monitoredIdentifier `json:"identifiers"`
+}
+
+func (req *monitoredRequest) validate(t monitoredType) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errNoMonitoredName
+	}
+	if req.DateOfBirth = strings.TrimSpace(req.DateOfBirth); req.DateOfBirth != "" {
+		if t != monitoredCustomer {
+			return errCompanyDateOfBirth
+		}
+		if !validDateOfBirth(req.DateOfBirth) {
+			return errInvalidDateOfBirth
+		}
+	}
+	if len(req.Identifiers) > maxMonitoredIdentifiers {
+		return fmt.Errorf("too many identifiers, at most %d are allowed", maxMonitoredIdentifiers)
+	}
+	for i := range req.Identifiers {
+		if strings.TrimSpace(req.Identifiers[i].Number) == "" {
+			return errNoIdentifierNumber
+		}
+	}
+	req.Country = strings.TrimSpace(req.Country)
+	return nil
+}
+
+func validDateOfBirth(str string) bool {
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if _, err := time.Parse(layout, str); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+type monitoredFilter struct {
+	monitoredType monitoredType
+	limit, offset int
+}
+
+// HTTP routes
+
+func getMonitoredID(w http.ResponseWriter, r *http.Request) string {
+	v, ok := mux.Vars(r)["monitoredID"]
+	if !ok || v == "" {
+		moovhttp.Problem(w, errNoMonitoredID)
+		return ""
+	}
+	return v
+}
+
+func addMonitoringRoutes(logger log.Logger, r *mux.Router, searcher *searcher, repo monitoredRepository, alertRepo alertRepository) {
+	for path, t := range map[string]monitoredType{
+		"/monitored/customers": monitoredCustomer,
+		"/monitored/companies": monitoredCompany,
+	} {
+		r.Methods("GET").Path(path).HandlerFunc(listMonitored(logger, t, repo))
+		r.Methods("GET").Path(path + "/{monitoredID}").HandlerFunc(getMonitored(logger, t, repo))
+		r.Methods("PUT").Path(path + "/{monitoredID}").HandlerFunc(putMonitored(logger, t, searcher, repo, alertRepo))
+		r.Methods("DELETE").Path(path + "/{monitoredID}").HandlerFunc(removeMonitored(logger, t, repo))
+	}
+}
+
+// putMonitored registers (or replaces) a customer or company under our ID and screens it right away,
+// so it doesn't wait for the next refresh to raise alerts.
+func putMonitored(logger log.Logger, t monitoredType, searcher *searcher, repo monitoredRepository, alertRepo alertRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		monitoredID := getMonitoredID(w, r)
+		if monitoredID == "" {
+			return
+		}
+		var req monitoredRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if err := req.validate(t); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		now := time.Now()
+		m := &monitoredEntity{
+			ID:          monitoredID,
+			Type:        t,
+			Name:        req.Name,
+			DateOfBirth: req.DateOfBirth,
+			Country:     req.Country,
+			Identifiers: req.Identifiers,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := repo.upsertMonitored(m); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		m, err := repo.getMonitored(t, monitoredID)
+		if err != nil || m == nil {
+			moovhttp.Problem(w, fmt.Errorf("reading monitored %s %s: %v", t, monitoredID, err))
+			return
+		}
+		if _, err := searcher.screenMonitoredEntity(m, "", repo, alertRepo); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+		}).Logf("registered monitored %s=%s", t, monitoredID)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(m)
+	}
+}
+
+func listMonitored(logger log.Logger, t monitoredType, repo monitoredRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		filter := monitoredFilter{
+			monitoredType: t,
+			limit:         extractSearchLimit(r),
+		}
+		if v := r.URL.Query().Get("offset"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				moovhttp.Problem(w, fmt.Errorf("invalid offset %q", v))
+				return
+			}
+			filter.offset = n
+		}
+
+		entities, err := repo.listMonitored(filter)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if entities == nil {
+			entities = []*monitoredEntity{}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(entities)
+	}
+}
+
+func getMonitored(logger log.Logger, t monitoredType, repo monitoredRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		monitoredID := getMonitoredID(w, r)
+		if monitoredID == "" {
+			return
+		}
+		m, err := repo.getMonitored(t, monitoredID)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if m == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(m)
+	}
+}
+
+// removeMonitored stops screening a customer or company, its alerts are kept
+func removeMonitored(logger log.Logger, t monitoredType, repo monitoredRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		monitoredID := getMonitoredID(w, r)
+		if monitoredID == "" {
+			return
+		}
+		if err := repo.removeMonitored(t, monitoredID); err != nil {
+			if errors.Is(err, errMonitoredNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+		}).Logf("removed monitored %s=%s", t, monitoredID)
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// Repository
+
+type monitoredRepository interface {
+	// upsertMonitored registers m or replaces the details of an entity registered with the same
+	// type and ID, keeping when it was created and what it last matched.
+	upsertMonitored(m *monitoredEntity) error
+	removeMonitored(t monitoredType, monitoredID string) error
+	getMonitored(t monitoredType, monitoredID string) (*monitoredEntity, error)
+	listMonitored(filter monitoredFilter) ([]*monitoredEntity, error)
+
+	// saveScreening replaces the matches of an entity
+	saveScreening(t monitoredType, monitoredID string, matches []watchResult, screenedAt time.Time) error
+}
+
+type sqliteMonitoredRepository struct {
+	db *sql.DB
+}
+
+func (r *sqliteMonitoredRepository) close() error {
+	return r.db.Close()
+}
+
+func (r *sqliteMonitoredRepository) upsertMonitored(m *monitoredEntity) error {
+	identifiers, err := json.Marshal(m.Identifiers)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRow(`select count(*) from monitored_entities where entity_type = ? and id = ?;`, m.Type, m.ID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists > 0 {
+		query := `update monitored_entities set name = ?, date_of_birth = ?, country = ?, identifiers = ?, updated_at = ? where entity_type = ? and id = ?;`
+		_, err = tx.Exec(query, m.Name, m.DateOfBirth, m.Country, string(identifiers), m.UpdatedAt, m.Type, m.ID)
+	} else {
+		query := `insert into monitored_entities (id, entity_type, name, date_of_birth, country, identifiers, created_at, updated_at) values (?, ?, ?, ?, ?, ?, ?, ?);`
+		_, err = tx.Exec(query, m.ID, m.Type, m.Name, m.DateOfBirth, m.Country, string(identifiers), m.CreatedAt, m.UpdatedAt)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqliteMonitoredRepository) removeMonitored(t monitoredType, monitoredID string) error {
+	res, err := r.db.Exec(`delete from monitored_entities where entity_type = ? and id = ?;`, t, monitoredID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errMonitoredNotFound
+	}
+	return nil
+}
+
+const monitoredColumns = `id, entity_type, name, date_of_birth, country, identifiers, results, screened_at, created_at, updated_at`
+
+// getMonitored returns nil if the entity isn't registered
+func (r *sqliteMonitoredRepository) getMonitored(t monitoredType, monitoredID string) (*monitoredEntity, error) {
+	query := `select ` + monitoredColumns + ` from monitored_entities where entity_type = ? and id = ? limit 1;`
+	entities, err := r.queryMonitored(query, t, monitoredID)
+	if err != nil || len(entities) == 0 {
+		return nil, err
+	}
+	return entities[0], nil
+}
+
+// listMonitored returns registered entities, oldest first
+func (r *sqliteMonitoredRepository) listMonitored(filter monitoredFilter) ([]*monitoredEntity, error) {
+	query := `select ` + monitoredColumns + ` from monitored_entities`
+	var args []interface{}
+	if filter.monitoredType != "" {
+		query += ` where entity_type = ?`
+		args = append(args, filter.monitoredType)
+	}
+	query += ` order by created_at asc, entity_type asc, id asc limit ? offset ?;`
+	args = append(args, filter.limit, filter.offset)
+
+	return r.queryMonitored(query, args...)
+}
+
+func (r *sqliteMonitoredRepository) queryMonitored(query string, args ...interface{}) ([]*monitoredEntity, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*monitoredEntity
+	for rows.Next() {
+		var m monitoredEntity
+		var dateOfBirth, country, identifiers, results sql.NullString
+		var screenedAt sql.NullTime
+		if err := rows.Scan(&m.ID, &m.Type, &m.Name, &dateOfBirth, &country, &identifiers, &results, &screenedAt, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+		m.DateOfBirth = dateOfBirth.String
+		m.Country = country.String
+		if identifiers.String != "" {
+			if err := json.Unmarshal([]byte(identifiers.String), &m.Identifiers); err != nil {
+				return nil, fmt.Errorf("reading identifiers of monitored %s %s: %v", m.Type, m.ID, err)
+			}
+		}
+		m.Matches = []watchResult{}
+		if results.String != "" {
+			if err := json.Unmarshal([]byte(results.String), &m.Matches); err != nil {
+				return nil, fmt.Errorf("reading matches of monitored %s %s: %v", m.Type, m.ID, err)
+			}
+		}
+		if screenedAt.Valid {
+			m.ScreenedAt = &screenedAt.Time
+		}
+		out = append(out, &m)
+	}
+	return out, rows.Err()
+}
+
+func (r *sqliteMonitoredRepository) saveScreening(t monitoredType, monitoredID string, matches []watchResult, screenedAt time.Time) error {
+	bs, err := json.Marshal(matches)
+	if err != nil {
+		return err
+	}
+	query := `update monitored_entities set results = ?, screened_at = ? where entity_type = ? and id = ?;`
+	_, err = r.db.Exec(query, string(bs), screenedAt, t, monitoredID)
+	return err
+}
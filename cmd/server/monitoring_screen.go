@@ -0,0 +1,322 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/csl"
+
+	"github.com/pariz/gountries"
+)
+
+// screenMonitored screens every monitored customer and company against monitorLists after a refresh
+// and raises an alert for each entity one of them newly matches.
+//
+// Like watches, only the replica holding the watch leader lease screens the monitored entities.
+func (s *searcher) screenMonitored(logger log.Logger, stats *DownloadStats, repo monitoredRepository, alertRepo alertRepository, leader *leaderElection) {
+	if !leader.isLeader() {
+		s.logger.Log("monitoring: another replica holds the watch leader lease, skipping screening of monitored entities")
+		return
+	}
+	downloadID := ""
+	if stats != nil {
+		downloadID = stats.ID
+	}
+
+	s.logger.Log("monitoring: starting screening of monitored entities")
+	screened, raised := 0, 0
+	for offset := 0; ; offset += watchResearchBatchSize {
+		entities, err := repo.listMonitored(monitoredFilter{limit: watchResearchBatchSize, offset: offset})
+		if err != nil {
+			logger.Error().LogErrorf("monitoring: problem reading monitored entities: %v", err)
+			return
+		}
+		if len(entities) == 0 {
+			break
+		}
+		if !leader.isLeader() {
+			s.logger.Log("monitoring: lost the watch leader lease, stopping screening of monitored entities")
+			return
+		}
+		for _, m := range entities {
+			alerts, err := s.screenMonitoredEntity(m, downloadID, repo, alertRepo)
+			if err != nil {
+				logger.Error().LogErrorf("monitoring: %s %s: %v", m.Type, m.ID, err)
+				continue
+			}
+			screened++
+			raised += len(alerts)
+		}
+	}
+	s.logger.Logf("monitoring: finished screening %d monitored entities, raised %d alerts", screened, raised)
+}
+
+// screenMonitoredEntity searches the lists for m and raises an alert for each entity it didn't match
+// the last time it was screened. m's matches are replaced once its alerts are stored.
+func (s *searcher) screenMonitoredEntity(m *monitoredEntity, downloadID string, repo monitoredRepository, alertRepo alertRepository) ([]*screeningAlert, error) {
+	hits := s.monitoredHits(m)
+	current := newWatchResults(hits)
+	diff, _ := diffWatchResults(m.Matches, current, false)
+
+	added := make(map[string]bool, len(diff.Added))
+	for _, r := range diff.Added {
+		added[r.key()] = true
+	}
+
+	now := time.Now()
+	var alerts []*screeningAlert
+	for i, hit := range hits {
+		if !added[current[i].key()] {
+			continue
+		}
+		alert, err := newScreeningAlert(m, hit, downloadID, now)
+		if err != nil {
+			return alerts, err
+		}
+		if err := alertRepo.addAlert(alert, ""); err != nil {
+			return alerts, fmt.Errorf("problem saving alert: %v", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := repo.saveScreening(m.Type, m.ID, current, now); err != nil {
+		return alerts, fmt.Errorf("problem saving matches: %v", err)
+	}
+	m.Matches = current
+	m.ScreenedAt = &now
+	return alerts, nil
+}
+
+// monitoredHits returns the entities matching m's name at or above monitorMinMatch. Entities listed
+// with dates of birth are dropped when none of them is in a monitored customer's birth year.
+func (s *searcher) monitoredHits(m *monitoredEntity) []watchHit {
+	hits := s.searchWatchHits(monitorLists, m.Name, m.Type.sdnType(), monitorMinMatch, nil)
+
+	out := make([]watchHit, 0, len(hits))
+	for _, hit := range hits {
+		if birthYearConflicts(m.DateOfBirth, hitBirthYears(hit)) {
+			continue
+		}
+		out = append(out, hit)
+	}
+	return out
+}
+
+// listedRecord is implemented by list records which list the dates of birth, IDs or countries of their entity
+type listedRecord interface {
+	ListedBirthDates() []string
+	ListedIDNumbers() []string
+	ListedCountries() []string
+}
+
+// hitListed returns what's listed for the entity behind hit, or nil if its list doesn't carry any details
+func hitListed(hit watchHit) listedRecord {
+	switch e := hit.Entity.(type) {
+	case *SDN:
+		if e.SDN != nil {
+			return sdnRemarks(e.Remarks)
+		}
+	case *DP:
+		if e.DeniedPerson != nil {
+			return e
+		}
+	case interface{ record() interface{} }:
+		switch r := e.record().(type) {
+		case csl.Detailed:
+			return r.CSLDetails()
+		case listedRecord:
+			return r
+		}
+	}
+	return nil
+}
+
+// sdnRemarks are the parts of an OFAC record's remarks (e.g. "DOB 10 Dec 1948; POB Egypt; Passport 1084010 (Egypt)")
+type sdnRemarks string
+
+func (r sdnRemarks) ListedBirthDates() []string {
+	var out []string
+	for _, remark := range r.split() {
+		if strings.HasPrefix(remark, "DOB ") {
+			out = append(out, remark)
+		}
+	}
+	return out
+}
+
+// ListedIDNumbers returns every word with a digit outside of the DOB and POB remarks, e.g. "Passport 1084010 (Egypt)"
+func (r sdnRemarks) ListedIDNumbers() []string {
+	var out []string
+	for _, remark := range r.split() {
+		if strings.HasPrefix(remark, "DOB ") || strings.HasPrefix(remark, "POB ") {
+			continue
+		}
+		for _, word := range strings.Fields(remark) {
+			if strings.ContainsAny(word, "0123456789") {
+				out = append(out, word)
+			}
+		}
+	}
+	return out
+}
+
+// ListedCountries returns the countries of the nationality and citizen remarks, e.g. "nationality Egypt"
+func (r sdnRemarks) ListedCountries() []string {
+	var out []string
+	for _, remark := range r.split() {
+		for _, prefix := range []string{"nationality ", "citizen "} {
+			if strings.HasPrefix(strings.ToLower(remark), prefix) {
+				out = append(out, strings.TrimSpace(remark[len(prefix):]))
+			}
+		}
+	}
+	return out
+}
+
+func (r sdnRemarks) split() []string {
+	var out []string
+	for _, part := range strings.Split(string(r), ";") {
+		if part = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(part), "alt.")); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+var birthYearRegex = regexp.MustCompile(`\b(1[89]|20)\d{2}\b`)
+
+// hitBirthYears returns the years the entity behind hit was born in, if the list has them. Ranges
+// ("DOB 1960 to 1962") include every year in between and approximate years ("circa 1955") the
+// two years either side.
+func hitBirthYears(hit watchHit) map[int]bool {
+	years := make(map[int]bool)
+	listed := hitListed(hit)
+	if listed == nil {
+		return years
+	}
+	for _, date := range listed.ListedBirthDates() {
+		var found []int
+		for _, y := range birthYearRegex.FindAllString(date, -1) {
+			n, _ := strconv.Atoi(y)
+			found = append(found, n)
+		}
+		if len(found) == 0 {
+			continue
+		}
+		first, last := found[0], found[len(found)-1]
+		lower := strings.ToLower(date)
+		switch {
+		case strings.Contains(lower, " to ") && first < last:
+		case strings.Contains(lower, "circa"):
+			first, last = first-2, first+2
+		default:
+			for _, y := range found {
+				years[y] = true
+			}
+			continue
+		}
+		for y := first; y <= last; y++ {
+			years[y] = true
+		}
+	}
+	return years
+}
+
+// birthYearConflicts reports if a listed entity's birth years rule out dateOfBirth
+func birthYearConflicts(dateOfBirth string, years map[int]bool) bool {
+	if len(dateOfBirth) < 4 || len(years) == 0 {
+		return false
+	}
+	year, err := strconv.Atoi(dateOfBirth[:4])
+	if err != nil {
+		return false
+	}
+	return !years[year]
+}
+
+// hitIdentifierMatch reports if one of identifiers is listed for the entity behind hit
+func hitIdentifierMatch(hit watchHit, identifiers []monitoredIdentifier) bool {
+	if len(identifiers) == 0 {
+		return false
+	}
+	listed := hitIdentifierNumbers(hit)
+	for i := range identifiers {
+		if listed[normalizeIDNumber(identifiers[i].Number)] {
+			return true
+		}
+	}
+	return false
+}
+
+// hitIdentifierNumbers returns the normalized numbers of the IDs, passports, etc listed for the entity behind hit
+func hitIdentifierNumbers(hit watchHit) map[string]bool {
+	out := make(map[string]bool)
+	if listed := hitListed(hit); listed != nil {
+		for _, number := range listed.ListedIDNumbers() {
+			if n := normalizeIDNumber(number); n != "" {
+				out[n] = true
+			}
+		}
+	}
+	return out
+}
+
+// hitCountryMatch reports if country is listed as a nationality, citizenship, address, etc of the entity behind hit
+func hitCountryMatch(hit watchHit, country string) bool {
+	country = normalizeCountry(country)
+	if country == "" {
+		return false
+	}
+	if listed := hitListed(hit); listed != nil {
+		for _, c := range listed.ListedCountries() {
+			if normalizeCountry(c) == country {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeCountry returns the ISO 3166-1 alpha-2 code of a country's name or code, or the lowercased value
+// when it's not a known country
+func normalizeCountry(country string) string {
+	country = strings.TrimSpace(country)
+	if country == "" {
+		return ""
+	}
+	query := gountries.New()
+	if len(country) <= 3 {
+		if c, err := query.FindCountryByAlpha(country); err == nil {
+			return c.Codes.Alpha2
+		}
+	}
+	if c, err := query.FindCountryByName(country); err == nil {
+		return c.Codes.Alpha2
+	}
+	return strings.ToLower(country)
+}
+
+// hitName returns the primary name of the entity behind hit
+func hitName(hit watchHit) string {
+	switch e := hit.Entity.(type) {
+	case *SDN:
+		if e.SDN != nil {
+			return e.SDNName
+		}
+	case *DP:
+		if e.DeniedPerson != nil {
+			return e.DeniedPerson.Name
+		}
+	case interface{ record() interface{} }:
+		return cslName(e.record()).Original
+	}
+	return ""
+}
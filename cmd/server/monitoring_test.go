@@ -0,0 +1,335 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ftm"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/pep"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitoring__config(t *testing.T) {
+	require.Equal(t, 0.8, readMonitorMinMatch("0.8"))
+	require.Equal(t, monitorMinMatch, readMonitorMinMatch("0"))
+	require.Equal(t, monitorMinMatch, readMonitorMinMatch("1.5"))
+}
+
+func TestMonitoring__routes(t *testing.T) {
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteMonitoredRepository{db.DB}
+	alertRepo := &sqliteAlertRepository{db.DB}
+
+	router := mux.NewRouter()
+	addMonitoringRoutes(log.NewNopLogger(), router, createTestWatchListsSearcher(t), repo, alertRepo)
+
+	put := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("PUT", path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	for path, body := range map[string]string{
+		"/monitored/customers/c1": `{}`,
+		"/monitored/customers/c2": `{"name":"John Doe","dateOfBirth":"06/05/1961"}`,
+		"/monitored/customers/c3": `{"name":"John Doe","identifiers":[{"type":"passport"}]}`,
+		"/monitored/companies/c4": `{"name":"Acme Corp","dateOfBirth":"1961"}`,
+	} {
+		w := put(path, body)
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	// register a customer, which is screened right away
+	w := put("/monitored/customers/cust-1", `{"name":"Saddam Hussein Al-Tikriti","dateOfBirth":"1937-04-28","country":"IQ"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var m monitoredEntity
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&m))
+	require.Equal(t, "cust-1", m.ID)
+	require.Equal(t, monitoredCustomer, m.Type)
+	require.NotNil(t, m.ScreenedAt)
+	require.Len(t, m.Matches, 1)
+	require.Equal(t, "EUCSL", m.Matches[0].List)
+
+	alerts, err := alertRepo.listAlerts(alertFilter{monitoredID: "cust-1", limit: 10})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t, "13", alerts[0].EntityID)
+	require.Equal(t, "Saddam Hussein Al-Tikriti", alerts[0].Name)
+
+	// updating the customer keeps its matches, so nothing new is raised
+	w = put("/monitored/customers/cust-1", `{"name":"Saddam Hussein Al-Tikriti","dateOfBirth":"1937"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	alerts, err = alertRepo.listAlerts(alertFilter{monitoredID: "cust-1", limit: 10})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+
+	// companies are registered separately, the same ID doesn't collide
+	w = put("/monitored/companies/cust-1", `{"name":"Acme Corp"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/monitored/customers", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var customers []monitoredEntity
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&customers))
+	require.Len(t, customers, 1)
+	require.Equal(t, "1937", customers[0].DateOfBirth)
+	require.Equal(t, "", customers[0].Country)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/monitored/customers?offset=-1", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/monitored/companies/cust-1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "Acme Corp")
+
+	// removing the customer keeps its alerts
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/monitored/customers/cust-1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest("GET", "/monitored/customers/cust-1", nil),
+		httptest.NewRequest("DELETE", "/monitored/customers/cust-1", nil),
+	} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code, req.URL.Path)
+	}
+
+	alerts, err = alertRepo.listAlerts(alertFilter{monitoredID: "cust-1", limit: 10})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+}
+
+func TestMonitoring__repository(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, repo *sqliteMonitoredRepository) {
+		now := time.Now().Truncate(time.Second)
+		m := &monitoredEntity{
+			ID:          "cust-1",
+			Type:        monitoredCustomer,
+			Name:        "John Doe",
+			DateOfBirth: "1961-06-05",
+			Identifiers: []monitoredIdentifier{{Type: "passport", Number: "1084010", Country: "EG"}},
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		require.NoError(t, repo.upsertMonitored(m))
+
+		matches := []watchResult{{List: "SDNs", Source: "SDNs", EntityID: "2676", Match: 0.95}}
+		require.NoError(t, repo.saveScreening(m.Type, m.ID, matches, now))
+
+		// replacing the details keeps when it was created and what it matched
+		later := now.Add(time.Hour)
+		require.NoError(t, repo.upsertMonitored(&monitoredEntity{ID: "cust-1", Type: monitoredCustomer, Name: "Jane Doe", CreatedAt: later, UpdatedAt: later}))
+
+		found, err := repo.getMonitored(monitoredCustomer, "cust-1")
+		require.NoError(t, err)
+		require.Equal(t, "Jane Doe", found.Name)
+		require.Empty(t, found.DateOfBirth)
+		require.Empty(t, found.Identifiers)
+		require.Equal(t, matches, found.Matches)
+		require.True(t, now.Equal(found.CreatedAt))
+		require.True(t, later.Equal(found.UpdatedAt))
+		require.True(t, now.Equal(*found.ScreenedAt))
+
+		found, err = repo.getMonitored(monitoredCompany, "cust-1")
+		require.NoError(t, err)
+		require.Nil(t, found)
+
+		require.NoError(t, repo.upsertMonitored(&monitoredEntity{ID: "comp-1", Type: monitoredCompany, Name: "Acme Corp", CreatedAt: later, UpdatedAt: later}))
+
+		entities, err := repo.listMonitored(monitoredFilter{limit: 10})
+		require.NoError(t, err)
+		require.Len(t, entities, 2)
+		require.Equal(t, "cust-1", entities[0].ID)
+		require.Empty(t, entities[1].Matches)
+		require.Nil(t, entities[1].ScreenedAt)
+
+		entities, err = repo.listMonitored(monitoredFilter{monitoredType: monitoredCompany, limit: 10})
+		require.NoError(t, err)
+		require.Len(t, entities, 1)
+
+		entities, err = repo.listMonitored(monitoredFilter{limit: 10, offset: 1})
+		require.NoError(t, err)
+		require.Len(t, entities, 1)
+		require.Equal(t, "comp-1", entities[0].ID)
+
+		require.NoError(t, repo.removeMonitored(monitoredCustomer, "cust-1"))
+		require.ErrorIs(t, repo.removeMonitored(monitoredCustomer, "cust-1"), errMonitoredNotFound)
+	}
+
+	// SQLite tests
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+	check(t, &sqliteMonitoredRepository{sqliteDB.DB})
+
+	// MySQL tests
+	mysqlDB := database.TestMySQLConnection(t)
+	check(t, &sqliteMonitoredRepository{mysqlDB})
+}
+
+func TestMonitoring__screen(t *testing.T) {
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+	repo := &sqliteMonitoredRepository{db.DB}
+	alertRepo := &sqliteAlertRepository{db.DB}
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{
+			EntityID: "2676",
+			SDNName:  "AL ZAWAHIRI, Dr. Ayman",
+			SDNType:  "individual",
+			Remarks:  "DOB 19 Jun 1951; POB Giza, Egypt; nationality Egypt; Passport 1084010 (Egypt); alt. Passport 19820215",
+		},
+	}, nil, noLogPipeliner)
+	s.EUCSL = eu_cslSearcher.EUCSL
+
+	add := func(t *testing.T, m *monitoredEntity) {
+		t.Helper()
+		m.CreatedAt, m.UpdatedAt = time.Now(), time.Now()
+		require.NoError(t, repo.upsertMonitored(m))
+	}
+	add(t, &monitoredEntity{ID: "ayman", Type: monitoredCustomer, Name: "Ayman Al Zawahiri", DateOfBirth: "1951-06-19", Country: "EG",
+		Identifiers: []monitoredIdentifier{{Type: "passport", Number: "1084-010"}}})
+	add(t, &monitoredEntity{ID: "other-ayman", Type: monitoredCustomer, Name: "Ayman Al Zawahiri", DateOfBirth: "1990-01-01"})
+	add(t, &monitoredEntity{ID: "saddam", Type: monitoredCustomer, Name: "Saddam Hussein Al-Tikriti"})
+	add(t, &monitoredEntity{ID: "acme", Type: monitoredCompany, Name: "Acme Corp"})
+
+	// followers don't screen monitored entities
+	s.screenMonitored(log.NewNopLogger(), nil, repo, alertRepo, &leaderElection{})
+
+	alerts, err := alertRepo.listAlerts(alertFilter{limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+
+	s.screenMonitored(log.NewNopLogger(), &DownloadStats{ID: "downloadID"}, repo, alertRepo, nil)
+
+	alerts, err = alertRepo.listAlerts(alertFilter{limit: 10})
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+
+	byID := make(map[string]*screeningAlert)
+	for _, a := range alerts {
+		byID[a.MonitoredID] = a
+		require.Equal(t, "downloadID", a.DownloadID)
+	}
+	require.Equal(t, "2676", byID["ayman"].EntityID)
+	require.True(t, byID["ayman"].IdentifierMatch)
+	require.True(t, byID["ayman"].CountryMatch)
+	require.Equal(t, "EUCSL", byID["saddam"].List)
+	require.False(t, byID["saddam"].IdentifierMatch)
+	require.False(t, byID["saddam"].CountryMatch)
+
+	// the other Ayman was born decades later
+	other, err := repo.getMonitored(monitoredCustomer, "other-ayman")
+	require.NoError(t, err)
+	require.NotNil(t, other.ScreenedAt)
+	require.Empty(t, other.Matches)
+
+	// only new matches raise alerts
+	s.screenMonitored(log.NewNopLogger(), &DownloadStats{ID: "second"}, repo, alertRepo, nil)
+
+	alerts, err = alertRepo.listAlerts(alertFilter{limit: 10})
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+}
+
+func TestMonitoring__birthYears(t *testing.T) {
+	sdn := func(remarks string) watchHit {
+		return watchHit{Entity: &SDN{SDN: &ofac.SDN{Remarks: remarks}}}
+	}
+	years := func(hit watchHit) []int {
+		var out []int
+		for y := 1900; y < 2100; y++ {
+			if hitBirthYears(hit)[y] {
+				out = append(out, y)
+			}
+		}
+		return out
+	}
+
+	require.Equal(t, []int{1948}, years(sdn("DOB 10 Dec 1948; Director of PALESTINE LIBERATION FRONT.")))
+	require.Equal(t, []int{1960, 1961, 1962}, years(sdn("DOB 1960 to 1962; POB Baghdad, Iraq")))
+	require.Equal(t, []int{1953, 1954, 1955, 1956, 1957}, years(sdn("DOB circa 1955")))
+	require.Equal(t, []int{1948, 1950}, years(sdn("DOB 10 Dec 1948; alt. DOB 1950")))
+	require.Empty(t, years(sdn("Passport 19820215 (Egypt)")))
+	require.Empty(t, years(watchHit{Entity: &DP{}}))
+
+	uk := watchHit{Entity: &Result[csl.UKCSLRecord]{Data: csl.UKCSLRecord{DatesOfBirth: []string{"05/06/1961"}}}}
+	require.Equal(t, []int{1961}, years(uk))
+
+	require.False(t, birthYearConflicts("1961-06-05", hitBirthYears(uk)))
+	require.False(t, birthYearConflicts("1961", hitBirthYears(uk)))
+	require.True(t, birthYearConflicts("1962-06-05", hitBirthYears(uk)))
+	require.False(t, birthYearConflicts("", hitBirthYears(uk)))
+	require.False(t, birthYearConflicts("1962", nil))
+}
+
+func TestMonitoring__identifiers(t *testing.T) {
+	ids := []monitoredIdentifier{{Number: "CHE-427.006.032"}}
+
+	hit := watchHit{Entity: &Result[csl.SSI]{Data: csl.SSI{Details: csl.Details{Identifications: []csl.CSLIdentification{{Number: "CHE427006032"}}}}}}
+	require.True(t, hitIdentifierMatch(hit, ids))
+	require.False(t, hitIdentifierMatch(hit, nil))
+
+	hit = watchHit{Entity: &Result[csl.EUCSLRecord]{Data: csl.EUCSLRecord{Identifications: []*csl.Identification{nil, {Number: "che 427006032"}}}}}
+	require.True(t, hitIdentifierMatch(hit, ids))
+
+	hit = watchHit{Entity: &Result[csl.UKSanctionsListRecord]{Data: csl.UKSanctionsListRecord{Passports: []csl.UKSLDocument{{Number: "123"}}}}}
+	require.False(t, hitIdentifierMatch(hit, ids))
+	require.True(t, hitIdentifierMatch(hit, []monitoredIdentifier{{Number: "123"}}))
+
+	// a nil embedded SDN has nothing listed
+	require.False(t, hitIdentifierMatch(watchHit{Entity: &SDN{}}, ids))
+	require.Equal(t, "", hitName(watchHit{Entity: &SDN{}}))
+}
+
+func TestMonitoring__countries(t *testing.T) {
+	sdn := watchHit{Entity: &SDN{SDN: &ofac.SDN{Remarks: "DOB 1951; nationality Egypt; Passport 1084010 (Egypt)"}}}
+	require.True(t, hitCountryMatch(sdn, "EG"))
+	require.True(t, hitCountryMatch(sdn, "egypt"))
+	require.False(t, hitCountryMatch(sdn, "US"))
+	require.False(t, hitCountryMatch(sdn, ""))
+
+	dp := watchHit{Entity: &DP{DeniedPerson: &dpl.DPL{Country: "US"}}}
+	require.True(t, hitCountryMatch(dp, "United States"))
+	require.False(t, hitCountryMatch(watchHit{Entity: &DP{}}, "US"))
+
+	ssi := watchHit{Entity: &Result[csl.SSI]{Data: csl.SSI{Details: csl.Details{Nationalities: []string{"Russia"}}}}}
+	require.True(t, hitCountryMatch(ssi, "RU"))
+
+	eu := watchHit{Entity: &Result[csl.EUCSLRecord]{Data: csl.EUCSLRecord{Citizenships: []*csl.Citizenship{nil, {CountryIso2Code: "IQ"}}}}}
+	require.True(t, hitCountryMatch(eu, "IRQ"))
+
+	pepHit := watchHit{Entity: &Result[pep.PEP]{Data: pep.PEP{Countries: []string{"ru"}}}}
+	require.True(t, hitCountryMatch(pepHit, "Russia"))
+
+	entity := &ftm.Entity{}
+	entity.Add("jurisdiction", "gb")
+	require.True(t, hitCountryMatch(watchHit{Entity: &Result[ftm.Entity]{Data: *entity}}, "GB"))
+}
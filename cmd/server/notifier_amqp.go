@@ -0,0 +1,140 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// amqpNotifier publishes notifications to an exchange with AMQP 0-9-1 (e.g. RabbitMQ). One connection and
+// channel are kept open, and are replaced once they fail. Notifications are published as mandatory and
+// persistent in confirm mode, so they're only delivered once the broker acknowledged they were routed to a queue.
+type amqpNotifier struct {
+	url        string
+	exchange   string
+	routingKey string
+
+	mu      sync.Mutex // serializes publishes so confirms and returns match their notification
+	conn    *amqp.Connection
+	channel *amqp.Channel
+	returns chan amqp.Return
+}
+
+func newAMQPNotifier(rawURL, exchange, routingKey string) (*amqpNotifier, error) {
+	if _, err := amqp.ParseURI(rawURL); err != nil {
+		return nil, fmt.Errorf("invalid AMQP_URL: %v", err)
+	}
+	if routingKey == "" {
+		routingKey = defaultNotifierTopic
+	}
+	// the connection is opened by the first notification
+	return &amqpNotifier{
+		url:        rawURL,
+		exchange:   exchange,
+		routingKey: routingKey,
+	}, nil
+}
+
+// open connects to the broker and opens a channel in confirm mode unless they're still open.
+// Callers hold a.mu.
+func (a *amqpNotifier) open() error {
+	if a.channel != nil && !a.channel.IsClosed() {
+		return nil
+	}
+	a.reset()
+
+	conn, err := amqp.DialConfig(a.url, amqp.Config{
+		Dial:       amqp.DefaultDial(notifierTimeout),
+		Properties: amqp.Table{"connection_name": "watchman"},
+	})
+	if err != nil {
+		return fmt.Errorf("connecting to amqp: %v", err)
+	}
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("opening amqp channel: %v", err)
+	}
+	if err := channel.Confirm(false); err != nil {
+		conn.Close()
+		return fmt.Errorf("amqp confirm mode: %v", err)
+	}
+	a.conn, a.channel = conn, channel
+	a.returns = channel.NotifyReturn(make(chan amqp.Return, 1))
+	return nil
+}
+
+// reset closes the connection, so the next notification opens another. Callers hold a.mu.
+func (a *amqpNotifier) reset() {
+	if a.conn != nil {
+		a.conn.Close()
+	}
+	a.conn, a.channel, a.returns = nil, nil, nil
+}
+
+func (a *amqpNotifier) notify(n notification) (int, error) {
+	now := time.Now()
+	payload, err := n.envelope(now)
+	if err != nil {
+		return 0, &invalidWebhookError{fmt.Sprintf("encoding notification: %v", err)}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.open(); err != nil {
+		return 0, err
+	}
+	if err := a.publish(n.deliveryID, now, payload); err != nil {
+		// confirms and returns of this message could still arrive, so they're discarded with the channel
+		a.reset()
+		return 0, err
+	}
+	return 0, nil
+}
+
+func (a *amqpNotifier) publish(deliveryID string, now time.Time, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notifierTimeout)
+	defer cancel()
+
+	confirm, err := a.channel.PublishWithDeferredConfirmWithContext(ctx, a.exchange, a.routingKey, true, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    deliveryID,
+		Timestamp:    now,
+		Body:         payload,
+	})
+	if err != nil {
+		return fmt.Errorf("publishing to amqp: %v", err)
+	}
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("waiting for amqp confirm: %v", err)
+	}
+	if !acked {
+		return errors.New("amqp: message rejected by the broker")
+	}
+
+	// the broker returns unroutable messages before acknowledging them
+	select {
+	case ret := <-a.returns:
+		return fmt.Errorf("amqp: message returned by the broker: %d %s", ret.ReplyCode, ret.ReplyText)
+	default:
+		return nil
+	}
+}
+
+func (a *amqpNotifier) close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.reset()
+}
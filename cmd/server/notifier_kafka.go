@@ -0,0 +1,100 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+)
+
+const defaultNotifierTopic = "watchman.watches"
+
+// kafkaNotifier produces notifications to a topic with one client kept for the life of Watchman.
+// Records are keyed by watchID so each watch's notifications stay in order on one partition.
+type kafkaNotifier struct {
+	client *kgo.Client
+}
+
+type kafkaConfig struct {
+	brokers string
+	topic   string
+
+	tls                bool
+	username, password string
+}
+
+func newKafkaNotifier(conf kafkaConfig) (*kafkaNotifier, error) {
+	var brokers []string
+	for _, broker := range strings.Split(conf.brokers, ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	if len(brokers) == 0 || strings.Contains(conf.brokers, "://") {
+		return nil, fmt.Errorf("invalid KAFKA_BROKERS=%q", conf.brokers)
+	}
+	if conf.topic == "" {
+		conf.topic = defaultNotifierTopic
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(brokers...),
+		kgo.DefaultProduceTopic(conf.topic),
+		kgo.ClientID("watchman"),
+		kgo.DialTimeout(notifierTimeout),
+		kgo.RecordDeliveryTimeout(notifierTimeout),
+	}
+	if conf.tls {
+		opts = append(opts, kgo.DialTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+	if conf.username != "" {
+		opts = append(opts, kgo.SASL(plain.Auth{User: conf.username, Pass: conf.password}.AsMechanism()))
+	}
+
+	// the client connects to brokers once records are produced
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: %v", err)
+	}
+	return &kafkaNotifier{client: client}, nil
+}
+
+func (k *kafkaNotifier) notify(n notification) (int, error) {
+	value, err := n.envelope(time.Now())
+	if err != nil {
+		return 0, &invalidWebhookError{fmt.Sprintf("encoding notification: %v", err)}
+	}
+
+	if webhookGate != nil {
+		webhookGate.Start()
+		defer webhookGate.Done()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), notifierTimeout)
+	defer cancel()
+
+	record := &kgo.Record{Key: []byte(n.watchID), Value: value}
+	if err := k.client.ProduceSync(ctx, record).FirstErr(); err != nil {
+		var kafkaErr *kerr.Error
+		if errors.As(err, &kafkaErr) && !kafkaErr.Retriable {
+			// e.g. records which are too large or topics we can't write to
+			return 0, &invalidWebhookError{fmt.Sprintf("producing to kafka: %v", err)}
+		}
+		return 0, fmt.Errorf("producing to kafka: %v", err)
+	}
+	return 0, nil
+}
+
+func (k *kafkaNotifier) close() {
+	k.client.Close()
+}
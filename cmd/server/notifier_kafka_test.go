@@ -0,0 +1,53 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestKafkaNotifier(t *testing.T) {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, "watches"))
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	brokers := strings.Join(cluster.ListenAddrs(), ",")
+
+	k, err := newKafkaNotifier(kafkaConfig{brokers: brokers, topic: "watches"})
+	require.NoError(t, err)
+	t.Cleanup(k.close)
+
+	status, err := k.notify(notification{deliveryID: "deliveryID", watchID: "watchID", body: []byte(`{"id":"306"}`), secrets: []string{"secret"}})
+	require.NoError(t, err)
+	require.Equal(t, 0, status)
+
+	consumer, err := kgo.NewClient(kgo.SeedBrokers(cluster.ListenAddrs()...), kgo.ConsumeTopics("watches"))
+	require.NoError(t, err)
+	t.Cleanup(consumer.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	fetches := consumer.PollFetches(ctx)
+	require.NoError(t, fetches.Err())
+
+	records := fetches.Records()
+	require.Len(t, records, 1)
+	require.Equal(t, "watchID", string(records[0].Key))
+
+	env, body := verifyEnvelope(t, records[0].Value, "secret")
+	require.Equal(t, "deliveryID", env.DeliveryID)
+	require.JSONEq(t, `{"id":"306"}`, string(body))
+
+	for _, brokers := range []string{"", " , ", "http://localhost:8082"} {
+		_, err = newKafkaNotifier(kafkaConfig{brokers: brokers})
+		require.ErrorContains(t, err, "invalid KAFKA_BROKERS", brokers)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsNotifier publishes notifications to a subject over one connection kept for the life of Watchman.
+// Each publish is followed by a flush, which confirms the server processed it.
+type natsNotifier struct {
+	conn    *nats.Conn
+	subject string
+}
+
+func newNATSNotifier(rawURL, subject string) (*natsNotifier, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "nats" && u.Scheme != "tls") || u.Host == "" {
+		return nil, fmt.Errorf("invalid NATS_URL=%q", rawURL)
+	}
+	if subject == "" {
+		subject = defaultNotifierTopic
+	}
+	if strings.ContainsAny(subject, " \t\r\n") {
+		return nil, fmt.Errorf("invalid NATS_SUBJECT=%q", subject)
+	}
+
+	conn, err := nats.Connect(rawURL,
+		nats.Name("watchman"),
+		nats.Timeout(notifierTimeout),
+		// keep reconnecting in the background, including when NATS is down at startup
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		// fail publishes while disconnected so they're retried by the webhookQueue
+		nats.ReconnectBufSize(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to nats: %v", err)
+	}
+	return &natsNotifier{conn: conn, subject: subject}, nil
+}
+
+func (n *natsNotifier) notify(note notification) (int, error) {
+	payload, err := note.envelope(time.Now())
+	if err != nil {
+		return 0, &invalidWebhookError{fmt.Sprintf("encoding notification: %v", err)}
+	}
+
+	// nats.go rejects every payload as too large while it's reconnecting
+	if !n.conn.IsConnected() {
+		return 0, fmt.Errorf("publishing to nats: %v", nats.ErrConnectionReconnecting)
+	}
+	if err := n.conn.Publish(n.subject, payload); err != nil {
+		if errors.Is(err, nats.ErrMaxPayload) || errors.Is(err, nats.ErrBadSubject) {
+			return 0, &invalidWebhookError{fmt.Sprintf("publishing to nats: %v", err)}
+		}
+		return 0, fmt.Errorf("publishing to nats: %v", err)
+	}
+	if err := n.conn.FlushTimeout(notifierTimeout); err != nil {
+		return 0, fmt.Errorf("flushing to nats: %v", err)
+	}
+	return 0, nil
+}
+
+func (n *natsNotifier) close() {
+	n.conn.Close()
+}
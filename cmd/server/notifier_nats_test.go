@@ -0,0 +1,89 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+// runNATSServer starts an embedded NATS server on a random port
+func runNATSServer(t *testing.T, opts *server.Options) *server.Server {
+	t.Helper()
+
+	opts.Host, opts.Port = "127.0.0.1", server.RANDOM_PORT
+	opts.NoLog, opts.NoSigs = true, true
+
+	s, err := server.NewServer(opts)
+	require.NoError(t, err)
+	go s.Start()
+	t.Cleanup(s.Shutdown)
+
+	require.True(t, s.ReadyForConnections(5*time.Second))
+	return s
+}
+
+func TestNATSNotifier(t *testing.T) {
+	s := runNATSServer(t, &server.Options{Username: "user", Password: "pass"})
+	addr := strings.TrimPrefix(s.ClientURL(), "nats://")
+
+	sub, err := nats.Connect("nats://user:pass@" + addr)
+	require.NoError(t, err)
+	t.Cleanup(sub.Close)
+	messages, err := sub.SubscribeSync(defaultNotifierTopic)
+	require.NoError(t, err)
+	require.NoError(t, sub.Flush())
+
+	n, err := newNATSNotifier("nats://user:pass@"+addr, "")
+	require.NoError(t, err)
+	t.Cleanup(n.close)
+
+	status, err := n.notify(notification{deliveryID: "deliveryID", watchID: "watchID", body: []byte(`{"id":"306"}`), secrets: []string{"secret"}})
+	require.NoError(t, err)
+	require.Equal(t, 0, status)
+
+	msg, err := messages.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	env, body := verifyEnvelope(t, msg.Data, "secret")
+	require.Equal(t, "watchID", env.WatchID)
+	require.JSONEq(t, `{"id":"306"}`, string(body))
+}
+
+func TestNATSNotifier__errors(t *testing.T) {
+	s := runNATSServer(t, &server.Options{Authorization: "token", MaxPayload: 64})
+
+	n, err := newNATSNotifier(fmt.Sprintf("nats://token@%s", strings.TrimPrefix(s.ClientURL(), "nats://")), "watches")
+	require.NoError(t, err)
+	t.Cleanup(n.close)
+
+	// notifications larger than the server accepts are never retried
+	status, err := n.notify(notification{watchID: "watchID", body: []byte(`{"name":"` + strings.Repeat("a", 64) + `"}`)})
+	require.ErrorContains(t, err, "maximum payload exceeded")
+	require.False(t, retryableWebhook(status, err))
+
+	// publishes fail while disconnected and are retried
+	s.Shutdown()
+	require.Eventually(t, func() bool { return !n.conn.IsConnected() }, 5*time.Second, 10*time.Millisecond)
+	status, err = n.notify(notification{watchID: "watchID", body: []byte(`{}`)})
+	require.Error(t, err)
+	require.True(t, retryableWebhook(status, err))
+
+	_, err = newNATSNotifier("nats://localhost", "watch es")
+	require.ErrorContains(t, err, "invalid NATS_SUBJECT")
+
+	_, err = newNATSNotifier("localhost:4222", "")
+	require.ErrorContains(t, err, "invalid NATS_URL")
+
+	// nothing needs to be listening at startup
+	n, err = newNATSNotifier("nats://127.0.0.1:1", "")
+	require.NoError(t, err)
+	n.close()
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/base/strx"
+	"github.com/moov-io/watchman/client"
+)
+
+// notifierType selects how a watch's notifications are sent
+type notifierType string
+
+const (
+	webhookNotifierType notifierType = "webhook"
+	kafkaNotifierType   notifierType = "kafka"
+	natsNotifierType    notifierType = "nats"
+	amqpNotifierType    notifierType = "amqp"
+	fileNotifierType    notifierType = "file"
+	stdoutNotifierType  notifierType = "stdout"
+)
+
+func (t notifierType) valid() bool {
+	switch t {
+	case webhookNotifierType, kafkaNotifierType, natsNotifierType, amqpNotifierType, fileNotifierType, stdoutNotifierType:
+		return true
+	}
+	return false
+}
+
+// watchNotifier is used for watches which haven't selected their own notifier
+var watchNotifier = webhookNotifierType
+
+func init() {
+	watchNotifier = readNotifierType(os.Getenv("WATCH_NOTIFIER"))
+}
+
+func readNotifierType(str string) notifierType {
+	if t := notifierType(strings.ToLower(strings.TrimSpace(str))); t.valid() {
+		return t
+	}
+	return watchNotifier
+}
+
+// notifierType returns how w is notified
+func (w watch) notifierType() notifierType {
+	if w.notifier != "" {
+		return w.notifier
+	}
+	return watchNotifier
+}
+
+// notification is a watch's body as it's handed to a notifier
+type notification struct {
+	deliveryID string
+	watchID    string
+	webhook    string
+	authToken  string
+	body       []byte
+
+	// secrets sign the body, see client.SignWebhook
+	secrets []string
+}
+
+// notifier sends notifications to another system. Returned is the HTTP status code of webhooks,
+// other notifiers return zero.
+//
+// Errors are retried by the webhookQueue unless they're an *invalidWebhookError.
+type notifier interface {
+	notify(n notification) (int, error)
+}
+
+// notifierTimeout limits how long brokers have to accept a notification
+const notifierTimeout = 10 * time.Second
+
+// webhookNotifier POSTs notifications to each watch's HTTPS webhook
+type webhookNotifier struct{}
+
+func (webhookNotifier) notify(n notification) (int, error) {
+	return callWebhook(bytes.NewBuffer(n.body), n.webhook, n.authToken, n.secrets...)
+}
+
+// notificationEnvelope wraps the body of a notification sent anywhere but a webhook. Timestamp and
+// Signature are what the X-Watchman-Timestamp and X-Watchman-Signature headers of a webhook hold, so
+// receivers can check the body with client.VerifyWebhook.
+type notificationEnvelope struct {
+	DeliveryID string          `json:"deliveryID"`
+	WatchID    string          `json:"watchID"`
+	Timestamp  int64           `json:"timestamp"`
+	Signature  string          `json:"signature,omitempty"`
+	Body       json.RawMessage `json:"body"`
+}
+
+func (n notification) envelope(now time.Time) ([]byte, error) {
+	env := notificationEnvelope{
+		DeliveryID: n.deliveryID,
+		WatchID:    n.watchID,
+		Timestamp:  now.Unix(),
+		Body:       json.RawMessage(n.body),
+	}
+	if len(n.secrets) > 0 {
+		signatures := make([]string, len(n.secrets))
+		for i := range n.secrets {
+			signatures[i] = client.SignWebhook(n.secrets[i], now, n.body)
+		}
+		env.Signature = strings.Join(signatures, ",")
+	}
+	return json.Marshal(env)
+}
+
+// jsonLinesNotifier writes one envelope per line
+type jsonLinesNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (j *jsonLinesNotifier) notify(n notification) (int, error) {
+	line, err := n.envelope(time.Now())
+	if err != nil {
+		return 0, &invalidWebhookError{fmt.Sprintf("encoding notification: %v", err)}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.w.Write(append(line, '\n')); err != nil {
+		return 0, fmt.Errorf("writing notification: %v", err)
+	}
+	return 0, nil
+}
+
+// newNotifiers returns every notifier which is configured. Webhooks and stdout are always available.
+func newNotifiers() (map[notifierType]notifier, error) {
+	out := map[notifierType]notifier{
+		webhookNotifierType: webhookNotifier{},
+		stdoutNotifierType:  &jsonLinesNotifier{w: os.Stdout},
+	}
+	if path := os.Getenv("WATCH_NOTIFY_FILE"); path != "" {
+		fd, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("opening WATCH_NOTIFY_FILE: %v", err)
+		}
+		out[fileNotifierType] = &jsonLinesNotifier{w: fd}
+	}
+	if v := os.Getenv("KAFKA_BROKERS"); v != "" {
+		n, err := newKafkaNotifier(kafkaConfig{
+			brokers:  v,
+			topic:    os.Getenv("KAFKA_TOPIC"),
+			tls:      strx.Yes(os.Getenv("KAFKA_TLS")),
+			username: os.Getenv("KAFKA_SASL_USERNAME"),
+			password: os.Getenv("KAFKA_SASL_PASSWORD"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		out[kafkaNotifierType] = n
+	}
+	if v := os.Getenv("NATS_URL"); v != "" {
+		n, err := newNATSNotifier(v, os.Getenv("NATS_SUBJECT"))
+		if err != nil {
+			return nil, err
+		}
+		out[natsNotifierType] = n
+	}
+	if v := os.Getenv("AMQP_URL"); v != "" {
+		n, err := newAMQPNotifier(v, os.Getenv("AMQP_EXCHANGE"), os.Getenv("AMQP_ROUTING_KEY"))
+		if err != nil {
+			return nil, err
+		}
+		out[amqpNotifierType] = n
+	}
+	if _, ok := out[watchNotifier]; !ok {
+		return nil, fmt.Errorf("WATCH_NOTIFIER=%s isn't configured", watchNotifier)
+	}
+	return out, nil
+}
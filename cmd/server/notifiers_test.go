@@ -0,0 +1,143 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/client"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyEnvelope checks the signature of a notification sent anywhere but a webhook and returns its body
+func verifyEnvelope(t *testing.T, bs []byte, secret string) (notificationEnvelope, []byte) {
+	t.Helper()
+
+	var env notificationEnvelope
+	require.NoError(t, json.Unmarshal(bs, &env))
+
+	header := make(http.Header)
+	header.Set(client.WebhookTimestampHeader, strconv.FormatInt(env.Timestamp, 10))
+	header.Set(client.WebhookSignatureHeader, env.Signature)
+	require.NoError(t, client.VerifyWebhook(secret, header, env.Body, time.Minute))
+
+	return env, env.Body
+}
+
+func TestNotifiers__jsonLines(t *testing.T) {
+	var buf bytes.Buffer
+	n := &jsonLinesNotifier{w: &buf}
+
+	for _, id := range []string{"first", "second"} {
+		status, err := n.notify(notification{deliveryID: id, watchID: "watchID", body: []byte(`{"id":"306"}`), secrets: []string{"secret"}})
+		require.NoError(t, err)
+		require.Equal(t, 0, status)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	env, body := verifyEnvelope(t, lines[1], "secret")
+	require.Equal(t, "second", env.DeliveryID)
+	require.Equal(t, "watchID", env.WatchID)
+	require.JSONEq(t, `{"id":"306"}`, string(body))
+}
+
+func TestNotifiers__config(t *testing.T) {
+	notifiers, err := newNotifiers()
+	require.NoError(t, err)
+	require.Contains(t, notifiers, webhookNotifierType)
+	require.Contains(t, notifiers, stdoutNotifierType)
+	require.NotContains(t, notifiers, kafkaNotifierType)
+
+	t.Setenv("NATS_URL", "http://localhost:4222")
+	_, err = newNotifiers()
+	require.ErrorContains(t, err, "invalid NATS_URL")
+
+	require.Equal(t, natsNotifierType, readNotifierType("NATS"))
+	require.Equal(t, watchNotifier, readNotifierType("sqs"))
+}
+
+func TestNotifiers__queue(t *testing.T) {
+	queue := createTestWebhookQueue(t)
+
+	var buf bytes.Buffer
+	queue.notifiers[fileNotifierType] = &jsonLinesNotifier{w: &buf}
+
+	// watches notified somewhere else don't need a webhook
+	d, err := queue.enqueue(watch{id: "watchID", notifier: fileNotifierType}, bytes.NewBufferString(`{"id":"306"}`))
+	require.NoError(t, err)
+	require.Equal(t, fileNotifierType, d.Notifier)
+	require.NoError(t, queue.deliverDue(time.Now()))
+
+	found, err := queue.repo.getDelivery(d.ID)
+	require.NoError(t, err)
+	require.Equal(t, deliveryDelivered, found.Status)
+	require.Equal(t, fileNotifierType, found.Notifier)
+	require.Contains(t, buf.String(), d.ID)
+
+	// notifiers without config can't be delivered to
+	d, err = queue.enqueue(watch{id: "watchID", notifier: kafkaNotifierType}, bytes.NewBufferString(`{"id":"306"}`))
+	require.NoError(t, err)
+	require.NoError(t, queue.deliverDue(time.Now()))
+
+	found, err = queue.repo.getDelivery(d.ID)
+	require.NoError(t, err)
+	require.Equal(t, deliveryDead, found.Status)
+	require.Contains(t, found.LastError, "kafka notifier isn't configured")
+}
+
+func TestNotifiers__routes(t *testing.T) {
+	repo := createTestWatchRepository(t)
+	defer repo.close()
+
+	router := mux.NewRouter()
+	addCustomerRoutes(log.NewNopLogger(), router, nil, nil, repo)
+	addWatchRoutes(log.NewNopLogger(), router, repo, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac/customers/watch?name=foo", bytes.NewBufferString(`{"notifier":"nats"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var created customerWatchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+
+	info, err := repo.getWatch(created.WatchID)
+	require.NoError(t, err)
+	require.Equal(t, natsNotifierType, info.Notifier)
+	require.Empty(t, info.Webhook)
+
+	batch, err := repo.getWatchesCursor(log.NewNopLogger(), 4).Next()
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	require.Equal(t, natsNotifierType, batch[0].notifier)
+
+	// switching to webhooks needs a webhook
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+created.WatchID, bytes.NewBufferString(`{"notifier":"webhook"}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"notifier":"webhook","webhook":"https://example.com","authToken":"authToken"}`)
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+created.WatchID, body))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+	require.Equal(t, webhookNotifierType, info.Notifier)
+
+	for _, body := range []string{`{}`, `{"notifier":"webhook"}`, `{"notifier":"sqs"}`, `{"notifier":"nats","webhook":"http://example.com","authToken":"authToken"}`} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac/customers/watch?name=foo", bytes.NewBufferString(body)))
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
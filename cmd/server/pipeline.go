@@ -11,7 +11,9 @@ import (
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/watchman/pkg/csl"
 	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ftm"
 	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/pep"
 )
 
 // Name represents an individual or entity name to be processed for search.
@@ -42,6 +44,10 @@ type Name struct {
 
 	uk_sanctionsList *csl.UKSanctionsListRecord
 
+	pep *pep.PEP
+
+	ftm *ftm.Entity
+
 	dp    *dpl.DPL
 	el    *csl.EL
 	addrs []*ofac.Address
@@ -183,6 +189,22 @@ func cslName(item interface{}) *Name {
 		}
 
 		return &Name{}
+	case *pep.PEP:
+		return &Name{
+			Original:  v.Name,
+			Processed: v.Name,
+			pep:       v,
+			altNames:  v.AlternateNames,
+		}
+	case *ftm.Entity:
+		if names := v.Names(); len(names) >= 1 {
+			return &Name{
+				Original:  names[0],
+				Processed: names[0],
+				ftm:       v,
+				altNames:  v.AlternateNames(),
+			}
+		}
 	}
 	return &Name{}
 }
@@ -0,0 +1,267 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+)
+
+const (
+	dataQualityPath = "/data/quality"
+
+	skipPipelineError = "pipeline error"
+	skipMissingName   = "missing name"
+
+	// emptinessJump is how far the share of records with a field empty can rise
+	// between two refreshes before the field is reported as unexpectedly empty.
+	emptinessJump = 0.25
+)
+
+// QualityReport holds the ListQuality of each list, keyed by list name
+type QualityReport map[string]*ListQuality
+
+// ListQuality summarizes how cleanly a list's source data was read during a refresh
+type ListQuality struct {
+	// RowsRead is the number of records read from the source file(s)
+	RowsRead int `json:"rowsRead"`
+	// RowsSkipped is the number of rows and records left out of the search index
+	RowsSkipped int `json:"rowsSkipped"`
+	// SkipReasons breaks RowsSkipped down by why they were left out
+	SkipReasons map[string]int `json:"skipReasons,omitempty"`
+
+	// EmptyFields is the share (0-1) of records with each field empty
+	EmptyFields map[string]float64 `json:"emptyFields,omitempty"`
+	// UnexpectedlyEmpty lists the fields which are empty far more often than in the previous refresh
+	UnexpectedlyEmpty []string `json:"unexpectedlyEmpty,omitempty"`
+
+	// HeaderDrift describes how the file's header differs from the expected columns
+	HeaderDrift []string `json:"headerDrift,omitempty"`
+
+	// Error is why the list couldn't be downloaded or read, in which case its previous data was kept
+	Error string `json:"error,omitempty"`
+}
+
+func newListQuality() *ListQuality {
+	return &ListQuality{
+		SkipReasons: make(map[string]int),
+	}
+}
+
+func (q *ListQuality) skip(reason string, n int) {
+	if n <= 0 {
+		return
+	}
+	q.RowsSkipped += n
+	q.SkipReasons[reason] += n
+}
+
+func (q *ListQuality) skipAll(reasons map[string]int) {
+	for reason, n := range reasons {
+		q.skip(reason, n)
+	}
+}
+
+// compare flags the fields which are empty more often than they were in prev
+func (q *ListQuality) compare(prev *ListQuality) {
+	if prev == nil {
+		return
+	}
+	q.UnexpectedlyEmpty = nil
+	for field, rate := range q.EmptyFields {
+		before, exists := prev.EmptyFields[field]
+		if exists && rate-before >= emptinessJump {
+			q.UnexpectedlyEmpty = append(q.UnexpectedlyEmpty, field)
+		}
+	}
+	sort.Strings(q.UnexpectedlyEmpty)
+}
+
+// dropIncomplete removes records which can't be searched: those the pipeline failed
+// on (left nil by the precompute functions) and those without a name.
+func dropIncomplete[T any](items []*T, name func(*T) string, q *ListQuality) []*T {
+	out := make([]*T, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			q.skip(skipPipelineError, 1)
+			continue
+		}
+		if strings.TrimSpace(name(item)) == "" {
+			q.skip(skipMissingName, 1)
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func resultName[T any](r *Result[T]) string {
+	return r.precomputedName
+}
+
+// fieldEmptiness computes the share of records where each exported string, slice,
+// map or pointer field is empty.
+func fieldEmptiness[T any](items []*T) map[string]float64 {
+	if len(items) == 0 {
+		return nil
+	}
+	empties := make(map[string]int)
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		elm := reflect.ValueOf(item).Elem()
+		if elm.Kind() != reflect.Struct {
+			return nil
+		}
+		for i := 0; i < elm.NumField(); i++ {
+			field := elm.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			empty, ok := isEmptyValue(elm.Field(i))
+			if !ok {
+				continue
+			}
+			if _, exists := empties[field.Name]; !exists {
+				empties[field.Name] = 0
+			}
+			if empty {
+				empties[field.Name]++
+			}
+		}
+	}
+	out := make(map[string]float64, len(empties))
+	for field, n := range empties {
+		out[field] = float64(n) / float64(len(items))
+	}
+	return out
+}
+
+func isEmptyValue(v reflect.Value) (empty bool, ok bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == "", true
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0, true
+	case reflect.Pointer:
+		return v.IsNil(), true
+	}
+	return false, false
+}
+
+// list returns the quality of a list, adding it to the report if needed
+func (r QualityReport) list(name string) *ListQuality {
+	q, exists := r[name]
+	if !exists {
+		q = newListQuality()
+		r[name] = q
+	}
+	return q
+}
+
+// keepPrevious records err against the list, which keeps serving its previous data
+func (r QualityReport) keepPrevious(list string, err error) {
+	r.list(list).Error = err.Error()
+}
+
+// kept reports if the list is keeping its previous data
+func (r QualityReport) kept(list string) bool {
+	q, exists := r[list]
+	return exists && q.Error != ""
+}
+
+// qualityLists are the names a list's quality is reported under
+func qualityLists(list string) []string {
+	switch list {
+	case "SDNs":
+		return []string{"SDNs", "Alts"}
+	case "CSL":
+		return []string{"CSL", "BISEntities", "MilitaryEndUsers", "SSIs", "UVLs", "ISNs", "FSEs", "PLCs", "CAPs", "DTCs", "CMICs", "NS_MBSs"}
+	}
+	return []string{list}
+}
+
+// checkList fills in a list's quality from its parsed records and returns the searchable ones
+func checkList[T, R any](report QualityReport, list string, records []*T, precomputed []*R, name func(*R) string) (*ListQuality, []*R) {
+	q := report.list(list)
+	q.RowsRead += len(records)
+	q.EmptyFields = fieldEmptiness(records)
+	return q, dropIncomplete(precomputed, name, q)
+}
+
+// recordQuality compares report against the previous refresh's and keeps it for the admin endpoint
+func (s *searcher) recordQuality(report QualityReport) {
+	s.Lock()
+	defer s.Unlock()
+
+	for list, q := range report {
+		if s.quality[list] == q {
+			continue // carried forward from a list which wasn't read
+		}
+		if prev := s.quality[list]; q.Error != "" && prev != nil {
+			// the list kept its previous data, so keep describing it
+			q.RowsRead, q.EmptyFields = prev.RowsRead, prev.EmptyFields
+		}
+		q.compare(s.quality[list])
+
+		if s.logger == nil {
+			continue
+		}
+		if q.RowsSkipped > 0 {
+			s.logger.Warn().With(log.Fields{
+				"list":    log.String(list),
+				"skipped": log.Int(q.RowsSkipped),
+			}).Logf("skipped %d %s rows", q.RowsSkipped, list)
+		}
+		if len(q.UnexpectedlyEmpty) > 0 {
+			s.logger.Warn().With(log.Fields{
+				"list": log.String(list),
+			}).Logf("%s fields are unexpectedly empty: %s", list, strings.Join(q.UnexpectedlyEmpty, ", "))
+		}
+		if q.Error != "" {
+			s.logger.Error().With(log.Fields{
+				"list": log.String(list),
+			}).Logf("keeping previous %s data: %s", list, q.Error)
+		}
+		if len(q.HeaderDrift) > 0 {
+			s.logger.Warn().With(log.Fields{
+				"list": log.String(list),
+			}).Logf("%s header drift: %s", list, strings.Join(q.HeaderDrift, "; "))
+		}
+	}
+	s.quality = report
+}
+
+func (s *searcher) dataQuality() QualityReport {
+	s.RLock()
+	defer s.RUnlock()
+	return s.quality
+}
+
+func dataQualityHandler(logger log.Logger, searcher *searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if requestID := moovhttp.GetRequestID(r); requestID != "" {
+			logger.Info().With(log.Fields{
+				"requestID": log.String(requestID),
+			}).Log("data quality report")
+		}
+
+		report := searcher.dataQuality()
+		if report == nil {
+			report = QualityReport{}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(report)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/dpl"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuality__checkList(t *testing.T) {
+	persons := []*dpl.DPL{
+		{Name: "Jane Doe", City: "Tucson"},
+		{Name: "", City: "Phoenix"},
+		{Name: "John Doe"},
+	}
+	precomputed := precomputeDPs(persons, noLogPipeliner)
+	precomputed[2] = nil // the pipeline failed on this record
+
+	report := make(QualityReport)
+	q, dps := checkList(report, "DPs", persons, precomputed, func(dp *DP) string { return dp.name })
+
+	require.Len(t, dps, 1)
+	require.Equal(t, "jane doe", dps[0].name)
+
+	require.Equal(t, 3, q.RowsRead)
+	require.Equal(t, 2, q.RowsSkipped)
+	require.Equal(t, map[string]int{skipMissingName: 1, skipPipelineError: 1}, q.SkipReasons)
+
+	require.InDelta(t, 1.0/3.0, q.EmptyFields["Name"], 0.001)
+	require.InDelta(t, 1.0/3.0, q.EmptyFields["City"], 0.001)
+	require.InDelta(t, 1.0, q.EmptyFields["State"], 0.001)
+	require.Same(t, q, report["DPs"])
+}
+
+func TestQuality__compare(t *testing.T) {
+	prev := &ListQuality{
+		EmptyFields: map[string]float64{"Name": 0.0, "City": 0.1, "State": 0.9},
+	}
+	q := &ListQuality{
+		EmptyFields: map[string]float64{"Name": 0.05, "City": 0.8, "State": 1.0, "Country": 1.0},
+	}
+	q.compare(nil)
+	require.Empty(t, q.UnexpectedlyEmpty)
+
+	q.compare(prev)
+	require.Equal(t, []string{"City"}, q.UnexpectedlyEmpty)
+}
+
+func TestQuality__handler(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", dataQualityPath, nil)
+	dataQualityHandler(log.NewNopLogger(), s)(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, "{}", w.Body.String())
+
+	q := newListQuality()
+	q.RowsRead = 10
+	q.skip("sdn.csv: malformed row", 2)
+	s.recordQuality(QualityReport{"SDNs": q})
+
+	w = httptest.NewRecorder()
+	dataQualityHandler(log.NewNopLogger(), s)(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var report QualityReport
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	require.Equal(t, 10, report["SDNs"].RowsRead)
+	require.Equal(t, 2, report["SDNs"].RowsSkipped)
+	require.Equal(t, 2, report["SDNs"].SkipReasons["sdn.csv: malformed row"])
+}
+
+func TestQuality__keepPrevious(t *testing.T) {
+	report := make(QualityReport)
+
+	require.False(t, report.kept("DPs"))
+	report.keepPrevious("DPs", errors.New("connection refused"))
+	require.True(t, report.kept("DPs"))
+	require.Equal(t, "connection refused", report["DPs"].Error)
+
+	_, _, err := csl.ParseUKCSL(strings.NewReader("Report Date\nName 6,Group ID\n"))
+	require.ErrorIs(t, err, csl.ErrSchemaDrift)
+	report.keepPrevious("UKCSL", err)
+	require.True(t, report.kept("UKCSL"))
+	require.Contains(t, report["UKCSL"].Error, "schema drift")
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	prev := newListQuality()
+	prev.RowsRead = 42
+	prev.EmptyFields = map[string]float64{"Names": 0.0}
+	s.recordQuality(QualityReport{"UKCSL": prev})
+
+	s.recordQuality(report)
+	require.Equal(t, 42, s.dataQuality()["UKCSL"].RowsRead)
+	require.Empty(t, s.dataQuality()["UKCSL"].UnexpectedlyEmpty)
+}
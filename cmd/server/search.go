@@ -20,7 +20,9 @@ import (
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/watchman/pkg/csl"
 	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ftm"
 	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/pep"
 
 	"github.com/xrash/smetrics"
 	"go4.org/syncutil"
@@ -67,13 +69,30 @@ type searcher struct {
 	// UK Sanctions List
 	UKSanctionsList []*Result[csl.UKSanctionsListRecord]
 
+	// Politically Exposed Persons
+	PEPs []*Result[pep.PEP]
+
+	// FollowTheMoney entities imported from the initial data directory
+	FtMEntities []*Result[ftm.Entity]
+
 	// metadata
 	lastRefreshedAt time.Time
+	freshness       map[string]ListFreshness
+	quality         QualityReport
+	entities        map[string]listEntities // for diffs between refreshes
+	snapshotID      string
 	sync.RWMutex    // protects all above fields
 	*syncutil.Gate  // limits concurrent processing
 
+	// refreshMu serializes refreshes from reading lists until they're swapped in, so a
+	// partial refresh can't swap back lists which another refresh has replaced.
+	refreshMu sync.Mutex
+
 	pipe *pipeliner
 
+	// snapshots stores each refresh when enabled
+	snapshots *snapshotRepository
+
 	logger log.Logger
 }
 
@@ -88,6 +107,14 @@ func newSearcher(logger log.Logger, pipeline *pipeliner, workers int) *searcher
 	}
 }
 
+// metadata returns when the lists were refreshed and the snapshot they were loaded from
+func (s *searcher) metadata() (time.Time, map[string]ListFreshness, string) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.lastRefreshedAt, s.freshness, s.snapshotID
+}
+
 func (s *searcher) FindAddresses(limit int, id string) []*ofac.Address {
 	s.RLock()
 	defer s.RUnlock()
@@ -587,6 +614,21 @@ type DP struct {
 	name         string
 }
 
+// ListedBirthDates returns nil, as denied persons are listed without dates of birth
+func (d *DP) ListedBirthDates() []string {
+	return nil
+}
+
+// ListedIDNumbers returns nil, as denied persons are listed without IDs
+func (d *DP) ListedIDNumbers() []string {
+	return nil
+}
+
+// ListedCountries returns the country of the denied person's address
+func (d *DP) ListedCountries() []string {
+	return []string{d.DeniedPerson.Country}
+}
+
 // MarshalJSON is a custom method for marshaling a BIS Denied Person (DP)
 func (d DP) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
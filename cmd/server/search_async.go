@@ -18,10 +18,20 @@ import (
 
 var (
 	watchResearchBatchSize = 100
+
+	// watchRescreenIncremental only re-screens watches against the entities which were added
+	// or modified by a refresh instead of the entire lists.
+	watchRescreenIncremental = false
+
+	// watchRescreenMinMatch is the lowest match a name watch needs to be notified of a
+	// changed entity during incremental re-screening.
+	watchRescreenMinMatch = 0.90
 )
 
 func init() {
 	watchResearchBatchSize = readWebhookBatchSize(os.Getenv("WEBHOOK_BATCH_SIZE"))
+	watchRescreenIncremental = strings.EqualFold(os.Getenv("WATCH_RESCREEN_MODE"), "incremental")
+	watchRescreenMinMatch = readRescreenMinMatch(os.Getenv("WATCH_RESCREEN_MIN_MATCH"))
 }
 
 func readWebhookBatchSize(str string) int {
@@ -35,105 +45,235 @@ func readWebhookBatchSize(str string) int {
 	return watchResearchBatchSize
 }
 
+func readRescreenMinMatch(str string) float64 {
+	if str == "" {
+		return watchRescreenMinMatch
+	}
+	f, _ := strconv.ParseFloat(str, 64)
+	if f > 0 && f <= 1 {
+		return f
+	}
+	return watchRescreenMinMatch
+}
+
+// rescreenChanges returns the entities watches are re-screened against after a refresh.
+// nil is returned when every watch is re-screened against the entire lists, which happens
+// unless incremental re-screening is enabled and the refresh was compared to the previous one.
+func rescreenChanges(incremental bool, stats *DownloadStats) changedEntities {
+	if !incremental || stats == nil || len(stats.changes) == 0 {
+		return nil
+	}
+	out := make(changedEntities)
+	for list, changes := range stats.changes {
+		ids := make(map[string]bool)
+		for _, c := range changes.Added {
+			ids[c.ID] = true
+		}
+		for _, c := range changes.Modified {
+			ids[c.ID] = true
+		}
+		out[list] = ids
+	}
+	return out
+}
+
 // spawnResearching will block and select on updates for when to re-inspect all watches setup.
 // Since watches are used to post list data via webhooks they are used as catalysts in other systems.
-func (s *searcher) spawnResearching(logger log.Logger, companyRepo companyRepository, custRepo customerRepository, watchRepo watchRepository, webhookRepo webhookRepository) {
-	s.logger.Log("async: starting re-search of watches")
+//
+// Each rendered body is queued and delivered (with retries) by the webhookQueue.
+//
+// Only the replica holding the watch leader lease re-screens watches, and it stops between
+// batches if the lease is lost, so replicas sharing a database don't notify watches twice.
+func (s *searcher) spawnResearching(logger log.Logger, stats *DownloadStats, companyRepo companyRepository, custRepo customerRepository, watchRepo watchRepository, queue *webhookQueue, leader *leaderElection) {
+	if !leader.isLeader() {
+		s.logger.Log("async: another replica holds the watch leader lease, skipping re-search of watches")
+		return
+	}
+	changed := rescreenChanges(watchRescreenIncremental, stats)
+	if changed != nil {
+		s.logger.Logf("async: starting incremental re-search of watches against %d changed entities", changed.count())
+		if changed.count() == 0 {
+			return
+		}
+	} else {
+		s.logger.Log("async: starting re-search of watches")
+	}
 	cursor := watchRepo.getWatchesCursor(logger, watchResearchBatchSize)
 	for {
 		watches, _ := cursor.Next()
 		if len(watches) == 0 {
 			break
 		}
+		if !leader.isLeader() {
+			s.logger.Log("async: lost the watch leader lease, stopping re-search of watches")
+			return
+		}
 		for i := range watches {
-			body, err := s.renderBody(watches[i], companyRepo, custRepo)
+			body, results, err := s.rescreenWatch(watches[i], changed, companyRepo, custRepo, watchRepo)
 			if err != nil {
 				s.logger.Logf("async: watch %s: %v", watches[i].id, err)
 				continue
 			}
 			if body == nil {
-				s.logger.Logf("async: no body rendered for watchID=%s - skipping", watches[i].id)
+				if changed == nil && watches[i].notifyPolicy() == notifyAlways {
+					s.logger.Logf("async: no body rendered for watchID=%s - skipping", watches[i].id)
+				}
 				continue
 			}
 
-			// Send HTTP webhook
-			now := time.Now()
-			status, err := callWebhook(body, watches[i].webhook, watches[i].authToken)
-			if err != nil {
-				s.logger.Logf("async: problem writing watch (%s) webhook status: %v", watches[i].id, err)
+			// Queue the HTTP webhook
+			if _, err := queue.enqueue(watches[i], body); err != nil {
+				s.logger.Logf("async: problem queueing watch (%s) webhook: %v", watches[i].id, err)
+				continue
 			}
-			if err := webhookRepo.recordWebhook(watches[i].id, now, status); err != nil {
-				s.logger.Logf("async: problem writing watch (%s) webhook status: %v", watches[i].id, err)
+			if err := watchRepo.saveWatchResults(watches[i].id, results, time.Now()); err != nil {
+				s.logger.Logf("async: problem saving watch (%s) results: %v", watches[i].id, err)
 			}
 		}
 	}
 	s.logger.Log("async: finished re-search of watches")
 }
 
-func (s *searcher) renderBody(w watch, companyRepo companyRepository, custRepo customerRepository) (*bytes.Buffer, error) {
-	// Perform a query (ID watches) or search (name watches) and encode the model in JSON for calling the webhook.
+// rescreenWatch renders the body w is notified with, including how its hits differ from those it was
+// last notified of, and the results to save once the body is queued. nil is returned when the watch's
+// notifyPolicy skips this refresh.
+func (s *searcher) rescreenWatch(w watch, changed changedEntities, companyRepo companyRepository, custRepo customerRepository, watchRepo watchRepository) (*bytes.Buffer, []watchResult, error) {
+	hits := s.watchHits(w, changed)
+
+	previous, err := watchRepo.getWatchResults(w.id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("problem reading notified results: %v", err)
+	}
+	var before []watchResult
+	if previous != nil {
+		before = previous.Results
+	}
+
+	// Entities which are no longer listed are only sent to watches notified of changes
+	policy := w.notifyPolicy()
+	if len(hits) == 0 && changed == nil && (policy == notifyAlways || len(before) == 0) {
+		return nil, nil, watchNotFound(w)
+	}
+
+	diff, results := diffWatchResults(before, newWatchResults(hits), changed != nil)
+	if !policy.shouldNotify(len(hits) > 0, diff) {
+		return nil, nil, nil
+	}
+	if hits == nil {
+		hits = []watchHit{}
+	}
+	body, err := s.encodeWatchNotification(w, watchNotification{Hits: hits, Diff: &diff}, companyRepo, custRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, results, nil
+}
+
+// watchHits looks up (ID watches) or searches (name watches) each list w selects
+func (s *searcher) watchHits(w watch, changed changedEntities) []watchHit {
+	minMatch := watchMinMatch
+	if changed != nil {
+		minMatch = watchRescreenMinMatch
+	}
+	if w.minMatch > 0 {
+		minMatch = w.minMatch
+	}
+
 	switch {
 	case w.customerID != "":
 		s.logger.Logf("async: watch %s for customer %s found", w.id, w.customerID)
-		return getCustomerBody(s, w.id, w.customerID, 1.0, custRepo)
+		return s.findWatchHits(w.idList(), w.customerID, changed)
 
 	case w.customerName != "":
 		s.logger.Logf("async: name watch '%s' for customer %s found", w.customerName, w.id)
-		keeper := keepSDN(filterRequest{
-			sdnType: "individual",
-		})
-		sdns := s.TopSDNs(5, 0.00, w.customerName, keeper)
-		for j := range sdns {
-			if strings.EqualFold(sdns[j].SDNType, "individual") {
-				return getCustomerBody(s, w.id, sdns[j].EntityID, sdns[j].match, custRepo)
-			}
-		}
+		return s.searchWatchHits(w.watchLists(), w.customerName, "individual", minMatch, changed)
 
 	case w.companyID != "":
 		s.logger.Logf("async: watch %s for company %s found", w.id, w.companyID)
-		return getCompanyBody(s, w.id, w.companyID, 1.0, companyRepo)
+		return s.findWatchHits(w.idList(), w.companyID, changed)
 
 	case w.companyName != "":
 		s.logger.Logf("async: name watch '%s' for company %s found", w.companyName, w.id)
-		keeper := keepSDN(filterRequest{
-			sdnType: "entity",
-		})
-		sdns := s.TopSDNs(5, 0.00, w.companyName, keeper)
-		for j := range sdns {
-			if !strings.EqualFold(sdns[j].SDNType, "individual") {
-				return getCompanyBody(s, w.id, sdns[j].EntityID, sdns[j].match, companyRepo)
-			}
+		return s.searchWatchHits(w.watchLists(), w.companyName, "entity", minMatch, changed)
+	}
+	return nil
+}
+
+// watchNotFound returns the error for an ID watch whose entity isn't on any of its lists.
+// Name watches without hits aren't an error.
+func watchNotFound(w watch) error {
+	switch {
+	case w.customerID != "":
+		return fmt.Errorf("async: watch %s customer %v not found", w.id, w.customerID)
+	case w.companyID != "":
+		return fmt.Errorf("async: watch %s company %v not found", w.id, w.companyID)
+	}
+	return nil
+}
+
+// encodeWatchNotification encodes note with the best OFAC SDN hit as the customer or company
+func (s *searcher) encodeWatchNotification(w watch, note watchNotification, companyRepo companyRepository, custRepo customerRepository) (*bytes.Buffer, error) {
+	sdn := firstSDNHit(note.Hits)
+	switch {
+	case w.customerID != "" || w.customerName != "":
+		if sdn == nil {
+			return encodeWatchBody(w.id, customerWatchBody{watchNotification: note})
+		}
+		return getCustomerBody(s, w.id, sdn.EntityID, sdn.Match, note, custRepo)
+
+	default:
+		if sdn == nil {
+			return encodeWatchBody(w.id, companyWatchBody{watchNotification: note})
 		}
+		return getCompanyBody(s, w.id, sdn.EntityID, sdn.Match, note, companyRepo)
+	}
+}
+
+// watchNotification is included in every watch's body. Diff is only set when the watch is re-screened
+// after a refresh and holds how the hits differ from those the watch was last notified of.
+type watchNotification struct {
+	Hits []watchHit `json:"hits"`
+	Diff *watchDiff `json:"diff,omitempty"`
+}
+
+// customerWatchBody is sent to customer watches, Customer is nil without an OFAC SDN hit
+type customerWatchBody struct {
+	*Customer
+	watchNotification
+}
+
+// companyWatchBody is sent to company watches, Company is nil without an OFAC SDN hit
+type companyWatchBody struct {
+	*Company
+	watchNotification
+}
+
+func encodeWatchBody(watchID string, body interface{}) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("problem creating JSON for watch %s: %v", watchID, err)
 	}
-	return nil, nil
+	return &buf, nil
 }
 
 // getCustomerBody returns the JSON encoded form of a given customer by their EntityID
-func getCustomerBody(s *searcher, watchID string, customerID string, match float64, repo customerRepository) (*bytes.Buffer, error) {
+func getCustomerBody(s *searcher, watchID string, customerID string, match float64, note watchNotification, repo customerRepository) (*bytes.Buffer, error) {
 	customer, _ := getCustomerByID(customerID, s, repo)
 	if customer == nil {
 		return nil, fmt.Errorf("async: watch %s customer %v not found", watchID, customerID)
 	}
 	customer.Match = match
 
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(customer); err != nil {
-		return nil, fmt.Errorf("problem creating JSON for customer watch %s: %v", watchID, err)
-	}
-	return &buf, nil
+	return encodeWatchBody(watchID, customerWatchBody{Customer: customer, watchNotification: note})
 }
 
 // getCompanyBody returns the JSON encoded form of a given customer by their EntityID
-func getCompanyBody(s *searcher, watchID string, companyID string, match float64, repo companyRepository) (*bytes.Buffer, error) {
+func getCompanyBody(s *searcher, watchID string, companyID string, match float64, note watchNotification, repo companyRepository) (*bytes.Buffer, error) {
 	company, _ := getCompanyByID(companyID, s, repo)
 	if company == nil {
 		return nil, fmt.Errorf("async: watch %s company %v not found", watchID, companyID)
 	}
 	company.Match = match
 
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(company); err != nil {
-		return nil, fmt.Errorf("problem creating JSON for company watch %s: %v", watchID, err)
-	}
-	return &buf, nil
+	return encodeWatchBody(watchID, companyWatchBody{Company: company, watchNotification: note})
 }
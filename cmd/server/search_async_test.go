@@ -7,6 +7,8 @@ package main
 import (
 	"encoding/json"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestSearchAsync_batchSize(t *testing.T) {
@@ -19,7 +21,7 @@ func TestSearchAsync_batchSize(t *testing.T) {
 	}
 }
 
-func TestSearchAsync__renderBody(t *testing.T) {
+func TestSearchAsync__rescreenWatch(t *testing.T) {
 	w := watch{
 		id:           "12345",
 		customerID:   "306",
@@ -32,8 +34,10 @@ func TestSearchAsync__renderBody(t *testing.T) {
 	defer companyRepo.close()
 	customerRepo := createTestCustomerRepository(t)
 	defer customerRepo.close()
+	watchRepo := createTestWatchRepository(t)
+	defer watchRepo.close()
 
-	body, err := customerSearcher.renderBody(w, companyRepo, customerRepo)
+	body, _, err := customerSearcher.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -48,7 +52,7 @@ func TestSearchAsync__renderBody(t *testing.T) {
 		webhook:     "https://moov.io",
 		authToken:   "hidden",
 	}
-	body, err = companySearcher.renderBody(w, companyRepo, customerRepo)
+	body, _, err = companySearcher.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -57,7 +61,7 @@ func TestSearchAsync__renderBody(t *testing.T) {
 	}
 }
 
-func TestSearchAsync__renderBodyName(t *testing.T) {
+func TestSearchAsync__rescreenWatchName(t *testing.T) {
 	w := watch{
 		id:           "12345",
 		customerName: "BANCO NACIONAL DE CUBA",
@@ -69,8 +73,10 @@ func TestSearchAsync__renderBodyName(t *testing.T) {
 	defer companyRepo.close()
 	customerRepo := createTestCustomerRepository(t)
 	defer customerRepo.close()
+	watchRepo := createTestWatchRepository(t)
+	defer watchRepo.close()
 
-	body, err := customerSearcher.renderBody(w, companyRepo, customerRepo)
+	body, _, err := customerSearcher.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -84,7 +90,7 @@ func TestSearchAsync__renderBodyName(t *testing.T) {
 		webhook:     "https://moov.io",
 		authToken:   "hidden",
 	}
-	body, err = companySearcher.renderBody(w, companyRepo, customerRepo)
+	body, _, err = companySearcher.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -97,7 +103,7 @@ func TestSearchAsync_getCompanyBody(t *testing.T) {
 	repo := createTestCompanyRepository(t)
 	defer repo.close()
 
-	body, err := getCompanyBody(companySearcher, "watchID", "21206", 1.0, repo)
+	body, err := getCompanyBody(companySearcher, "watchID", "21206", 1.0, watchNotification{}, repo)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -117,7 +123,7 @@ func TestSearchAsync_getCompanyBody(t *testing.T) {
 	}
 
 	// Company not found
-	body, err = getCompanyBody(companySearcher, "watchID", "", 0.0, repo)
+	body, err = getCompanyBody(companySearcher, "watchID", "", 0.0, watchNotification{}, repo)
 	if err == nil || body != nil {
 		t.Fatal("expected error and no body")
 	}
@@ -127,7 +133,7 @@ func TestSearchAsync_getCustomerBody(t *testing.T) {
 	repo := createTestCustomerRepository(t)
 	defer repo.close()
 
-	body, err := getCustomerBody(customerSearcher, "watchID", "306", 0.91, repo)
+	body, err := getCustomerBody(customerSearcher, "watchID", "306", 0.91, watchNotification{}, repo)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -147,8 +153,86 @@ func TestSearchAsync_getCustomerBody(t *testing.T) {
 	}
 
 	// Customer not found
-	body, err = getCustomerBody(customerSearcher, "watchID", "", 0.0, repo)
+	body, err = getCustomerBody(customerSearcher, "watchID", "", 0.0, watchNotification{}, repo)
 	if err == nil || body != nil {
 		t.Fatal("expected error and no body")
 	}
 }
+
+func TestSearchAsync__rescreenChanges(t *testing.T) {
+	stats := &DownloadStats{
+		changes: map[string]*ListChanges{
+			"SDNs": {
+				Added:    []EntityChange{{ID: "306", Name: "BANCO NACIONAL DE CUBA"}},
+				Removed:  []EntityChange{{ID: "22790", Name: "MADURO MOROS, Nicolas"}},
+				Modified: []EntityChange{{ID: "21206", Name: "AL-HISN"}},
+			},
+		},
+	}
+	require.Nil(t, rescreenChanges(false, stats))
+	require.Equal(t, changedEntities{"SDNs": {"306": true, "21206": true}}, rescreenChanges(true, stats))
+
+	// SDNs which weren't compared to a previous refresh are re-screened in full
+	require.Nil(t, rescreenChanges(true, &DownloadStats{}))
+
+	// nothing changed
+	stats = &DownloadStats{changes: map[string]*ListChanges{"SDNs": {}}}
+	require.Equal(t, changedEntities{"SDNs": {}}, rescreenChanges(true, stats))
+}
+
+func TestSearchAsync__rescreenWatchIncremental(t *testing.T) {
+	companyRepo := createTestCompanyRepository(t)
+	defer companyRepo.close()
+	customerRepo := createTestCustomerRepository(t)
+	defer customerRepo.close()
+	watchRepo := createTestWatchRepository(t)
+	defer watchRepo.close()
+
+	watches := []watch{
+		{id: "12345", customerID: "306", webhook: "https://example.com"},
+		{id: "23456", customerName: "BANCO NACIONAL DE CUBA", webhook: "https://example.com"},
+	}
+	for _, w := range watches {
+		// the customer changed
+		body, _, err := customerSearcher.rescreenWatch(w, changedEntities{"SDNs": {"306": true}}, companyRepo, customerRepo, watchRepo)
+		require.NoError(t, err)
+		require.NotNil(t, body)
+
+		// other SDNs changed
+		body, _, err = customerSearcher.rescreenWatch(w, changedEntities{"SDNs": {"21206": true}}, companyRepo, customerRepo, watchRepo)
+		require.NoError(t, err)
+		require.Nil(t, body)
+	}
+
+	// changed SDNs which don't match the name closely enough
+	w := watch{id: "34567", customerName: "JOHN SMITH", webhook: "https://example.com"}
+	body, _, err := customerSearcher.rescreenWatch(w, changedEntities{"SDNs": {"306": true}}, companyRepo, customerRepo, watchRepo)
+	require.NoError(t, err)
+	require.Nil(t, body)
+}
+
+func TestSearchAsync__rescreenWatchMinMatch(t *testing.T) {
+	companyRepo := createTestCompanyRepository(t)
+	defer companyRepo.close()
+	customerRepo := createTestCustomerRepository(t)
+	defer customerRepo.close()
+	watchRepo := createTestWatchRepository(t)
+	defer watchRepo.close()
+
+	// the default threshold is above the watch's best match
+	w := watch{id: "12345", customerName: "JOHN SMITH", webhook: "https://example.com"}
+	body, _, err := customerSearcher.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.NoError(t, err)
+	require.Nil(t, body)
+
+	w.minMatch = 0.20
+	body, _, err = customerSearcher.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.NoError(t, err)
+	require.NotNil(t, body)
+
+	// the watch's threshold is above its best match
+	w.minMatch = 0.99
+	body, _, err = customerSearcher.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.NoError(t, err)
+	require.Nil(t, body)
+}
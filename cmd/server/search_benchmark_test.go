@@ -34,7 +34,7 @@ func BenchmarkSearch__BISEntities(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		searcher.TopBISEntities(10, 0.0, randomName())
+		searcher.TopBISEntities(10, 0.0, randomName(), filterRequest{})
 	}
 }
 
@@ -109,7 +109,7 @@ func BenchmarkSearch__SSIs(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		searcher.TopSSIs(10, 0.0, randomName())
+		searcher.TopSSIs(10, 0.0, randomName(), filterRequest{})
 	}
 }
 
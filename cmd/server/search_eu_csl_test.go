@@ -23,7 +23,7 @@ func TestSearch__EU_CSL(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search/eu-csl?name=Saddam%20Hussien", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, eu_cslSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, eu_cslSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -19,6 +19,11 @@ type Result[T any] struct {
 	precomputedAlts []string
 }
 
+// record returns the list record behind the result
+func (e *Result[T]) record() interface{} {
+	return &e.Data
+}
+
 func (e Result[T]) MarshalJSON() ([]byte, error) {
 	// Due to a problem with embedding type parameters we have to dig into
 	// the parameterized type fields and include them in one object.
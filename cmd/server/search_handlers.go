@@ -6,6 +6,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"math"
 	"net/http"
 	"net/url"
@@ -16,7 +17,10 @@ import (
 
 	moovhttp "github.com/moov-io/base/http"
 	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/snapshot"
 	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/ftm"
+	"github.com/moov-io/watchman/pkg/pep"
 
 	"github.com/go-kit/kit/metrics/prometheus"
 	"github.com/gorilla/mux"
@@ -33,12 +37,16 @@ var (
 )
 
 // TODO: modify existing search endpoint with additional eu info and add an eu only endpoint
-func addSearchRoutes(logger log.Logger, r *mux.Router, searcher *searcher) {
+func addSearchRoutes(logger log.Logger, r *mux.Router, searcher *searcher, alertRepo alertRepository) {
 	r.Methods("GET").Path("/search").HandlerFunc(search(logger, searcher))
 	r.Methods("GET").Path("/search/us-csl").HandlerFunc(searchUSCSL(logger, searcher))
 	r.Methods("GET").Path("/search/eu-csl").HandlerFunc(searchEUCSL(logger, searcher))
 	r.Methods("GET").Path("/search/uk-csl").HandlerFunc(searchUKCSL(logger, searcher))
+	r.Methods("GET").Path("/search/pep").HandlerFunc(searchPEP(logger, searcher))
 	r.Methods("POST").Path("/search/batch").HandlerFunc(internal.SearchBatch(logger))
+	if alertRepo != nil {
+		r.Methods("POST").Path("/search/alerts").HandlerFunc(createSearchAlerts(logger, searcher, alertRepo))
+	}
 }
 
 func extractSearchLimit(r *http.Request) int {
@@ -92,6 +100,17 @@ func search(logger log.Logger, searcher *searcher) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w = wrapResponseWriter(logger, w, r)
 
+		// Search a historical snapshot instead of the current data
+		searcher, err := searcher.atSnapshot(strings.TrimSpace(r.URL.Query().Get("snapshot")))
+		if err != nil {
+			if errors.Is(err, snapshot.ErrNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			moovhttp.Problem(w, err)
+			return
+		}
+
 		// Search over all fields
 		if q := strings.TrimSpace(r.URL.Query().Get("q")); q != "" {
 			searchViaQ(searcher, q)(w, r)
@@ -162,8 +181,16 @@ type searchResponse struct {
 	// UK Sanctions List
 	UKSanctionsList []*Result[csl.UKSanctionsListRecord] `json:"ukSanctionsList"`
 
+	// Politically Exposed Persons
+	PoliticallyExposedPersons []*Result[pep.PEP] `json:"politicallyExposedPersons"`
+
+	// FollowTheMoney entities
+	FtMEntities []*Result[ftm.Entity] `json:"ftmEntities"`
+
 	// Metadata
-	RefreshedAt time.Time `json:"refreshedAt"`
+	RefreshedAt time.Time                `json:"refreshedAt"`
+	Freshness   map[string]ListFreshness `json:"freshness,omitempty"`
+	Snapshot    string                   `json:"snapshot,omitempty"`
 }
 
 func buildAddressCompares(req addressSearchRequest) []func(*Address) *item {
@@ -196,8 +223,11 @@ func searchByAddress(searcher *searcher, req addressSearchRequest) http.HandlerF
 			return
 		}
 
+		refreshedAt, freshness, snapshotID := searcher.metadata()
 		resp := searchResponse{
-			RefreshedAt: searcher.lastRefreshedAt,
+			RefreshedAt: refreshedAt,
+			Freshness:   freshness,
+			Snapshot:    snapshotID,
 		}
 		limit := extractSearchLimit(r)
 		minMatch := extractSearchMinMatch(r)
@@ -281,38 +311,38 @@ var (
 
 	// Consolidated Screening List Results
 	cslGatherings = []searchGather{
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.BISEntities = s.TopBISEntities(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.BISEntities = s.TopBISEntities(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.MilitaryEndUsers = s.TopMEUs(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.MilitaryEndUsers = s.TopMEUs(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.SectoralSanctions = s.TopSSIs(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.SectoralSanctions = s.TopSSIs(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.Unverified = s.TopUVLs(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.Unverified = s.TopUVLs(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.NonproliferationSanctions = s.TopISNs(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.NonproliferationSanctions = s.TopISNs(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.ForeignSanctionsEvaders = s.TopFSEs(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.ForeignSanctionsEvaders = s.TopFSEs(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.PalestinianLegislativeCouncil = s.TopPLCs(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.PalestinianLegislativeCouncil = s.TopPLCs(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.CaptaList = s.TopCAPs(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.CaptaList = s.TopCAPs(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.ITARDebarred = s.TopDTCs(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.ITARDebarred = s.TopDTCs(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.NonSDNChineseMilitaryIndustrialComplex = s.TopCMICs(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.NonSDNChineseMilitaryIndustrialComplex = s.TopCMICs(limit, minMatch, name, filters)
 		},
-		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
-			resp.NonSDNMenuBasedSanctionsList = s.TopNS_MBS(limit, minMatch, name)
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.NonSDNMenuBasedSanctionsList = s.TopNS_MBS(limit, minMatch, name, filters)
 		},
 	}
 
@@ -333,7 +363,21 @@ var (
 		},
 	}
 
-	allGatherings = append(append(append(baseGatherings, cslGatherings...), euGatherings...), ukGatherings...)
+	// politically exposed persons
+	pepGatherings = []searchGather{
+		func(s *searcher, filters filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.PoliticallyExposedPersons = s.TopPEPs(limit, minMatch, name, filters.pepStatus)
+		},
+	}
+
+	// imported followthemoney entities
+	ftmGatherings = []searchGather{
+		func(s *searcher, _ filterRequest, limit int, minMatch float64, name string, resp *searchResponse) {
+			resp.FtMEntities = s.TopFtMEntities(limit, minMatch, name)
+		},
+	}
+
+	allGatherings = append(append(append(append(append(baseGatherings, cslGatherings...), euGatherings...), ukGatherings...), pepGatherings...), ftmGatherings...)
 )
 
 func buildFullSearchResponse(searcher *searcher, filters filterRequest, limit int, minMatch float64, name string) *searchResponse {
@@ -341,8 +385,11 @@ func buildFullSearchResponse(searcher *searcher, filters filterRequest, limit in
 }
 
 func buildFullSearchResponseWith(searcher *searcher, searchGatherings []searchGather, filters filterRequest, limit int, minMatch float64, name string) *searchResponse {
+	refreshedAt, freshness, snapshotID := searcher.metadata()
 	resp := searchResponse{
-		RefreshedAt: searcher.lastRefreshedAt,
+		RefreshedAt: refreshedAt,
+		Freshness:   freshness,
+		Snapshot:    snapshotID,
 	}
 	var wg sync.WaitGroup
 	wg.Add(len(searchGatherings))
@@ -353,6 +400,9 @@ func buildFullSearchResponseWith(searcher *searcher, searchGatherings []searchGa
 		}(i)
 	}
 	wg.Wait()
+
+	mergeUKResults(&resp)
+
 	return &resp
 }
 
@@ -366,8 +416,11 @@ func searchViaAddressAndName(searcher *searcher, name string, req addressSearchR
 
 		limit, minMatch := extractSearchLimit(r), extractSearchMinMatch(r)
 
+		refreshedAt, freshness, snapshotID := searcher.metadata()
 		resp := &searchResponse{
-			RefreshedAt: searcher.lastRefreshedAt,
+			RefreshedAt: refreshedAt,
+			Freshness:   freshness,
+			Snapshot:    snapshotID,
 		}
 
 		resp.SDNs = searcher.TopSDNs(limit, minMatch, name, keepSDN(buildFilterRequest(r.URL)))
@@ -410,9 +463,12 @@ func searchByRemarksID(searcher *searcher, id string) http.HandlerFunc {
 
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
+		refreshedAt, freshness, snapshotID := searcher.metadata()
 		json.NewEncoder(w).Encode(&searchResponse{
 			SDNs:        sdns,
-			RefreshedAt: searcher.lastRefreshedAt,
+			RefreshedAt: refreshedAt,
+			Freshness:   freshness,
+			Snapshot:    snapshotID,
 		})
 	}
 }
@@ -427,9 +483,10 @@ func searchByName(searcher *searcher, nameSlug string) http.HandlerFunc {
 
 		limit := extractSearchLimit(r)
 		minMatch := extractSearchMinMatch(r)
+		filters := buildFilterRequest(r.URL)
 
 		// Grab the SDN's and then filter any out based on query params
-		sdns := searcher.TopSDNs(limit, minMatch, nameSlug, keepSDN(buildFilterRequest(r.URL)))
+		sdns := searcher.TopSDNs(limit, minMatch, nameSlug, keepSDN(filters))
 
 		// record Prometheus metrics
 		if len(sdns) > 0 {
@@ -438,25 +495,35 @@ func searchByName(searcher *searcher, nameSlug string) http.HandlerFunc {
 			matchHist.With("type", "name").Observe(0.0)
 		}
 
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(&searchResponse{
+		refreshedAt, freshness, snapshotID := searcher.metadata()
+		resp := &searchResponse{
 			// OFAC
 			SDNs:              sdns,
 			AltNames:          searcher.TopAltNames(limit, minMatch, nameSlug),
-			SectoralSanctions: searcher.TopSSIs(limit, minMatch, nameSlug),
+			SectoralSanctions: searcher.TopSSIs(limit, minMatch, nameSlug, filters),
 			// BIS
 			DeniedPersons: searcher.TopDPs(limit, minMatch, nameSlug),
-			BISEntities:   searcher.TopBISEntities(limit, minMatch, nameSlug),
+			BISEntities:   searcher.TopBISEntities(limit, minMatch, nameSlug, filters),
 			// EUCSL
 			EUCSL: searcher.TopEUCSL(limit, minMatch, nameSlug),
 			// UKCSL
 			UKCSL: searcher.TopUKCSL(limit, minMatch, nameSlug),
 			// UKSanctionsList
 			UKSanctionsList: searcher.TopUKSanctionsList(limit, minMatch, nameSlug),
+			// PEP
+			PoliticallyExposedPersons: searcher.TopPEPs(limit, minMatch, nameSlug, filters.pepStatus),
+			// FtM
+			FtMEntities: searcher.TopFtMEntities(limit, minMatch, nameSlug),
 			// Metadata
-			RefreshedAt: searcher.lastRefreshedAt,
-		})
+			RefreshedAt: refreshedAt,
+			Freshness:   freshness,
+			Snapshot:    snapshotID,
+		}
+		mergeUKResults(resp)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(resp)
 	}
 }
 
@@ -481,9 +548,12 @@ func searchByAltName(searcher *searcher, altSlug string) http.HandlerFunc {
 
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
+		refreshedAt, freshness, snapshotID := searcher.metadata()
 		json.NewEncoder(w).Encode(&searchResponse{
 			AltNames:    alts,
-			RefreshedAt: searcher.lastRefreshedAt,
+			RefreshedAt: refreshedAt,
+			Freshness:   freshness,
+			Snapshot:    snapshotID,
 		})
 	}
 }
@@ -26,7 +26,7 @@ func TestSearch__Address(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search?address=ibex+house+minories&limit=1", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, addressSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, addressSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -67,7 +67,7 @@ func TestSearch__AddressCountry(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search?country=united+kingdom&limit=1", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, addressSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, addressSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -85,7 +85,7 @@ func TestSearch__AddressMulti(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search?address=ibex+house&country=united+kingdom&limit=1", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, addressSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, addressSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -103,7 +103,7 @@ func TestSearch__AddressProvidence(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search?address=ibex+house&country=united+kingdom&providence=london+ec3n+1DY&limit=1", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, addressSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, addressSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -121,7 +121,7 @@ func TestSearch__AddressCity(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search?address=ibex+house&country=united+kingdom&city=london+ec3n+1DY&limit=1", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, addressSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, addressSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -139,7 +139,7 @@ func TestSearch__AddressState(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search?address=ibex+house&country=united+kingdom&state=london+ec3n+1DY&limit=1", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, addressSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, addressSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -185,7 +185,7 @@ func TestSearch__NameAndAddress(t *testing.T) {
 	}, nil, noLogPipeliner)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, s)
+	addSearchRoutes(log.NewNopLogger(), router, s, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -241,7 +241,7 @@ func TestSearch__NameAndAltName(t *testing.T) {
 	s.BISEntities = bisEntitySearcher.BISEntities
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, s)
+	addSearchRoutes(log.NewNopLogger(), router, s, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -300,7 +300,7 @@ func TestSearch__Name(t *testing.T) {
 	combinedSearcher.DPs = dplSearcher.DPs
 	combinedSearcher.BISEntities = bisEntitySearcher.BISEntities
 
-	addSearchRoutes(log.NewNopLogger(), router, combinedSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, combinedSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -350,7 +350,7 @@ func TestSearch__AltName(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search?altName=SOGO+KENKYUSHO&limit=1", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, altSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, altSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -378,7 +378,7 @@ func TestSearch__ID(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search?id=5892464&limit=2", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, idSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, idSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
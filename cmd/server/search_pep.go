@@ -0,0 +1,66 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/pep"
+)
+
+// search Politically Exposed Persons
+func searchPEP(logger log.Logger, searcher *searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+		requestID := moovhttp.GetRequestID(r)
+
+		limit := extractSearchLimit(r)
+		filters := buildFilterRequest(r.URL)
+		minMatch := extractSearchMinMatch(r)
+
+		name := r.URL.Query().Get("name")
+		resp := buildFullSearchResponseWith(searcher, pepGatherings, filters, limit, minMatch, name)
+
+		logger.Info().With(log.Fields{
+			"name":      log.String(name),
+			"requestID": log.String(requestID),
+		}).Log("performing PEP search")
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// TopPEPs searches Politically Exposed Persons by Name and Alias.
+//
+// status optionally limits results to people currently (or formerly) holding a position.
+func (s *searcher) TopPEPs(limit int, minMatch float64, name string, status pep.Status) []*Result[pep.PEP] {
+	s.RLock()
+	defer s.RUnlock()
+
+	s.Gate.Start()
+	defer s.Gate.Done()
+
+	return topResults[pep.PEP](limit, minMatch, name, filterPEPs(s.PEPs, status, time.Now()))
+}
+
+// filterPEPs returns the PEPs whose status matches. All PEPs are returned if status is empty.
+func filterPEPs(peps []*Result[pep.PEP], status pep.Status, now time.Time) []*Result[pep.PEP] {
+	if status == "" {
+		return peps
+	}
+	var out []*Result[pep.PEP]
+	for i := range peps {
+		if peps[i].Data.Status(now) == status {
+			out = append(out, peps[i])
+		}
+	}
+	return out
+}
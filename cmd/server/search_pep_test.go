@@ -0,0 +1,72 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/pep"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func createPEPSearcher(t *testing.T) *searcher {
+	t.Helper()
+
+	peps, err := pep.ReadFile(filepath.Join("..", "..", "test", "testdata", "pep.json"))
+	require.NoError(t, err)
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.PEPs = precomputeCSLEntities[pep.PEP](peps, noLogPipeliner)
+	return s
+}
+
+func TestSearch__PEP(t *testing.T) {
+	s := createPEPSearcher(t)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/search/pep?name=jane+example&limit=1", nil)
+
+	router := mux.NewRouter()
+	addSearchRoutes(log.NewNopLogger(), router, s, nil)
+	router.ServeHTTP(w, req)
+	w.Flush()
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var wrapper struct {
+		PEPs []struct {
+			pep.PEP
+			Match float64 `json:"match"`
+		} `json:"politicallyExposedPersons"`
+	}
+	err := json.NewDecoder(w.Body).Decode(&wrapper)
+	require.NoError(t, err)
+
+	require.Len(t, wrapper.PEPs, 1)
+	require.Equal(t, "Q1001", wrapper.PEPs[0].EntityID)
+	require.Equal(t, "Member of Parliament", wrapper.PEPs[0].Positions[0].Name)
+	require.InDelta(t, 1.0, wrapper.PEPs[0].Match, 0.001)
+}
+
+func TestSearch__PEPStatus(t *testing.T) {
+	s := createPEPSearcher(t)
+
+	resp := buildFullSearchResponse(s, filterRequest{pepStatus: pep.StatusFormer}, 10, 0.0, "pedro muestra")
+	require.Len(t, resp.PoliticallyExposedPersons, 1)
+	require.Equal(t, "Q1002", resp.PoliticallyExposedPersons[0].Data.EntityID)
+
+	resp = buildFullSearchResponse(s, filterRequest{pepStatus: pep.StatusActive}, 10, 0.0, "pedro muestra")
+	require.Len(t, resp.PoliticallyExposedPersons, 2)
+	for i := range resp.PoliticallyExposedPersons {
+		require.NotEqual(t, "Q1002", resp.PoliticallyExposedPersons[i].Data.EntityID)
+	}
+}
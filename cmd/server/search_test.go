@@ -240,10 +240,12 @@ func init() {
 			Name:          "BEKTAS, Halis",
 			Addresses:     nil,
 			SourceListURL: "https://bit.ly/1QWTIfE",
-			Citizenships:  "CH",
-			DatesOfBirth:  "1966-02-13",
 			SourceInfoURL: "http://bit.ly/1N1docf",
 			IDs:           []string{"CH, X0906223, Passport"},
+			Details: csl.Details{
+				Citizenships: []string{"CH"},
+				DatesOfBirth: []string{"1966-02-13"},
+			},
 		},
 	}, noLogPipeliner)
 	plcSearcher.PLCs = precomputeCSLEntities[csl.PLC]([]*csl.PLC{
@@ -257,9 +259,10 @@ func init() {
 			Remarks:        "HAMAS - Der al-Balah",
 			SourceListURL:  "https://bit.ly/1QWTIfE",
 			AlternateNames: []string{"SALAMEH, Salem Ahmad Abdel Hadi"},
-			DatesOfBirth:   "1951",
-			PlacesOfBirth:  "",
 			SourceInfoURL:  "http://bit.ly/2tjOLpx",
+			Details: csl.Details{
+				DatesOfBirth: []string{"1951"},
+			},
 		},
 	}, noLogPipeliner)
 	capSearcher.CAPs = precomputeCSLEntities[csl.CAP]([]*csl.CAP{
@@ -6,7 +6,10 @@ package main
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
 
 	moovhttp "github.com/moov-io/base/http"
 	"github.com/moov-io/base/log"
@@ -58,3 +61,33 @@ func (s *searcher) TopUKSanctionsList(limit int, minMatch float64, name string)
 
 	return topResults[csl.UKSanctionsListRecord](limit, minMatch, name, s.UKSanctionsList)
 }
+
+// mergeUKResults drops UK CSL hits for designations which were also found on the UK Sanctions List.
+// OFSI's consolidated list and the UK Sanctions List are linked by the OFSI Group ID, so each
+// person or entity is reported once with the better of the two match scores.
+func mergeUKResults(resp *searchResponse) {
+	if len(resp.UKCSL) == 0 || len(resp.UKSanctionsList) == 0 {
+		return
+	}
+
+	byGroupID := make(map[string]*Result[csl.UKSanctionsListRecord])
+	for _, res := range resp.UKSanctionsList {
+		if res.Data.OFSIGroupID != "" {
+			byGroupID[res.Data.OFSIGroupID] = res
+		}
+	}
+
+	var ukCSL []*Result[csl.UKCSLRecord]
+	for _, res := range resp.UKCSL {
+		if linked, exists := byGroupID[strconv.Itoa(res.Data.GroupID)]; exists {
+			linked.match = math.Max(linked.match, res.match)
+			continue
+		}
+		ukCSL = append(ukCSL, res)
+	}
+	resp.UKCSL = ukCSL
+
+	sort.SliceStable(resp.UKSanctionsList, func(i, j int) bool {
+		return resp.UKSanctionsList[i].match > resp.UKSanctionsList[j].match
+	})
+}
@@ -22,7 +22,7 @@ func TestSearch_UK_CSL(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search/uk-csl?name=%27ABD%20AL-NASIR", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, uk_cslSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, uk_cslSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -45,7 +45,7 @@ func TestSearch_UK_SanctionsList(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search/uk-csl?name=HAJI%20KHAIRULLAH%20HAJI%20SATTAR%20MONEY%20EXCHANGE", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, uk_sanctionsListSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, uk_sanctionsListSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -62,3 +62,27 @@ func TestSearch_UK_SanctionsList(t *testing.T) {
 
 	require.Equal(t, "AFG0001", wrapper.UKSanctionsList[0].UniqueID)
 }
+
+func TestSearch_mergeUKResults(t *testing.T) {
+	resp := &searchResponse{
+		UKCSL: []*Result[csl.UKCSLRecord]{
+			{Data: csl.UKCSLRecord{GroupID: 12703, Names: []string{"HAJI KHAIRULLAH HAJI SATTAR MONEY EXCHANGE"}}, match: 0.98},
+			{Data: csl.UKCSLRecord{GroupID: 13720, Names: []string{"'ABD AL-NASIR"}}, match: 0.91},
+		},
+		UKSanctionsList: []*Result[csl.UKSanctionsListRecord]{
+			{Data: csl.UKSanctionsListRecord{UniqueID: "RUS0001", OFSIGroupID: "14001"}, match: 0.95},
+			{Data: csl.UKSanctionsListRecord{UniqueID: "AFG0001", OFSIGroupID: "12703"}, match: 0.90},
+		},
+	}
+	mergeUKResults(resp)
+
+	// the linked UK CSL hit is dropped and only the unlinked one remains
+	require.Len(t, resp.UKCSL, 1)
+	require.Equal(t, 13720, resp.UKCSL[0].Data.GroupID)
+
+	// the UK Sanctions List hit keeps the better score and is re-sorted
+	require.Len(t, resp.UKSanctionsList, 2)
+	require.Equal(t, "AFG0001", resp.UKSanctionsList[0].Data.UniqueID)
+	require.InDelta(t, 0.98, resp.UKSanctionsList[0].match, 0.001)
+	require.Equal(t, "RUS0001", resp.UKSanctionsList[1].Data.UniqueID)
+}
@@ -86,6 +86,15 @@ func precomputeCSLEntities[T any](items []*T, pipe *pipeliner) []*Result[T] {
 				}
 			}
 		}
+		// Some records (e.g. FtM entities) expose their alternate names through a method
+		if v, ok := any(item).(interface{ AlternateNames() []string }); ok {
+			alts := v.AlternateNames()
+			for j := range alts {
+				alt := &Name{Processed: alts[j]}
+				pipe.Do(alt)
+				altNames = append(altNames, alt.Processed)
+			}
+		}
 
 		out[i] = &Result[T]{
 			Data:            *item,
@@ -98,122 +107,122 @@ func precomputeCSLEntities[T any](items []*T, pipe *pipeliner) []*Result[T] {
 }
 
 // TopBISEntities searches BIS Entity List records by name and alias
-func (s *searcher) TopBISEntities(limit int, minMatch float64, name string) []*Result[csl.EL] {
+func (s *searcher) TopBISEntities(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.EL] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start() // TODO(adam): This used to be on a pre-record gate, so this may have different perf metrics
 	defer s.Gate.Done()
 
-	return topResults[csl.EL](limit, minMatch, name, s.BISEntities)
+	return topResults[csl.EL](limit, minMatch, name, filterCSL(s.BISEntities, filters))
 }
 
 // TopMEUs searches Military End User records by name and alias
-func (s *searcher) TopMEUs(limit int, minMatch float64, name string) []*Result[csl.MEU] {
+func (s *searcher) TopMEUs(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.MEU] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.MEU](limit, minMatch, name, s.MilitaryEndUsers)
+	return topResults[csl.MEU](limit, minMatch, name, filterCSL(s.MilitaryEndUsers, filters))
 }
 
 // TopSSIs searches Sectoral Sanctions records by Name and Alias
-func (s *searcher) TopSSIs(limit int, minMatch float64, name string) []*Result[csl.SSI] {
+func (s *searcher) TopSSIs(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.SSI] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.SSI](limit, minMatch, name, s.SSIs)
+	return topResults[csl.SSI](limit, minMatch, name, filterCSL(s.SSIs, filters))
 }
 
 // TopUVLs search Unverified Lists records by Name and Alias
-func (s *searcher) TopUVLs(limit int, minMatch float64, name string) []*Result[csl.UVL] {
+func (s *searcher) TopUVLs(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.UVL] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.UVL](limit, minMatch, name, s.UVLs)
+	return topResults[csl.UVL](limit, minMatch, name, filterCSL(s.UVLs, filters))
 }
 
 // TopISNs searches Nonproliferation Sanctions records by Name and Alias
-func (s *searcher) TopISNs(limit int, minMatch float64, name string) []*Result[csl.ISN] {
+func (s *searcher) TopISNs(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.ISN] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.ISN](limit, minMatch, name, s.ISNs)
+	return topResults[csl.ISN](limit, minMatch, name, filterCSL(s.ISNs, filters))
 }
 
 // TopFSEs searches Foreign Sanctions Evaders records by Name and Alias
-func (s *searcher) TopFSEs(limit int, minMatch float64, name string) []*Result[csl.FSE] {
+func (s *searcher) TopFSEs(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.FSE] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.FSE](limit, minMatch, name, s.FSEs)
+	return topResults[csl.FSE](limit, minMatch, name, filterCSL(s.FSEs, filters))
 }
 
 // TopPLCs searches Palestinian Legislative Council records by Name and Alias
-func (s *searcher) TopPLCs(limit int, minMatch float64, name string) []*Result[csl.PLC] {
+func (s *searcher) TopPLCs(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.PLC] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.PLC](limit, minMatch, name, s.PLCs)
+	return topResults[csl.PLC](limit, minMatch, name, filterCSL(s.PLCs, filters))
 }
 
 // TopCAPs searches the CAPTA list by Name and Alias
-func (s *searcher) TopCAPs(limit int, minMatch float64, name string) []*Result[csl.CAP] {
+func (s *searcher) TopCAPs(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.CAP] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.CAP](limit, minMatch, name, s.CAPs)
+	return topResults[csl.CAP](limit, minMatch, name, filterCSL(s.CAPs, filters))
 }
 
 // TopDTCs searches the ITAR Debarred list by Name and Alias
-func (s *searcher) TopDTCs(limit int, minMatch float64, name string) []*Result[csl.DTC] {
+func (s *searcher) TopDTCs(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.DTC] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.DTC](limit, minMatch, name, s.DTCs)
+	return topResults[csl.DTC](limit, minMatch, name, filterCSL(s.DTCs, filters))
 }
 
 // TopCMICs searches the Non-SDN Chinese Military Industrial Complex list by Name and Alias
-func (s *searcher) TopCMICs(limit int, minMatch float64, name string) []*Result[csl.CMIC] {
+func (s *searcher) TopCMICs(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.CMIC] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.CMIC](limit, minMatch, name, s.CMICs)
+	return topResults[csl.CMIC](limit, minMatch, name, filterCSL(s.CMICs, filters))
 }
 
 // TopNS_MBS searches the Non-SDN Menu Based Sanctions list by Name and Alias
-func (s *searcher) TopNS_MBS(limit int, minMatch float64, name string) []*Result[csl.NS_MBS] {
+func (s *searcher) TopNS_MBS(limit int, minMatch float64, name string, filters filterRequest) []*Result[csl.NS_MBS] {
 	s.RLock()
 	defer s.RUnlock()
 
 	s.Gate.Start()
 	defer s.Gate.Done()
 
-	return topResults[csl.NS_MBS](limit, minMatch, name, s.NS_MBSs)
+	return topResults[csl.NS_MBS](limit, minMatch, name, filterCSL(s.NS_MBSs, filters))
 }
@@ -25,7 +25,7 @@ func TestSearch_US_CSL(t *testing.T) {
 	req := httptest.NewRequest("GET", "/search/us-csl?name=Khan&limit=1", nil)
 
 	router := mux.NewRouter()
-	addSearchRoutes(log.NewNopLogger(), router, isnSearcher)
+	addSearchRoutes(log.NewNopLogger(), router, isnSearcher, nil)
 	router.ServeHTTP(w, req)
 	w.Flush()
 
@@ -44,7 +44,7 @@ func TestSearch_US_CSL(t *testing.T) {
 }
 
 func TestSearcher_TopBISEntities(t *testing.T) {
-	els := bisEntitySearcher.TopBISEntities(1, 0.00, "Khan")
+	els := bisEntitySearcher.TopBISEntities(1, 0.00, "Khan", filterRequest{})
 	if len(els) == 0 {
 		t.Fatal("empty ELs")
 	}
@@ -58,7 +58,7 @@ func TestSearcher_TopBISEntities(t *testing.T) {
 }
 
 func TestSearcher_TopBISEntities_AltName(t *testing.T) {
-	els := bisEntitySearcher.TopBISEntities(1, 0.00, "Luqman Sehreci.")
+	els := bisEntitySearcher.TopBISEntities(1, 0.00, "Luqman Sehreci.", filterRequest{})
 	if len(els) == 0 {
 		t.Fatal("empty ELs")
 	}
@@ -71,7 +71,7 @@ func TestSearcher_TopBISEntities_AltName(t *testing.T) {
 }
 
 func TestSearcher_TopMEUs(t *testing.T) {
-	meus := meuSearcher.TopMEUs(1, 0.00, "China Gas")
+	meus := meuSearcher.TopMEUs(1, 0.00, "China Gas", filterRequest{})
 	require.Len(t, meus, 1)
 
 	require.Equal(t, "d54346ef81802673c1b1daeb2ca8bd5d13755abd", meus[0].Data.EntityID)
@@ -79,7 +79,7 @@ func TestSearcher_TopMEUs(t *testing.T) {
 }
 
 func TestSearcher_TopSSIs(t *testing.T) {
-	ssis := ssiSearcher.TopSSIs(1, 0.00, "ROSOBORONEKSPORT")
+	ssis := ssiSearcher.TopSSIs(1, 0.00, "ROSOBORONEKSPORT", filterRequest{})
 	if len(ssis) == 0 {
 		t.Fatal("empty SSIs")
 	}
@@ -93,7 +93,7 @@ func TestSearcher_TopSSIs(t *testing.T) {
 }
 
 func TestSearcher_TopSSIs_limit(t *testing.T) {
-	ssis := ssiSearcher.TopSSIs(2, 0.00, "SPECIALIZED DEPOSITORY")
+	ssis := ssiSearcher.TopSSIs(2, 0.00, "SPECIALIZED DEPOSITORY", filterRequest{})
 	if len(ssis) != 2 {
 		t.Fatalf("Expected 2 results, found %d", len(ssis))
 	}
@@ -103,7 +103,7 @@ func TestSearcher_TopSSIs_limit(t *testing.T) {
 }
 
 func TestSearcher_TopSSIs_reportAltNameWeight(t *testing.T) {
-	ssis := ssiSearcher.TopSSIs(1, 0.00, "KENKYUSHO")
+	ssis := ssiSearcher.TopSSIs(1, 0.00, "KENKYUSHO", filterRequest{})
 	if len(ssis) == 0 {
 		t.Fatal("empty SSIs")
 	}
@@ -116,7 +116,7 @@ func TestSearcher_TopSSIs_reportAltNameWeight(t *testing.T) {
 }
 
 func TestSearcher_TopISNs(t *testing.T) {
-	isns := isnSearcher.TopISNs(1, 0.00, "Abdul Qadeer K")
+	isns := isnSearcher.TopISNs(1, 0.00, "Abdul Qadeer K", filterRequest{})
 	require.Len(t, isns, 1)
 
 	isn := isns[0]
@@ -125,7 +125,7 @@ func TestSearcher_TopISNs(t *testing.T) {
 }
 
 func TestSearcher_TopUVLs(t *testing.T) {
-	uvls := uvlSearcher.TopUVLs(1, 0.00, "Atlas Sanatgaran")
+	uvls := uvlSearcher.TopUVLs(1, 0.00, "Atlas Sanatgaran", filterRequest{})
 	require.Len(t, uvls, 1)
 
 	uvl := uvls[0]
@@ -134,7 +134,7 @@ func TestSearcher_TopUVLs(t *testing.T) {
 }
 
 func TestSearcher_TopFSEs(t *testing.T) {
-	fses := fseSearcher.TopFSEs(1, 0.00, "BEKTAS, Halis")
+	fses := fseSearcher.TopFSEs(1, 0.00, "BEKTAS, Halis", filterRequest{})
 	require.Len(t, fses, 1)
 
 	fse := fses[0]
@@ -143,7 +143,7 @@ func TestSearcher_TopFSEs(t *testing.T) {
 }
 
 func TestSearcher_TopPLCs(t *testing.T) {
-	plcs := plcSearcher.TopPLCs(1, 0.00, "SALAMEH, Salem")
+	plcs := plcSearcher.TopPLCs(1, 0.00, "SALAMEH, Salem", filterRequest{})
 	require.Len(t, plcs, 1)
 
 	plc := plcs[0]
@@ -152,7 +152,7 @@ func TestSearcher_TopPLCs(t *testing.T) {
 }
 
 func TestSearcher_TopCAPs(t *testing.T) {
-	caps := capSearcher.TopCAPs(1, 0.00, "BM BANK PUBLIC JOINT STOCK COMPANY")
+	caps := capSearcher.TopCAPs(1, 0.00, "BM BANK PUBLIC JOINT STOCK COMPANY", filterRequest{})
 	require.Len(t, caps, 1)
 
 	cap := caps[0]
@@ -161,7 +161,7 @@ func TestSearcher_TopCAPs(t *testing.T) {
 }
 
 func TestSearcher_TopDTCs(t *testing.T) {
-	dtcs := dtcSearcher.TopDTCs(1, 0.00, "Yasmin Ahmed")
+	dtcs := dtcSearcher.TopDTCs(1, 0.00, "Yasmin Ahmed", filterRequest{})
 	require.Len(t, dtcs, 1)
 
 	dtc := dtcs[0]
@@ -170,7 +170,7 @@ func TestSearcher_TopDTCs(t *testing.T) {
 }
 
 func TestSearcher_TopCMICs(t *testing.T) {
-	cmics := cmicSearcher.TopCMICs(1, 0.00, "PROVEN HONOUR CAPITAL LIMITED")
+	cmics := cmicSearcher.TopCMICs(1, 0.00, "PROVEN HONOUR CAPITAL LIMITED", filterRequest{})
 	require.Len(t, cmics, 1)
 
 	cmic := cmics[0]
@@ -179,7 +179,7 @@ func TestSearcher_TopCMICs(t *testing.T) {
 }
 
 func TestSearcher_TopNSMBSs(t *testing.T) {
-	ns_mbss := ns_mbsSearcher.TopNS_MBS(1, 0.00, "GAZPROMBANK JOINT STOCK COMPANY")
+	ns_mbss := ns_mbsSearcher.TopNS_MBS(1, 0.00, "GAZPROMBANK JOINT STOCK COMPANY", filterRequest{})
 	require.Len(t, ns_mbss, 1)
 
 	ns_mbs := ns_mbss[0]
@@ -0,0 +1,359 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/snapshot"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	snapshotsPath = "/snapshots"
+	snapshotPath  = "/snapshots/{snapshotID}"
+
+	defaultSnapshotRetentionAge = 90 * 24 * time.Hour
+)
+
+var errSnapshotsDisabled = errors.New("snapshots are not enabled, set SNAPSHOT_DIRECTORY")
+
+// snapshotRepository keeps the files and parsed records of each refresh as a snapshot
+// and loads searchers from them.
+type snapshotRepository struct {
+	store     *snapshot.Store
+	retention snapshot.Retention
+	logger    log.Logger
+
+	// loaded is the searcher of the most recently searched snapshot
+	mu       sync.Mutex
+	loaded   *searcher
+	loadedID string
+}
+
+// newSnapshotRepository reads the snapshot config from environment variables. Snapshots
+// are disabled (and nil is returned) when SNAPSHOT_DIRECTORY is unset.
+func newSnapshotRepository(logger log.Logger) (*snapshotRepository, error) {
+	dir := os.Getenv("SNAPSHOT_DIRECTORY")
+	if dir == "" {
+		return nil, nil
+	}
+	store, err := snapshot.New(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := snapshot.Retention{
+		MaxAge: defaultSnapshotRetentionAge,
+	}
+	if v := os.Getenv("SNAPSHOT_RETENTION_COUNT"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid SNAPSHOT_RETENTION_COUNT=%q", v)
+		}
+		retention.MaxCount = n
+	}
+	if v := os.Getenv("SNAPSHOT_RETENTION_AGE"); v != "" {
+		if v == "off" {
+			retention.MaxAge = 0
+		} else {
+			dur, err := time.ParseDuration(v)
+			if err != nil || dur <= 0 {
+				return nil, fmt.Errorf("invalid SNAPSHOT_RETENTION_AGE=%q", v)
+			}
+			retention.MaxAge = dur
+		}
+	}
+	logger.Logf("storing snapshots in %s, keeping %d for %v (zero is unlimited)", dir, retention.MaxCount, retention.MaxAge)
+
+	return &snapshotRepository{
+		store:     store,
+		retention: retention,
+		logger:    logger,
+	}, nil
+}
+
+// save stores a refresh as a snapshot and prunes the snapshots outside of retention.
+// Problems are logged as they don't affect the refreshed data.
+func (r *snapshotRepository) save(stats *DownloadStats, parsed *parsedLists, files sourceFiles) {
+	snap, err := r.write(stats, parsed, files)
+	if err != nil {
+		r.logger.Error().LogErrorf("problem saving snapshot: %v", err)
+		return
+	}
+	r.logger.Info().With(log.Fields{
+		"snapshot": log.String(snap.ID),
+	}).Logf("saved snapshot %s", snap.ID)
+
+	removed, err := r.store.Prune(r.retention, time.Now())
+	if err != nil {
+		r.logger.Error().LogErrorf("problem pruning snapshots: %v", err)
+	}
+	if len(removed) > 0 {
+		r.logger.Logf("removed %d snapshots outside of retention", len(removed))
+	}
+}
+
+func (r *snapshotRepository) write(stats *DownloadStats, parsed *parsedLists, files sourceFiles) (*snapshot.Snapshot, error) {
+	var snap *snapshot.Snapshot
+	err := r.store.Write(func() error {
+		prev, err := r.store.Latest()
+		if err != nil {
+			return err
+		}
+
+		records := parsed.records()
+		lists := make(map[string]snapshot.List)
+		for _, name := range allLists {
+			if !parsed.has(name) || parsed.errors[name] != nil {
+				// lists which weren't read or kept their previous data keep their previous files
+				if prev != nil {
+					if list, exists := prev.Lists[name]; exists {
+						lists[name] = list
+					}
+				}
+				continue
+			}
+
+			list := snapshot.List{
+				RefreshedAt: stats.RefreshedAt,
+			}
+			if at, exists := parsed.refreshedAt[name]; exists {
+				list.RefreshedAt = at
+			}
+			for _, path := range files[name] {
+				file, err := r.store.PutFile(path)
+				if err != nil {
+					return err
+				}
+				list.Files = append(list.Files, file)
+			}
+			file, err := r.putRecords(name, records[name])
+			if err != nil {
+				return err
+			}
+			list.Parsed = &file
+			lists[name] = list
+		}
+		snap, err = r.store.Save(stats.RefreshedAt, lists)
+		return err
+	})
+	return snap, err
+}
+
+// putRecords stores a list's records as gzipped JSON
+func (r *snapshotRepository) putRecords(name string, records any) (snapshot.File, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(records); err != nil {
+		return snapshot.File{}, fmt.Errorf("encoding %s records: %v", name, err)
+	}
+	if err := gz.Close(); err != nil {
+		return snapshot.File{}, fmt.Errorf("encoding %s records: %v", name, err)
+	}
+	return r.store.Put(name+".json.gz", &buf)
+}
+
+func (r *snapshotRepository) readRecords(file snapshot.File, records any) error {
+	fd, err := r.store.Open(file)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	gz, err := gzip.NewReader(fd)
+	if err != nil {
+		return fmt.Errorf("reading %s: %v", file.Name, err)
+	}
+	defer gz.Close()
+
+	if err := json.NewDecoder(gz).Decode(records); err != nil {
+		return fmt.Errorf("reading %s: %v", file.Name, err)
+	}
+	return nil
+}
+
+// read returns the parsed records of each list in a snapshot
+func (r *snapshotRepository) read(snap *snapshot.Snapshot) (*parsedLists, error) {
+	parsed := newParsedLists()
+	records := parsed.records()
+	for _, name := range allLists {
+		list, exists := snap.Lists[name]
+		if !exists {
+			continue
+		}
+		parsed.lists = append(parsed.lists, name)
+		parsed.refreshedAt[name] = list.RefreshedAt
+
+		if list.Parsed == nil {
+			parsed.errors[name] = fmt.Errorf("snapshot %s has no %s records", snap.ID, name)
+			continue
+		}
+		if err := r.readRecords(*list.Parsed, records[name]); err != nil {
+			return nil, fmt.Errorf("snapshot %s: %v", snap.ID, err)
+		}
+	}
+	return parsed, nil
+}
+
+// load indexes the lists of a snapshot into s
+func (r *snapshotRepository) load(s *searcher, id string) (*DownloadStats, error) {
+	snap, err := r.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := r.read(snap)
+	if err != nil {
+		return nil, err
+	}
+
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	stats, err := s.indexLists(parsed, snap.CreatedAt)
+	if err != nil {
+		return stats, err
+	}
+	s.Lock()
+	s.snapshotID = snap.ID
+	s.Unlock()
+	return stats, nil
+}
+
+// searcher returns a searcher over the lists of a snapshot. The most recently searched
+// snapshot is kept in memory.
+func (r *snapshotRepository) searcher(base *searcher, id string) (*searcher, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.loaded != nil && r.loadedID == id {
+		return r.loaded, nil
+	}
+
+	s := &searcher{
+		logger: base.logger,
+		pipe:   base.pipe,
+		Gate:   base.Gate,
+	}
+	if _, err := r.load(s, id); err != nil {
+		return nil, err
+	}
+	r.loaded, r.loadedID = s, id
+	return s, nil
+}
+
+// atSnapshot returns a searcher over the lists of a snapshot, or s when id is empty
+// or s is already serving that snapshot.
+func (s *searcher) atSnapshot(id string) (*searcher, error) {
+	if _, _, current := s.metadata(); id == "" || id == current {
+		return s, nil
+	}
+	if s.snapshots == nil {
+		return nil, errSnapshotsDisabled
+	}
+	return s.snapshots.searcher(s, id)
+}
+
+// snapshotSummary describes a snapshot without the digests of its files
+type snapshotSummary struct {
+	ID        string                         `json:"id"`
+	CreatedAt time.Time                      `json:"createdAt"`
+	Size      int64                          `json:"size"`
+	Lists     map[string]snapshotListSummary `json:"lists"`
+}
+
+type snapshotListSummary struct {
+	RefreshedAt time.Time `json:"refreshedAt"`
+	Files       []string  `json:"files"`
+}
+
+func summarizeSnapshot(snap *snapshot.Snapshot) snapshotSummary {
+	out := snapshotSummary{
+		ID:        snap.ID,
+		CreatedAt: snap.CreatedAt,
+		Size:      snap.Size(),
+		Lists:     make(map[string]snapshotListSummary),
+	}
+	for name, list := range snap.Lists {
+		summary := snapshotListSummary{
+			RefreshedAt: list.RefreshedAt,
+		}
+		for _, f := range list.Files {
+			summary.Files = append(summary.Files, f.Name)
+		}
+		out.Lists[name] = summary
+	}
+	return out
+}
+
+func listSnapshotsHandler(logger log.Logger, repo *snapshotRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if repo == nil {
+			moovhttp.Problem(w, errSnapshotsDisabled)
+			return
+		}
+		snaps, err := repo.store.List()
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		if requestID := moovhttp.GetRequestID(r); requestID != "" {
+			logger.Info().With(log.Fields{
+				"requestID": log.String(requestID),
+			}).Log("list snapshots")
+		}
+
+		out := make([]snapshotSummary, 0, len(snaps))
+		for _, snap := range snaps {
+			out = append(out, summarizeSnapshot(snap))
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(out)
+	}
+}
+
+func getSnapshotHandler(logger log.Logger, repo *snapshotRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if repo == nil {
+			moovhttp.Problem(w, errSnapshotsDisabled)
+			return
+		}
+		snap, err := repo.store.Get(mux.Vars(r)["snapshotID"])
+		if err != nil {
+			if errors.Is(err, snapshot.ErrNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		if requestID := moovhttp.GetRequestID(r); requestID != "" {
+			logger.Info().With(log.Fields{
+				"requestID": log.String(requestID),
+				"snapshot":  log.String(snap.ID),
+			}).Log("get snapshot")
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(snap)
+	}
+}
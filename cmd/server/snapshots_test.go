@@ -0,0 +1,211 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/snapshot"
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ofac"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSnapshotRepository(t *testing.T) *snapshotRepository {
+	t.Helper()
+
+	t.Setenv("SNAPSHOT_DIRECTORY", t.TempDir())
+	t.Setenv("SNAPSHOT_RETENTION_AGE", "off") // keep the test snapshots from 2023
+	repo, err := newSnapshotRepository(log.NewNopLogger())
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+	return repo
+}
+
+func testParsedLists(t *testing.T, sdnName string) (*parsedLists, sourceFiles) {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sdn.csv")
+	require.NoError(t, os.WriteFile(path, []byte(sdnName), 0600))
+
+	parsed := newParsedLists()
+	parsed.OFAC = &ofac.Results{
+		SDNs: []*ofac.SDN{{EntityID: "1", SDNName: sdnName, SDNType: "individual"}},
+	}
+	parsed.read("SDNs", nil)
+	parsed.DPL = []*dpl.DPL{{Name: "AL NASER WINGS AIRLINES"}}
+	parsed.read("DPs", nil)
+	parsed.CSL = &csl.CSL{
+		ELs: []*csl.EL{{ID: "2", Name: "Mohammad Jan Khan Mangal"}},
+	}
+	parsed.read("CSL", nil)
+
+	files := make(sourceFiles)
+	files.add("SDNs", path)
+	return parsed, files
+}
+
+func TestSnapshots__config(t *testing.T) {
+	t.Setenv("SNAPSHOT_DIRECTORY", "")
+	repo, err := newSnapshotRepository(log.NewNopLogger())
+	require.NoError(t, err)
+	require.Nil(t, repo)
+
+	t.Setenv("SNAPSHOT_DIRECTORY", t.TempDir())
+	t.Setenv("SNAPSHOT_RETENTION_COUNT", "10")
+	t.Setenv("SNAPSHOT_RETENTION_AGE", "720h")
+	repo, err = newSnapshotRepository(log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, snapshot.Retention{MaxCount: 10, MaxAge: 720 * time.Hour}, repo.retention)
+
+	t.Setenv("SNAPSHOT_RETENTION_AGE", "off")
+	repo, err = newSnapshotRepository(log.NewNopLogger())
+	require.NoError(t, err)
+	require.Zero(t, repo.retention.MaxAge)
+
+	t.Setenv("SNAPSHOT_RETENTION_COUNT", "many")
+	_, err = newSnapshotRepository(log.NewNopLogger())
+	require.Error(t, err)
+}
+
+func TestSnapshots__saveAndLoad(t *testing.T) {
+	repo := createTestSnapshotRepository(t)
+
+	first := time.Date(2023, time.March, 3, 9, 0, 0, 0, time.UTC)
+	parsed, files := testParsedLists(t, "NICOLAS MADURO MOROS")
+	repo.save(&DownloadStats{RefreshedAt: first}, parsed, files)
+
+	// the next refresh fails to read the CSL, which keeps its files from the first snapshot
+	second := first.Add(12 * time.Hour)
+	parsed, files = testParsedLists(t, "VLADIMIR PUTIN")
+	parsed.CSL = nil
+	parsed.errors["CSL"] = errors.New("download: connection refused")
+	repo.save(&DownloadStats{RefreshedAt: second}, parsed, files)
+
+	snaps, err := repo.store.List()
+	require.NoError(t, err)
+	require.Len(t, snaps, 2)
+	require.Equal(t, "20230303T210000Z", snaps[0].ID)
+	require.Equal(t, first, snaps[0].Lists["CSL"].RefreshedAt)
+	require.Equal(t, second, snaps[0].Lists["SDNs"].RefreshedAt)
+	require.Len(t, snaps[0].Lists["SDNs"].Files, 1)
+	require.Equal(t, "sdn.csv", snaps[0].Lists["SDNs"].Files[0].Name)
+
+	// search the first snapshot
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.snapshots = repo
+	historical, err := s.atSnapshot("20230303T090000Z")
+	require.NoError(t, err)
+	require.Equal(t, "20230303T090000Z", historical.snapshotID)
+	require.Len(t, historical.SDNs, 1)
+	require.Equal(t, "NICOLAS MADURO MOROS", historical.SDNs[0].SDNName)
+	require.Len(t, historical.DPs, 1)
+	require.Len(t, historical.BISEntities, 1)
+	require.Equal(t, first, historical.freshness["SDNs"].RefreshedAt)
+
+	// the loaded snapshot is reused
+	again, err := s.atSnapshot("20230303T090000Z")
+	require.NoError(t, err)
+	require.True(t, historical == again)
+
+	// start from the second snapshot, which carried the CSL forward
+	stats, err := repo.load(s, "20230303T210000Z")
+	require.NoError(t, err)
+	require.Empty(t, stats.Errors)
+	require.Equal(t, 1, stats.BISEntities)
+	require.Equal(t, "VLADIMIR PUTIN", s.SDNs[0].SDNName)
+	require.Equal(t, first, s.freshness["CSL"].RefreshedAt)
+	require.Equal(t, second, s.freshness["SDNs"].RefreshedAt)
+
+	current, err := s.atSnapshot("")
+	require.NoError(t, err)
+	require.True(t, s == current)
+
+	_, err = s.atSnapshot("20230101T000000Z")
+	require.ErrorIs(t, err, snapshot.ErrNotFound)
+
+	s.snapshots = nil
+	_, err = s.atSnapshot("20230303T090000Z")
+	require.ErrorIs(t, err, errSnapshotsDisabled)
+}
+
+func TestSnapshots__handlers(t *testing.T) {
+	repo := createTestSnapshotRepository(t)
+
+	parsed, files := testParsedLists(t, "NICOLAS MADURO MOROS")
+	repo.save(&DownloadStats{RefreshedAt: time.Date(2023, time.March, 3, 9, 0, 0, 0, time.UTC)}, parsed, files)
+
+	router := mux.NewRouter()
+	router.HandleFunc(snapshotsPath, listSnapshotsHandler(log.NewNopLogger(), repo))
+	router.HandleFunc(snapshotPath, getSnapshotHandler(log.NewNopLogger(), repo))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/snapshots", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var summaries []snapshotSummary
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&summaries))
+	require.Len(t, summaries, 1)
+	require.Equal(t, "20230303T090000Z", summaries[0].ID)
+	require.Equal(t, []string{"sdn.csv"}, summaries[0].Lists["SDNs"].Files)
+	require.Greater(t, summaries[0].Size, int64(0))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/snapshots/20230303T090000Z", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var snap snapshot.Snapshot
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&snap))
+	require.NotNil(t, snap.Lists["SDNs"].Parsed)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/snapshots/20230101T000000Z", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// snapshots are disabled
+	w = httptest.NewRecorder()
+	listSnapshotsHandler(log.NewNopLogger(), nil)(w, httptest.NewRequest("GET", "/snapshots", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSnapshots__search(t *testing.T) {
+	repo := createTestSnapshotRepository(t)
+
+	parsed, files := testParsedLists(t, "NICOLAS MADURO MOROS")
+	repo.save(&DownloadStats{RefreshedAt: time.Date(2023, time.March, 3, 9, 0, 0, 0, time.UTC)}, parsed, files)
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.snapshots = repo
+
+	router := mux.NewRouter()
+	addSearchRoutes(log.NewNopLogger(), router, s, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/search?name=maduro&snapshot=20230303T090000Z&limit=1", nil))
+	w.Flush()
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp searchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, "20230303T090000Z", resp.Snapshot)
+	require.Len(t, resp.SDNs, 1)
+	require.Equal(t, "1", resp.SDNs[0].EntityID)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/search?name=maduro&snapshot=20230101T000000Z", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
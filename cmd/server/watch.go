@@ -33,6 +33,12 @@ func getWatchID(w http.ResponseWriter, r *http.Request) string {
 type watchRequest struct {
 	AuthToken string `json:"authToken"`
 	Webhook   string `json:"webhook"`
+
+	// Lists, MinMatch, NotifyPolicy and Notifier are optional and saved after the watch is added
+	Lists        []string     `json:"lists"`
+	MinMatch     float64      `json:"minMatch"`
+	NotifyPolicy notifyPolicy `json:"notifyPolicy"`
+	Notifier     notifierType `json:"notifier"`
 }
 
 // watchRepository holds information about each company and/or customer that another service wants notifications
@@ -52,6 +58,21 @@ type watchRepository interface {
 	addCustomerNameWatch(name string, webhook string, authToken string) (string, error)
 	removeCustomerWatch(customerID string, watchID string) error
 	removeCustomerNameWatch(watchID string) error
+
+	// Management
+	listWatches(filter watchFilter) ([]*watchInfo, error)
+	getWatch(watchID string) (*watchInfo, error)
+	updateWatch(watchID string, update watchUpdate) (*watchInfo, error)
+
+	// Signing secrets
+	watchExists(watchID string) (bool, error)
+	rotateSigningSecret(watchID string, previousExpiresAt time.Time) (*watchSecret, error)
+	expirePreviousSigningSecret(watchID string) error
+	getSigningSecrets(watchID string, now time.Time) ([]string, error)
+
+	// Notified results
+	getWatchResults(watchID string) (*watchResults, error)
+	saveWatchResults(watchID string, results []watchResult, notifiedAt time.Time) error
 }
 
 type sqliteWatchRepository struct {
@@ -217,6 +238,18 @@ type watch struct {
 	companyID, companyName   string
 	webhook                  string
 	authToken                string
+
+	// lists are screened instead of watchDefaultLists when set
+	lists []string
+
+	// minMatch overrides the lowest match a name watch is notified of when non-zero
+	minMatch float64
+
+	// policy decides when the watch is notified, watchNotifyPolicy is used when it's empty
+	policy notifyPolicy
+
+	// notifier sends the watch's notifications, watchNotifier is used when it's empty
+	notifier notifierType
 }
 
 type watchCursor struct {
@@ -271,7 +304,7 @@ func (cur *watchCursor) Next() ([]watch, error) {
 }
 
 func (cur *watchCursor) getCompanyBatch(limit int) ([]watch, error) {
-	query := `select id, company_id, webhook, auth_token, created_at from company_watches where created_at > ? and deleted_at is null order by created_at asc limit ?`
+	query := `select id, company_id, webhook, auth_token, lists, notify_policy, notifier, created_at from company_watches where created_at > ? and deleted_at is null order by created_at asc limit ?`
 	stmt, err := cur.db.Prepare(query)
 	if err != nil {
 		return nil, err
@@ -289,8 +322,12 @@ func (cur *watchCursor) getCompanyBatch(limit int) ([]watch, error) {
 	var watches []watch
 	for rows.Next() {
 		var createdAt time.Time
+		var lists, policy, notifier sql.NullString
 		var watch watch
-		if err := rows.Scan(&watch.id, &watch.companyID, &watch.webhook, &watch.authToken, &createdAt); err == nil {
+		if err := rows.Scan(&watch.id, &watch.companyID, &watch.webhook, &watch.authToken, &lists, &policy, &notifier, &createdAt); err == nil {
+			watch.lists = splitWatchLists(lists.String)
+			watch.policy = notifyPolicy(policy.String)
+			watch.notifier = notifierType(notifier.String)
 			watches = append(watches, watch)
 		}
 		if createdAt.After(max) {
@@ -304,7 +341,7 @@ func (cur *watchCursor) getCompanyBatch(limit int) ([]watch, error) {
 }
 
 func (cur *watchCursor) getCompanyNameBatch(limit int) ([]watch, error) {
-	query := `select id, name, webhook, auth_token, created_at from company_name_watches where created_at > ? and deleted_at is null order by created_at asc limit ?`
+	query := `select id, name, webhook, auth_token, lists, min_match, notify_policy, notifier, created_at from company_name_watches where created_at > ? and deleted_at is null order by created_at asc limit ?`
 	stmt, err := cur.db.Prepare(query)
 	if err != nil {
 		return nil, err
@@ -322,8 +359,14 @@ func (cur *watchCursor) getCompanyNameBatch(limit int) ([]watch, error) {
 	var watches []watch
 	for rows.Next() {
 		var createdAt time.Time
+		var lists, policy, notifier sql.NullString
+		var minMatch sql.NullFloat64
 		var watch watch
-		if err := rows.Scan(&watch.id, &watch.companyName, &watch.webhook, &watch.authToken, &createdAt); err == nil {
+		if err := rows.Scan(&watch.id, &watch.companyName, &watch.webhook, &watch.authToken, &lists, &minMatch, &policy, &notifier, &createdAt); err == nil {
+			watch.lists = splitWatchLists(lists.String)
+			watch.policy = notifyPolicy(policy.String)
+			watch.notifier = notifierType(notifier.String)
+			watch.minMatch = minMatch.Float64
 			watches = append(watches, watch)
 		}
 		if createdAt.After(max) {
@@ -337,7 +380,7 @@ func (cur *watchCursor) getCompanyNameBatch(limit int) ([]watch, error) {
 }
 
 func (cur *watchCursor) getCustomerBatch(limit int) ([]watch, error) {
-	query := `select id, customer_id, webhook, auth_token, created_at from customer_watches where created_at > ? and deleted_at is null order by created_at asc limit ?`
+	query := `select id, customer_id, webhook, auth_token, lists, notify_policy, notifier, created_at from customer_watches where created_at > ? and deleted_at is null order by created_at asc limit ?`
 	stmt, err := cur.db.Prepare(query)
 	if err != nil {
 		return nil, err
@@ -355,8 +398,12 @@ func (cur *watchCursor) getCustomerBatch(limit int) ([]watch, error) {
 	var watches []watch
 	for rows.Next() {
 		var createdAt time.Time
+		var lists, policy, notifier sql.NullString
 		var watch watch
-		if err := rows.Scan(&watch.id, &watch.customerID, &watch.webhook, &watch.authToken, &createdAt); err == nil {
+		if err := rows.Scan(&watch.id, &watch.customerID, &watch.webhook, &watch.authToken, &lists, &policy, &notifier, &createdAt); err == nil {
+			watch.lists = splitWatchLists(lists.String)
+			watch.policy = notifyPolicy(policy.String)
+			watch.notifier = notifierType(notifier.String)
 			watches = append(watches, watch)
 		}
 		if createdAt.After(max) {
@@ -370,7 +417,7 @@ func (cur *watchCursor) getCustomerBatch(limit int) ([]watch, error) {
 }
 
 func (cur *watchCursor) getCustomerNameBatch(limit int) ([]watch, error) {
-	query := `select id, name, webhook, auth_token, created_at from customer_name_watches where created_at > ? and deleted_at is null order by created_at asc limit ?`
+	query := `select id, name, webhook, auth_token, lists, min_match, notify_policy, notifier, created_at from customer_name_watches where created_at > ? and deleted_at is null order by created_at asc limit ?`
 	stmt, err := cur.db.Prepare(query)
 	if err != nil {
 		return nil, err
@@ -388,8 +435,14 @@ func (cur *watchCursor) getCustomerNameBatch(limit int) ([]watch, error) {
 	var watches []watch
 	for rows.Next() {
 		var createdAt time.Time
+		var lists, policy, notifier sql.NullString
+		var minMatch sql.NullFloat64
 		var watch watch
-		if err := rows.Scan(&watch.id, &watch.customerName, &watch.webhook, &watch.authToken, &createdAt); err == nil {
+		if err := rows.Scan(&watch.id, &watch.customerName, &watch.webhook, &watch.authToken, &lists, &minMatch, &policy, &notifier, &createdAt); err == nil {
+			watch.lists = splitWatchLists(lists.String)
+			watch.policy = notifyPolicy(policy.String)
+			watch.notifier = notifierType(notifier.String)
+			watch.minMatch = minMatch.Float64
 			watches = append(watches, watch)
 		}
 		if createdAt.After(max) {
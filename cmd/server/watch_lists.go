@@ -0,0 +1,334 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/ftm"
+	"github.com/moov-io/watchman/pkg/pep"
+)
+
+var (
+	// watchDefaultLists are the lists a watch is screened against when it hasn't selected any
+	watchDefaultLists = []string{"SDNs"}
+
+	// watchMinMatch is the lowest match a name watch is notified of when it's re-screened
+	// against the entire list and hasn't set its own minMatch. It defaults to watchRescreenMinMatch.
+	watchMinMatch = 0.90
+
+	// watchHitLimit is the most hits from each list included in a watch's webhook
+	watchHitLimit = 5
+)
+
+func init() {
+	if lists, err := readWatchLists(os.Getenv("WATCH_LISTS")); err == nil && len(lists) > 0 {
+		watchDefaultLists = lists
+	}
+	watchMinMatch = readWatchMinMatch(os.Getenv("WATCH_MIN_MATCH"), readRescreenMinMatch(os.Getenv("WATCH_RESCREEN_MIN_MATCH")))
+	watchHitLimit = readWatchHitLimit(os.Getenv("WATCH_MAX_HITS"))
+}
+
+// readWatchLists parses comma separated list names, which are matched case-insensitively against allLists
+func readWatchLists(str string) ([]string, error) {
+	if strings.TrimSpace(str) == "" {
+		return nil, nil
+	}
+	return validateWatchLists(strings.Split(str, ","))
+}
+
+// validateWatchLists returns the names of lists as they're written in allLists without duplicates
+func validateWatchLists(lists []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var out []string
+	for _, list := range lists {
+		list = strings.TrimSpace(list)
+		found := ""
+		for _, name := range allLists {
+			if strings.EqualFold(name, list) {
+				found = name
+				break
+			}
+		}
+		if found == "" {
+			return nil, fmt.Errorf("unknown list %q, expected one of %s", list, strings.Join(allLists, ", "))
+		}
+		if !seen[found] {
+			seen[found] = true
+			out = append(out, found)
+		}
+	}
+	return out, nil
+}
+
+// readWatchMinMatch parses WATCH_MIN_MATCH, returning rescreenMinMatch when it's unset or invalid
+func readWatchMinMatch(str string, rescreenMinMatch float64) float64 {
+	if str == "" {
+		return rescreenMinMatch
+	}
+	f, err := strconv.ParseFloat(str, 64)
+	if err == nil && f >= 0 && f <= 1 {
+		return f
+	}
+	return rescreenMinMatch
+}
+
+func readWatchHitLimit(str string) int {
+	if str == "" {
+		return watchHitLimit
+	}
+	n, _ := strconv.Atoi(str)
+	if n > 0 {
+		return n
+	}
+	return watchHitLimit
+}
+
+// watchLists returns the lists w is screened against
+func (w watch) watchLists() []string {
+	if len(w.lists) > 0 {
+		return w.lists
+	}
+	return watchDefaultLists
+}
+
+// idList returns the one list an ID watch looks its ID up on, since lists' IDs can collide
+func (w watch) idList() []string {
+	return w.watchLists()[:1]
+}
+
+// watchHit is an entity on one of the lists which matches a watch
+type watchHit struct {
+	// List is the name the watch selects the list by
+	List string `json:"list"`
+	// Source is the part of the list the entity is from, named as in search responses
+	Source string `json:"source"`
+	// EntityID is the entity's ID on the list, empty for lists without stable IDs
+	EntityID string      `json:"entityID,omitempty"`
+	Match    float64     `json:"match"`
+	Entity   interface{} `json:"entity"`
+}
+
+// watchSource searches one part of a list for the entities a watch matches
+type watchSource struct {
+	list, source string
+
+	// search returns the entities closest to name. When keep is non-nil only the entities
+	// it keeps are searched, which excludes every entity of sources without stable IDs.
+	search func(s *searcher, name, sdnType string, limit int, minMatch float64, keep func(id string) bool) []watchHit
+
+	// find returns the entity with id, it's nil for sources without stable IDs
+	find func(s *searcher, id string) *watchHit
+}
+
+var watchSources = []watchSource{
+	{
+		list:   "SDNs",
+		source: "SDNs",
+		search: func(s *searcher, name, sdnType string, limit int, minMatch float64, keep func(id string) bool) []watchHit {
+			keeper := keepSDN(filterRequest{sdnType: sdnType})
+			if keep != nil {
+				inner := keeper
+				keeper = func(sdn *SDN) bool {
+					return keep(sdn.EntityID) && inner(sdn)
+				}
+			}
+			var out []watchHit
+			for _, sdn := range s.TopSDNs(limit, minMatch, name, keeper) {
+				out = append(out, watchHit{List: "SDNs", Source: "SDNs", EntityID: sdn.EntityID, Match: sdn.match, Entity: sdn})
+			}
+			return out
+		},
+		find: func(s *searcher, id string) *watchHit {
+			s.RLock()
+			defer s.RUnlock()
+			for _, sdn := range s.SDNs {
+				if sdn.EntityID == id {
+					found := *sdn
+					found.match = 1.0
+					return &watchHit{List: "SDNs", Source: "SDNs", EntityID: id, Match: 1.0, Entity: &found}
+				}
+			}
+			return nil
+		},
+	},
+	{
+		list:   "DPs",
+		source: "deniedPersons",
+		search: func(s *searcher, name, _ string, limit int, minMatch float64, keep func(id string) bool) []watchHit {
+			if keep != nil {
+				return nil
+			}
+			var out []watchHit
+			for _, dp := range s.TopDPs(limit, minMatch, name) {
+				out = append(out, watchHit{List: "DPs", Source: "deniedPersons", Match: dp.match, Entity: dp})
+			}
+			return out
+		},
+	},
+	resultSource("CSL", "bisEntities", func(s *searcher) []*Result[csl.EL] { return s.BISEntities }, func(r csl.EL) string { return r.ID }, nil),
+	resultSource("CSL", "militaryEndUsers", func(s *searcher) []*Result[csl.MEU] { return s.MilitaryEndUsers }, func(r csl.MEU) string { return r.EntityID }, nil),
+	resultSource("CSL", "sectoralSanctions", func(s *searcher) []*Result[csl.SSI] { return s.SSIs }, func(r csl.SSI) string { return r.EntityID }, func(r csl.SSI) string { return r.Type }),
+	resultSource("CSL", "unverifiedCSL", func(s *searcher) []*Result[csl.UVL] { return s.UVLs }, func(r csl.UVL) string { return r.EntityID }, nil),
+	resultSource("CSL", "nonproliferationSanctions", func(s *searcher) []*Result[csl.ISN] { return s.ISNs }, func(r csl.ISN) string { return r.EntityID }, nil),
+	resultSource("CSL", "foreignSanctionsEvaders", func(s *searcher) []*Result[csl.FSE] { return s.FSEs }, func(r csl.FSE) string { return r.EntityID }, func(r csl.FSE) string { return r.Type }),
+	resultSource("CSL", "palestinianLegislativeCouncil", func(s *searcher) []*Result[csl.PLC] { return s.PLCs }, func(r csl.PLC) string { return r.EntityID }, func(r csl.PLC) string { return r.Type }),
+	resultSource("CSL", "captaList", func(s *searcher) []*Result[csl.CAP] { return s.CAPs }, func(r csl.CAP) string { return r.EntityID }, func(r csl.CAP) string { return r.Type }),
+	resultSource("CSL", "itarDebarred", func(s *searcher) []*Result[csl.DTC] { return s.DTCs }, func(r csl.DTC) string { return r.EntityID }, nil),
+	resultSource("CSL", "nonSDNChineseMilitaryIndustrialComplex", func(s *searcher) []*Result[csl.CMIC] { return s.CMICs }, func(r csl.CMIC) string { return r.EntityID }, func(r csl.CMIC) string { return r.Type }),
+	resultSource("CSL", "nonSDNMenuBasedSanctionsList", func(s *searcher) []*Result[csl.NS_MBS] { return s.NS_MBSs }, func(r csl.NS_MBS) string { return r.EntityID }, func(r csl.NS_MBS) string { return r.Type }),
+	resultSource("EUCSL", "euConsolidatedSanctionsList", func(s *searcher) []*Result[csl.EUCSLRecord] { return s.EUCSL }, func(r csl.EUCSLRecord) string { return strconv.Itoa(r.EntityLogicalID) }, func(r csl.EUCSLRecord) string { return r.EntitySubjectType }),
+	resultSource("UKCSL", "ukConsolidatedSanctionsList", func(s *searcher) []*Result[csl.UKCSLRecord] { return s.UKCSL }, func(r csl.UKCSLRecord) string { return strconv.Itoa(r.GroupID) }, func(r csl.UKCSLRecord) string { return r.GroupType }),
+	resultSource("UKSanctionsList", "ukSanctionsList", func(s *searcher) []*Result[csl.UKSanctionsListRecord] { return s.UKSanctionsList }, func(r csl.UKSanctionsListRecord) string { return r.UniqueID }, ukSanctionsListType),
+	resultSource("PEPs", "politicallyExposedPersons", func(s *searcher) []*Result[pep.PEP] { return s.PEPs }, func(r pep.PEP) string { return r.EntityID }, func(pep.PEP) string { return "individual" }),
+	resultSource("FtM", "ftmEntities", func(s *searcher) []*Result[ftm.Entity] { return s.FtMEntities }, func(r ftm.Entity) string { return r.ID }, func(r ftm.Entity) string { return r.Schema }),
+}
+
+// resultSource is a watchSource over records held as a []*Result[T] with the ID returned by id.
+// kind returns the type of a record (e.g. Individual, Entity, Vessel) for sources which carry one,
+// it's nil for sources without types.
+func resultSource[T any](list, source string, records func(s *searcher) []*Result[T], id func(T) string, kind func(T) string) watchSource {
+	return watchSource{
+		list:   list,
+		source: source,
+		search: func(s *searcher, name, sdnType string, limit int, minMatch float64, keep func(id string) bool) []watchHit {
+			s.RLock()
+			defer s.RUnlock()
+
+			s.Gate.Start()
+			defer s.Gate.Done()
+
+			data := records(s)
+			if keep != nil || (kind != nil && sdnType != "") {
+				var kept []*Result[T]
+				for _, r := range data {
+					if keep != nil && !keep(id(r.Data)) {
+						continue
+					}
+					if kind != nil && !matchesEntityType(kind(r.Data), sdnType) {
+						continue
+					}
+					kept = append(kept, r)
+				}
+				data = kept
+			}
+			var out []watchHit
+			for _, r := range topResults[T](limit, minMatch, name, data) {
+				out = append(out, watchHit{List: list, Source: source, EntityID: id(r.Data), Match: r.match, Entity: r})
+			}
+			return out
+		},
+		find: func(s *searcher, entityID string) *watchHit {
+			s.RLock()
+			defer s.RUnlock()
+			for _, r := range records(s) {
+				if id(r.Data) == entityID {
+					found := &Result[T]{Data: r.Data, match: 1.0}
+					return &watchHit{List: list, Source: source, EntityID: entityID, Match: 1.0, Entity: found}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// matchesEntityType returns if a record of kind is kept for a search of sdnType (individual or entity).
+// Records without a type are always kept.
+func matchesEntityType(kind, sdnType string) bool {
+	if kind == "" || sdnType == "" {
+		return true
+	}
+	switch strings.ToLower(kind) {
+	case "individual", "person":
+		return strings.EqualFold(sdnType, "individual")
+	case "entity", "enterprise", "company", "organization", "legalentity":
+		return strings.EqualFold(sdnType, "entity")
+	}
+	// vessels, aircraft, etc are neither
+	return false
+}
+
+func ukSanctionsListType(r csl.UKSanctionsListRecord) string {
+	if r.EntityType == nil {
+		return ""
+	}
+	return r.EntityType.String()
+}
+
+// changedEntities are the IDs of entities on each list which were added or modified by a refresh.
+// Lists which weren't compared to their previous refresh are missing.
+type changedEntities map[string]map[string]bool
+
+// keep returns if the entity with id on list changed
+func (c changedEntities) keep(list string) func(id string) bool {
+	if c == nil {
+		return nil
+	}
+	return func(id string) bool {
+		return c[list][id]
+	}
+}
+
+func (c changedEntities) count() int {
+	n := 0
+	for _, ids := range c {
+		n += len(ids)
+	}
+	return n
+}
+
+// searchWatchHits returns the entities on lists which match name at or above minMatch, best first
+func (s *searcher) searchWatchHits(lists []string, name, sdnType string, minMatch float64, changed changedEntities) []watchHit {
+	var out []watchHit
+	for _, src := range watchSources {
+		if !containsList(lists, src.list) {
+			continue
+		}
+		out = append(out, src.search(s, name, sdnType, watchHitLimit, minMatch, changed.keep(src.list))...)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Match > out[j].Match })
+	return out
+}
+
+// findWatchHits returns the entities on lists with id
+func (s *searcher) findWatchHits(lists []string, id string, changed changedEntities) []watchHit {
+	var out []watchHit
+	for _, src := range watchSources {
+		if src.find == nil || !containsList(lists, src.list) {
+			continue
+		}
+		if keep := changed.keep(src.list); keep != nil && !keep(id) {
+			continue
+		}
+		if hit := src.find(s, id); hit != nil {
+			out = append(out, *hit)
+		}
+	}
+	return out
+}
+
+// firstSDNHit returns the best OFAC SDN in hits, if any
+func firstSDNHit(hits []watchHit) *watchHit {
+	for i := range hits {
+		if hits[i].List == "SDNs" {
+			return &hits[i]
+		}
+	}
+	return nil
+}
+
+func containsList(lists []string, list string) bool {
+	for i := range lists {
+		if lists[i] == list {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestWatchListsSearcher returns a searcher holding both OFAC SDNs and the EU CSL
+func createTestWatchListsSearcher(t *testing.T) *searcher {
+	t.Helper()
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs = customerSearcher.SDNs
+	s.EUCSL = eu_cslSearcher.EUCSL
+	return s
+}
+
+func TestWatchLists__validate(t *testing.T) {
+	lists, err := validateWatchLists([]string{"sdns", " EUCSL", "SDNs"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"SDNs", "EUCSL"}, lists)
+
+	_, err = validateWatchLists([]string{"OFAC"})
+	require.ErrorContains(t, err, `unknown list "OFAC"`)
+
+	lists, err = readWatchLists("")
+	require.NoError(t, err)
+	require.Empty(t, lists)
+
+	require.Equal(t, 0.75, readWatchMinMatch("0.75", 0.9))
+	require.Equal(t, 0.9, readWatchMinMatch("2", 0.9))
+	require.Equal(t, 0.8, readWatchMinMatch("", 0.8))
+	require.Equal(t, 10, readWatchHitLimit("10"))
+	require.Equal(t, watchHitLimit, readWatchHitLimit("-1"))
+}
+
+func TestWatchLists__rescreenWatch(t *testing.T) {
+	s := createTestWatchListsSearcher(t)
+	companyRepo := createTestCompanyRepository(t)
+	defer companyRepo.close()
+	customerRepo := createTestCustomerRepository(t)
+	defer customerRepo.close()
+	watchRepo := createTestWatchRepository(t)
+	defer watchRepo.close()
+
+	decode := func(t *testing.T, body *bytes.Buffer) (Customer, []watchHit) {
+		t.Helper()
+		require.NotNil(t, body)
+
+		var out struct {
+			Customer
+			Hits []watchHit `json:"hits"`
+		}
+		require.NoError(t, json.NewDecoder(body).Decode(&out))
+		return out.Customer, out.Hits
+	}
+
+	// only OFAC SDNs are screened by default
+	w := watch{id: "12345", customerName: "Saddam Hussein Al-Tikriti", minMatch: 0.95}
+	body, _, err := s.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.NoError(t, err)
+	require.Nil(t, body)
+
+	w.lists = []string{"SDNs", "EUCSL"}
+	body, _, err = s.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.NoError(t, err)
+	customer, hits := decode(t, body)
+	require.Empty(t, customer.ID)
+	require.Len(t, hits, 1)
+	require.Equal(t, "EUCSL", hits[0].List)
+	require.Equal(t, "euConsolidatedSanctionsList", hits[0].Source)
+	require.Equal(t, "13", hits[0].EntityID)
+	require.InDelta(t, 1.0, hits[0].Match, 0.001)
+
+	// every qualifying hit is included with the best SDN as the customer
+	w = watch{id: "23456", customerName: "BANCO NACIONAL DE CUBA", lists: []string{"SDNs", "EUCSL"}, minMatch: 0.01}
+	body, _, err = s.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.NoError(t, err)
+	customer, hits = decode(t, body)
+	require.Equal(t, "306", customer.ID)
+	require.Len(t, hits, 2)
+	require.Equal(t, "SDNs", hits[0].List)
+	require.Greater(t, hits[0].Match, hits[1].Match)
+
+	// ID watches look up entities on the list they select
+	w = watch{id: "34567", customerID: "13", lists: []string{"EUCSL"}}
+	body, _, err = s.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.NoError(t, err)
+	customer, hits = decode(t, body)
+	require.Empty(t, customer.ID)
+	require.Len(t, hits, 1)
+	require.Equal(t, "EUCSL", hits[0].List)
+
+	// and only the first of several lists, so an SDN's ID doesn't match another list's entity
+	w.lists = []string{"SDNs", "EUCSL"}
+	_, _, err = s.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.ErrorContains(t, err, "not found")
+
+	w.lists = nil
+	_, _, err = s.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.ErrorContains(t, err, "not found")
+
+	// company watches skip people on lists with entity types
+	w = watch{id: "45678", companyName: "Saddam Hussein Al-Tikriti", lists: []string{"EUCSL"}, minMatch: 0.01}
+	body, _, err = s.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.NoError(t, err)
+	require.Nil(t, body)
+}
+
+func TestWatchLists__matchesEntityType(t *testing.T) {
+	require.True(t, matchesEntityType("Individual", "individual"))
+	require.True(t, matchesEntityType("person", "individual"))
+	require.True(t, matchesEntityType("enterprise", "entity"))
+	require.True(t, matchesEntityType("Company", "entity"))
+	require.True(t, matchesEntityType("", "entity"))
+	require.True(t, matchesEntityType("Vessel", ""))
+
+	require.False(t, matchesEntityType("Entity", "individual"))
+	require.False(t, matchesEntityType("Person", "entity"))
+	require.False(t, matchesEntityType("Ship", "entity"))
+}
+
+func TestWatchLists__rescreenWatchIncremental(t *testing.T) {
+	s := createTestWatchListsSearcher(t)
+	companyRepo := createTestCompanyRepository(t)
+	defer companyRepo.close()
+	customerRepo := createTestCustomerRepository(t)
+	defer customerRepo.close()
+	watchRepo := createTestWatchRepository(t)
+	defer watchRepo.close()
+
+	watches := []watch{
+		{id: "12345", customerID: "13", lists: []string{"EUCSL"}},
+		{id: "23456", customerName: "Saddam Hussein Al-Tikriti", lists: []string{"EUCSL"}},
+	}
+	for _, w := range watches {
+		body, _, err := s.rescreenWatch(w, changedEntities{"EUCSL": {"13": true}}, companyRepo, customerRepo, watchRepo)
+		require.NoError(t, err)
+		require.NotNil(t, body)
+
+		// other entities changed
+		body, _, err = s.rescreenWatch(w, changedEntities{"EUCSL": {"14": true}}, companyRepo, customerRepo, watchRepo)
+		require.NoError(t, err)
+		require.Nil(t, body)
+
+		// the same ID changed on a list the watch doesn't select
+		body, _, err = s.rescreenWatch(w, changedEntities{"SDNs": {"13": true}}, companyRepo, customerRepo, watchRepo)
+		require.NoError(t, err)
+		require.Nil(t, body)
+	}
+}
+
+func TestWatchLists__routes(t *testing.T) {
+	repo := createTestWatchRepository(t)
+	defer repo.close()
+
+	router := mux.NewRouter()
+	addCustomerRoutes(log.NewNopLogger(), router, nil, nil, repo)
+	addWatchRoutes(log.NewNopLogger(), router, repo, nil)
+
+	w := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"webhook":"https://example.com","authToken":"authToken","lists":["sdns","eucsl"],"minMatch":0.9}`)
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac/customers/watch?name=foo", body))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var created customerWatchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+
+	info, err := repo.getWatch(created.WatchID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"SDNs", "EUCSL"}, info.Lists)
+	require.Equal(t, 0.9, info.MinMatch)
+
+	// the cursor reads the selected lists
+	batch, err := repo.getWatchesCursor(log.NewNopLogger(), 4).Next()
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	require.Equal(t, []string{"SDNs", "EUCSL"}, batch[0].lists)
+
+	// clearing the lists goes back to the default
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+created.WatchID, bytes.NewBufferString(`{"lists":[]}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+	require.Equal(t, watchDefaultLists, info.Lists)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+created.WatchID, bytes.NewBufferString(`{"lists":["OFAC"]}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// ID watches don't have a minMatch
+	w = httptest.NewRecorder()
+	body = bytes.NewBufferString(`{"webhook":"https://example.com","authToken":"authToken","minMatch":0.9}`)
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac/customers/306/watch", body))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// nor more than one list
+	w = httptest.NewRecorder()
+	body = bytes.NewBufferString(`{"webhook":"https://example.com","authToken":"authToken","lists":["SDNs","EUCSL"]}`)
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac/customers/306/watch", body))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "only select one list")
+
+	w = httptest.NewRecorder()
+	body = bytes.NewBufferString(`{"webhook":"https://example.com","authToken":"authToken","lists":["EUCSL"]}`)
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac/customers/306/watch", body))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+created.WatchID, bytes.NewBufferString(`{"lists":["SDNs","EUCSL"]}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "only select one list")
+}
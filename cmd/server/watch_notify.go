@@ -0,0 +1,226 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// notifyPolicy decides when a re-screened watch has its webhook called
+type notifyPolicy string
+
+const (
+	// notifyAlways calls the webhook after every refresh the watch has hits
+	notifyAlways notifyPolicy = "always"
+
+	// notifyOnChange calls the webhook when the watch's hits, or their match, differ from those it was last notified of
+	notifyOnChange notifyPolicy = "on-change"
+
+	// notifyOnNewHit calls the webhook when an entity the watch wasn't last notified of matches at or above its threshold
+	notifyOnNewHit notifyPolicy = "on-new-hit"
+)
+
+func (p notifyPolicy) valid() bool {
+	switch p {
+	case notifyAlways, notifyOnChange, notifyOnNewHit:
+		return true
+	}
+	return false
+}
+
+// watchNotifyPolicy is used for watches which haven't set their own notifyPolicy
+var watchNotifyPolicy = notifyAlways
+
+func init() {
+	watchNotifyPolicy = readNotifyPolicy(os.Getenv("WATCH_NOTIFY_POLICY"))
+}
+
+func readNotifyPolicy(str string) notifyPolicy {
+	if p := notifyPolicy(strings.ToLower(strings.TrimSpace(str))); p.valid() {
+		return p
+	}
+	return watchNotifyPolicy
+}
+
+// notifyPolicy returns the policy w is notified with
+func (w watch) notifyPolicy() notifyPolicy {
+	if w.policy != "" {
+		return w.policy
+	}
+	return watchNotifyPolicy
+}
+
+// watchResult is a hit as it's kept between notifications
+type watchResult struct {
+	List     string  `json:"list"`
+	Source   string  `json:"source"`
+	EntityID string  `json:"entityID,omitempty"`
+	Name     string  `json:"name,omitempty"`
+	Match    float64 `json:"match"`
+}
+
+// key identifies the entity across refreshes, entities without an ID are identified by their name
+func (r watchResult) key() string {
+	if r.EntityID != "" {
+		return r.Source + "/" + r.EntityID
+	}
+	return r.Source + "/name:" + r.Name
+}
+
+// watchResults are the hits a watch was last notified of
+type watchResults struct {
+	Results    []watchResult
+	NotifiedAt time.Time
+}
+
+func newWatchResults(hits []watchHit) []watchResult {
+	out := make([]watchResult, 0, len(hits))
+	for _, hit := range hits {
+		r := watchResult{List: hit.List, Source: hit.Source, EntityID: hit.EntityID, Match: hit.Match}
+		if dp, ok := hit.Entity.(*DP); ok && r.EntityID == "" && dp.DeniedPerson != nil {
+			r.Name = dp.DeniedPerson.Name
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// watchDiff is how a watch's hits differ from those it was last notified of
+type watchDiff struct {
+	Added   []watchResult       `json:"added"`
+	Removed []watchResult       `json:"removed"`
+	Changed []watchResultChange `json:"changed"`
+}
+
+// watchResultChange is a hit which is still found with a different match
+type watchResultChange struct {
+	watchResult
+	PreviousMatch float64 `json:"previousMatch"`
+}
+
+func (d watchDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// matchesDiffer ignores the rounding noise of re-computing a match
+func matchesDiffer(a, b float64) bool {
+	return math.Abs(a-b) >= 0.0001
+}
+
+// diffWatchResults compares current with previous. Incremental re-screening only finds the
+// changed entities, so nothing is removed and previous hits which weren't re-found are kept.
+//
+// The results to persist once the watch is notified are returned alongside the diff.
+func diffWatchResults(previous, current []watchResult, incremental bool) (watchDiff, []watchResult) {
+	diff := watchDiff{
+		Added:   []watchResult{},
+		Removed: []watchResult{},
+		Changed: []watchResultChange{},
+	}
+
+	before := make(map[string]watchResult, len(previous))
+	for _, r := range previous {
+		before[r.key()] = r
+	}
+	found := make(map[string]bool, len(current))
+	for _, r := range current {
+		found[r.key()] = true
+		prev, ok := before[r.key()]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, r)
+		case matchesDiffer(prev.Match, r.Match):
+			diff.Changed = append(diff.Changed, watchResultChange{watchResult: r, PreviousMatch: prev.Match})
+		}
+	}
+
+	results := append([]watchResult{}, current...)
+	for _, r := range previous {
+		if found[r.key()] {
+			continue
+		}
+		if incremental {
+			results = append(results, r)
+		} else {
+			diff.Removed = append(diff.Removed, r)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Match > results[j].Match })
+
+	return diff, results
+}
+
+// shouldNotify returns if a watch with policy is notified of diff. hasHits is false when
+// the watch no longer matches anything, which is only sent to watches notified of changes.
+func (p notifyPolicy) shouldNotify(hasHits bool, diff watchDiff) bool {
+	switch p {
+	case notifyOnChange:
+		return !diff.empty()
+	case notifyOnNewHit:
+		return len(diff.Added) > 0
+	default:
+		return hasHits
+	}
+}
+
+// Repository methods
+
+// getWatchResults returns the hits watchID was last notified of, or nil if it hasn't been notified
+func (r *sqliteWatchRepository) getWatchResults(watchID string) (*watchResults, error) {
+	var results sql.NullString
+	var notifiedAt sql.NullTime
+	err := r.db.QueryRow(`select results, notified_at from watch_results where watch_id = ?;`, watchID).Scan(&results, &notifiedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	out := &watchResults{NotifiedAt: notifiedAt.Time}
+	if results.String != "" {
+		if err := json.Unmarshal([]byte(results.String), &out.Results); err != nil {
+			return nil, fmt.Errorf("reading results of watch %s: %v", watchID, err)
+		}
+	}
+	return out, nil
+}
+
+// saveWatchResults replaces the hits watchID was last notified of
+func (r *sqliteWatchRepository) saveWatchResults(watchID string, results []watchResult, notifiedAt time.Time) error {
+	bs, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRow(`select count(*) from watch_results where watch_id = ?;`, watchID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists > 0 {
+		_, err = tx.Exec(`update watch_results set results = ?, notified_at = ? where watch_id = ?;`, string(bs), notifiedAt, watchID)
+	} else {
+		_, err = tx.Exec(`insert into watch_results (watch_id, results, notified_at) values (?, ?, ?);`, watchID, string(bs), notifiedAt)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
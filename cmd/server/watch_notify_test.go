@@ -0,0 +1,208 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchNotify__diff(t *testing.T) {
+	previous := []watchResult{
+		{List: "SDNs", Source: "SDNs", EntityID: "1", Match: 0.95},
+		{List: "SDNs", Source: "SDNs", EntityID: "2", Match: 0.91},
+		{List: "DPs", Source: "deniedPersons", Name: "JOHN DOE", Match: 0.92},
+	}
+	current := []watchResult{
+		{List: "SDNs", Source: "SDNs", EntityID: "1", Match: 0.95},
+		{List: "SDNs", Source: "SDNs", EntityID: "2", Match: 0.97},
+		{List: "EUCSL", Source: "euConsolidatedSanctionsList", EntityID: "1", Match: 0.93},
+	}
+
+	diff, results := diffWatchResults(previous, current, false)
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, "EUCSL", diff.Added[0].List)
+	require.Len(t, diff.Changed, 1)
+	require.Equal(t, "2", diff.Changed[0].EntityID)
+	require.Equal(t, 0.91, diff.Changed[0].PreviousMatch)
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, "JOHN DOE", diff.Removed[0].Name)
+	require.Len(t, results, 3)
+	require.Equal(t, 0.97, results[0].Match)
+
+	// incremental re-screening keeps what it didn't look at
+	diff, results = diffWatchResults(previous, current[2:], true)
+	require.Len(t, diff.Added, 1)
+	require.Empty(t, diff.Removed)
+	require.Len(t, results, 4)
+
+	diff, _ = diffWatchResults(current, current, false)
+	require.True(t, diff.empty())
+
+	require.True(t, notifyAlways.shouldNotify(true, diff))
+	require.False(t, notifyOnChange.shouldNotify(true, diff))
+	require.False(t, notifyOnNewHit.shouldNotify(true, watchDiff{Removed: previous}))
+	require.True(t, notifyOnChange.shouldNotify(false, watchDiff{Removed: previous}))
+
+	require.Equal(t, notifyOnChange, readNotifyPolicy("On-Change"))
+	require.Equal(t, watchNotifyPolicy, readNotifyPolicy("never"))
+}
+
+func TestWatchNotify__repository(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, repo *sqliteWatchRepository) {
+		watchID := base.ID()
+
+		results, err := repo.getWatchResults(watchID)
+		require.NoError(t, err)
+		require.Nil(t, results)
+
+		first := []watchResult{{List: "SDNs", Source: "SDNs", EntityID: "306", Match: 0.95}}
+		require.NoError(t, repo.saveWatchResults(watchID, first, time.Now()))
+
+		results, err = repo.getWatchResults(watchID)
+		require.NoError(t, err)
+		require.Equal(t, first, results.Results)
+
+		// results are replaced
+		require.NoError(t, repo.saveWatchResults(watchID, []watchResult{}, time.Now()))
+		results, err = repo.getWatchResults(watchID)
+		require.NoError(t, err)
+		require.NotNil(t, results)
+		require.Empty(t, results.Results)
+		require.False(t, results.NotifiedAt.IsZero())
+	}
+
+	// SQLite tests
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+	check(t, &sqliteWatchRepository{sqliteDB.DB, log.NewNopLogger()})
+
+	// MySQL tests
+	mysqlDB := database.TestMySQLConnection(t)
+	check(t, &sqliteWatchRepository{mysqlDB, log.NewNopLogger()})
+}
+
+func TestWatchNotify__rescreenWatch(t *testing.T) {
+	s := createTestWatchListsSearcher(t)
+	companyRepo := createTestCompanyRepository(t)
+	defer companyRepo.close()
+	customerRepo := createTestCustomerRepository(t)
+	defer customerRepo.close()
+	watchRepo := createTestWatchRepository(t)
+	defer watchRepo.close()
+
+	decode := func(t *testing.T, body *bytes.Buffer) watchNotification {
+		t.Helper()
+		require.NotNil(t, body)
+
+		var out watchNotification
+		require.NoError(t, json.NewDecoder(body).Decode(&out))
+		return out
+	}
+	rescreen := func(t *testing.T, w watch) (*bytes.Buffer, []watchResult) {
+		t.Helper()
+		body, results, err := s.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+		require.NoError(t, err)
+		if body != nil {
+			require.NoError(t, watchRepo.saveWatchResults(w.id, results, time.Now()))
+		}
+		return body, results
+	}
+
+	// the first notification adds every hit
+	w := watch{id: base.ID(), customerID: "306", policy: notifyOnChange}
+	body, results := rescreen(t, w)
+	note := decode(t, body)
+	require.Len(t, note.Hits, 1)
+	require.Len(t, note.Diff.Added, 1)
+	require.Equal(t, "306", results[0].EntityID)
+
+	// nothing changed
+	body, _ = rescreen(t, w)
+	require.Nil(t, body)
+
+	// always is notified of the same hits
+	w.policy = notifyAlways
+	body, _ = rescreen(t, w)
+	note = decode(t, body)
+	require.True(t, note.Diff.empty())
+
+	// selecting another list finds the entity there as well
+	w = watch{id: base.ID(), customerID: "13", lists: []string{"EUCSL"}, policy: notifyOnNewHit}
+	body, _ = rescreen(t, w)
+	require.NotNil(t, body)
+
+	// the EUCSL entity was notified before, so its absence isn't an error but removals aren't new hits
+	w.lists = []string{"SDNs"}
+	body, _ = rescreen(t, w)
+	require.Nil(t, body)
+
+	w.policy = notifyOnChange
+	body, results = rescreen(t, w)
+	note = decode(t, body)
+	require.Empty(t, note.Hits)
+	require.Len(t, note.Diff.Removed, 1)
+	require.Equal(t, "EUCSL", note.Diff.Removed[0].List)
+	require.Empty(t, results)
+
+	// once the removal is notified the missing entity is an error again
+	_, _, err := s.rescreenWatch(w, nil, companyRepo, customerRepo, watchRepo)
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestWatchNotify__routes(t *testing.T) {
+	repo := createTestWatchRepository(t)
+	defer repo.close()
+
+	router := mux.NewRouter()
+	addCompanyRoutes(log.NewNopLogger(), router, nil, nil, repo)
+	addWatchRoutes(log.NewNopLogger(), router, repo, nil)
+
+	w := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"webhook":"https://example.com","authToken":"authToken","notifyPolicy":"on-new-hit"}`)
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac/companies/watch?name=foo", body))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var created companyWatchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+
+	info, err := repo.getWatch(created.WatchID)
+	require.NoError(t, err)
+	require.Equal(t, notifyOnNewHit, info.NotifyPolicy)
+
+	batch, err := repo.getWatchesCursor(log.NewNopLogger(), 4).Next()
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	require.Equal(t, notifyOnNewHit, batch[0].policy)
+
+	// clearing the policy goes back to the default
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+created.WatchID, bytes.NewBufferString(`{"notifyPolicy":""}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+	require.Equal(t, watchNotifyPolicy, info.NotifyPolicy)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+created.WatchID, bytes.NewBufferString(`{"notifyPolicy":"never"}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	body = bytes.NewBufferString(`{"webhook":"https://example.com","authToken":"authToken","notifyPolicy":"sometimes"}`)
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac/companies/watch?name=foo", body))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
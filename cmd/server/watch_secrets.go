@@ -0,0 +1,229 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+
+	"github.com/gorilla/mux"
+)
+
+const defaultWatchSecretRotationGrace = 24 * time.Hour
+
+var (
+	errWatchNotFound = errors.New("watch not found")
+
+	// watchSecretRotationGrace is how long a rotated secret keeps signing webhooks
+	// alongside its replacement so receivers can switch over.
+	watchSecretRotationGrace = func() time.Duration {
+		if v := os.Getenv("WEBHOOK_SECRET_ROTATION_GRACE"); v != "" {
+			if dur, err := time.ParseDuration(v); err == nil && dur >= 0 {
+				return dur
+			}
+		}
+		return defaultWatchSecretRotationGrace
+	}()
+)
+
+// watchSecret is the HMAC key a watch's webhooks are signed with
+type watchSecret struct {
+	WatchID           string     `json:"watchID"`
+	SigningSecret     string     `json:"signingSecret"`
+	PreviousExpiresAt *time.Time `json:"previousExpiresAt,omitempty"`
+}
+
+func generateWatchSecret() (string, error) {
+	bs := make([]byte, 32)
+	if _, err := rand.Read(bs); err != nil {
+		return "", fmt.Errorf("generating signing secret: %v", err)
+	}
+	return hex.EncodeToString(bs), nil
+}
+
+func addWatchSecretRoutes(logger log.Logger, r *mux.Router, repo watchRepository) {
+	r.Methods("POST").Path("/watches/{watchID}/secret").HandlerFunc(rotateWatchSecret(logger, repo))
+	r.Methods("DELETE").Path("/watches/{watchID}/secret/previous").HandlerFunc(expirePreviousWatchSecret(logger, repo))
+}
+
+func rotateWatchSecret(logger log.Logger, repo watchRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		watchID := getWatchID(w, r)
+		if watchID == "" {
+			return
+		}
+		if exists, err := repo.watchExists(watchID); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		} else if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		secret, err := repo.rotateSigningSecret(watchID, time.Now().Add(watchSecretRotationGrace))
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+		}).Logf("rotated signing secret for watch=%s", watchID)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(secret)
+	}
+}
+
+func expirePreviousWatchSecret(logger log.Logger, repo watchRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		watchID := getWatchID(w, r)
+		if watchID == "" {
+			return
+		}
+		if err := repo.expirePreviousSigningSecret(watchID); err != nil {
+			if errors.Is(err, errWatchNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+		}).Logf("expired previous signing secret for watch=%s", watchID)
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// Repository methods
+
+// watchExists reports if watchID is a customer, customer name, company or company name watch which hasn't been removed
+func (r *sqliteWatchRepository) watchExists(watchID string) (bool, error) {
+	query := `select count(*) from (
+select id from customer_watches where id = ? and deleted_at is null
+union all select id from customer_name_watches where id = ? and deleted_at is null
+union all select id from company_watches where id = ? and deleted_at is null
+union all select id from company_name_watches where id = ? and deleted_at is null
+) as watches;`
+	var n int
+	if err := r.db.QueryRow(query, watchID, watchID, watchID, watchID).Scan(&n); err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// rotateSigningSecret generates a new signing secret for watchID. An existing secret keeps signing
+// webhooks until previousExpiresAt. Watches without a secret are given their first one.
+func (r *sqliteWatchRepository) rotateSigningSecret(watchID string, previousExpiresAt time.Time) (*watchSecret, error) {
+	secret, expiresAt, err := rotateSigningSecret(r.db, watchID, previousExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &watchSecret{WatchID: watchID, SigningSecret: secret, PreviousExpiresAt: expiresAt}, nil
+}
+
+// expirePreviousSigningSecret stops signing webhooks with the secret replaced by the last rotation
+func (r *sqliteWatchRepository) expirePreviousSigningSecret(watchID string) error {
+	found, err := expirePreviousSigningSecret(r.db, watchID)
+	if err == nil && !found {
+		return errWatchNotFound
+	}
+	return err
+}
+
+// getSigningSecrets returns the secrets webhooks for watchID are signed with at now, current first.
+// Nothing is returned for watches created before signing was added until their secret is rotated.
+func (r *sqliteWatchRepository) getSigningSecrets(watchID string, now time.Time) ([]string, error) {
+	return getSigningSecrets(r.db, watchID, now)
+}
+
+// The signing secrets of watches and download subscribers are stored in watch_signing_secrets by their ID
+
+// rotateSigningSecret generates and stores a new signing secret for id, returning when the replaced
+// secret expires. nil is returned for that when id didn't have a secret.
+func rotateSigningSecret(db *sql.DB, id string, previousExpiresAt time.Time) (string, *time.Time, error) {
+	secret, err := generateWatchSecret()
+	if err != nil {
+		return "", nil, err
+	}
+	now := time.Now()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return "", nil, err
+	}
+	defer tx.Rollback()
+
+	var current sql.NullString
+	err = tx.QueryRow(`select secret from watch_signing_secrets where watch_id = ?;`, id).Scan(&current)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		_, err = tx.Exec(`insert into watch_signing_secrets (watch_id, secret, created_at) values (?, ?, ?);`, id, secret, now)
+		if err != nil {
+			return "", nil, err
+		}
+		return secret, nil, tx.Commit()
+
+	case err != nil:
+		return "", nil, err
+	}
+
+	_, err = tx.Exec(`update watch_signing_secrets set secret = ?, previous_secret = ?, previous_expires_at = ?, rotated_at = ? where watch_id = ?;`,
+		secret, current, previousExpiresAt, now, id)
+	if err != nil {
+		return "", nil, err
+	}
+	return secret, &previousExpiresAt, tx.Commit()
+}
+
+// expirePreviousSigningSecret clears the replaced secret of id and reports if id has a secret
+func expirePreviousSigningSecret(db *sql.DB, id string) (bool, error) {
+	res, err := db.Exec(`update watch_signing_secrets set previous_secret = null, previous_expires_at = null where watch_id = ?;`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// getSigningSecrets returns the secrets id signs with at now, current first
+func getSigningSecrets(db *sql.DB, id string, now time.Time) ([]string, error) {
+	var current, previous sql.NullString
+	var previousExpiresAt sql.NullTime
+	err := db.QueryRow(`select secret, previous_secret, previous_expires_at from watch_signing_secrets where watch_id = ?;`, id).
+		Scan(&current, &previous, &previousExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var out []string
+	if current.String != "" {
+		out = append(out, current.String)
+	}
+	if previous.String != "" && previousExpiresAt.Valid && now.Before(previousExpiresAt.Time) {
+		out = append(out, previous.String)
+	}
+	return out, nil
+}
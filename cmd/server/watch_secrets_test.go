@@ -0,0 +1,193 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/client"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchSecrets__repository(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, repo *sqliteWatchRepository) {
+		watchID, err := repo.addCompanyNameWatch("foo", "https://example.com", "authToken")
+		require.NoError(t, err)
+
+		exists, err := repo.watchExists(watchID)
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		// watches without a secret aren't signed
+		secrets, err := repo.getSigningSecrets(watchID, time.Now())
+		require.NoError(t, err)
+		require.Empty(t, secrets)
+
+		first, err := repo.rotateSigningSecret(watchID, time.Now())
+		require.NoError(t, err)
+		require.Len(t, first.SigningSecret, 64)
+		require.Nil(t, first.PreviousExpiresAt)
+
+		// the previous secret signs until it expires
+		expiresAt := time.Now().Add(time.Hour)
+		second, err := repo.rotateSigningSecret(watchID, expiresAt)
+		require.NoError(t, err)
+		require.NotEqual(t, first.SigningSecret, second.SigningSecret)
+
+		secrets, err = repo.getSigningSecrets(watchID, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, []string{second.SigningSecret, first.SigningSecret}, secrets)
+
+		secrets, err = repo.getSigningSecrets(watchID, expiresAt.Add(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, []string{second.SigningSecret}, secrets)
+
+		require.NoError(t, repo.expirePreviousSigningSecret(watchID))
+		secrets, err = repo.getSigningSecrets(watchID, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, []string{second.SigningSecret}, secrets)
+
+		require.ErrorIs(t, repo.expirePreviousSigningSecret(base.ID()), errWatchNotFound)
+
+		require.NoError(t, repo.removeCompanyNameWatch(watchID))
+		exists, err = repo.watchExists(watchID)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	// SQLite tests
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+	check(t, &sqliteWatchRepository{sqliteDB.DB, log.NewNopLogger()})
+
+	// MySQL tests
+	mysqlDB := database.TestMySQLConnection(t)
+	check(t, &sqliteWatchRepository{mysqlDB, log.NewNopLogger()})
+}
+
+func TestWatchSecrets__routes(t *testing.T) {
+	repo := createTestWatchRepository(t)
+	defer repo.close()
+
+	router := mux.NewRouter()
+	addCustomerRoutes(log.NewNopLogger(), router, nil, nil, repo)
+	addWatchSecretRoutes(log.NewNopLogger(), router, repo)
+
+	// new watches are given a secret
+	w := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"webhook":"https://example.com","authToken":"authToken"}`)
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac/customers/watch?name=foo", body))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var created customerWatchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	require.NotEmpty(t, created.SigningSecret)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/watches/"+created.WatchID+"/secret", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var rotated watchSecret
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&rotated))
+	require.Equal(t, created.WatchID, rotated.WatchID)
+	require.NotEqual(t, created.SigningSecret, rotated.SigningSecret)
+	require.NotNil(t, rotated.PreviousExpiresAt)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/watches/"+created.WatchID+"/secret/previous", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// unknown watches
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/watches/"+base.ID()+"/secret", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/watches/"+base.ID()+"/secret/previous", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWatchSecrets__failedCreate(t *testing.T) {
+	repo := createTestWatchRepository(t)
+	defer repo.close()
+
+	router := mux.NewRouter()
+	addCustomerRoutes(log.NewNopLogger(), router, nil, nil, repo)
+	addCompanyRoutes(log.NewNopLogger(), router, nil, nil, repo)
+
+	// watches which can't be given a secret are removed instead of left notifying unsigned
+	_, err := repo.db.Exec(`drop table watch_signing_secrets;`)
+	require.NoError(t, err)
+
+	for _, path := range []string{
+		"/ofac/customers/watch?name=foo",
+		"/ofac/customers/foo/watch",
+		"/ofac/companies/watch?name=foo",
+		"/ofac/companies/foo/watch",
+	} {
+		w := httptest.NewRecorder()
+		body := bytes.NewBufferString(`{"webhook":"https://example.com","authToken":"authToken"}`)
+		router.ServeHTTP(w, httptest.NewRequest("POST", path, body))
+		require.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+
+	var active int
+	err = repo.db.QueryRow(`select count(*) from (
+select id from customer_watches where deleted_at is null
+union all select id from customer_name_watches where deleted_at is null
+union all select id from company_watches where deleted_at is null
+union all select id from company_name_watches where deleted_at is null
+) as watches;`).Scan(&active)
+	require.NoError(t, err)
+	require.Zero(t, active)
+}
+
+func TestWatchSecrets__signedDelivery(t *testing.T) {
+	var secret string
+	verified := make(chan error, 1)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := client.VerifyWebhookRequest(r, secret, 0)
+		verified <- err
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	trustTestServer(t, server)
+
+	queue := createTestWebhookQueue(t)
+	watchRepo := queue.watchRepo.(*sqliteWatchRepository)
+
+	watchID, err := watchRepo.addCustomerNameWatch("foo", server.URL, "authToken")
+	require.NoError(t, err)
+	created, err := watchRepo.rotateSigningSecret(watchID, time.Now())
+	require.NoError(t, err)
+	secret = created.SigningSecret
+
+	_, err = queue.enqueue(watch{id: watchID, webhook: server.URL, authToken: "authToken"}, bytes.NewBufferString(`{"id":"306"}`))
+	require.NoError(t, err)
+	require.NoError(t, queue.deliverDue(time.Now()))
+	require.NoError(t, <-verified)
+
+	// receivers holding the old secret still verify during the rotation grace period
+	rotated, err := watchRepo.rotateSigningSecret(watchID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NotEqual(t, secret, rotated.SigningSecret)
+
+	_, err = queue.enqueue(watch{id: watchID, webhook: server.URL, authToken: "authToken"}, bytes.NewBufferString(`{"id":"306"}`))
+	require.NoError(t, err)
+	require.NoError(t, queue.deliverDue(time.Now()))
+	require.NoError(t, <-verified)
+}
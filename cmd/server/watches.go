@@ -0,0 +1,498 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+
+	"github.com/gorilla/mux"
+)
+
+// watchRecentWebhooks is how many webhook attempts are returned with a watch
+const watchRecentWebhooks = 10
+
+type watchType string
+
+const (
+	customerWatchType     watchType = "customer"
+	customerNameWatchType watchType = "customerName"
+	companyWatchType      watchType = "company"
+	companyNameWatchType  watchType = "companyName"
+)
+
+func (t watchType) valid() bool {
+	switch t {
+	case customerWatchType, customerNameWatchType, companyWatchType, companyNameWatchType:
+		return true
+	}
+	return false
+}
+
+func (t watchType) table() string {
+	switch t {
+	case customerWatchType:
+		return "customer_watches"
+	case customerNameWatchType:
+		return "customer_name_watches"
+	case companyWatchType:
+		return "company_watches"
+	case companyNameWatchType:
+		return "company_name_watches"
+	}
+	return ""
+}
+
+// watchInfo describes a registered watch. The auth token sent with its webhooks is never returned.
+type watchInfo struct {
+	WatchID    string    `json:"watchID"`
+	Type       watchType `json:"type"`
+	CustomerID string    `json:"customerID,omitempty"`
+	CompanyID  string    `json:"companyID,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Webhook    string    `json:"webhook,omitempty"`
+	Lists      []string  `json:"lists"`
+	MinMatch   float64   `json:"minMatch,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+
+	// NotifyPolicy decides when the watch's webhook is called
+	NotifyPolicy notifyPolicy `json:"notifyPolicy"`
+
+	// Notifier sends the watch's notifications
+	Notifier notifierType `json:"notifier"`
+
+	// RecentWebhooks is only included when a single watch is read
+	RecentWebhooks []webhookStat `json:"recentWebhooks,omitempty"`
+}
+
+// webhookStat is one attempt at calling a watch's webhook
+type webhookStat struct {
+	AttemptedAt time.Time `json:"attemptedAt"`
+	Status      int       `json:"status"`
+}
+
+type watchFilter struct {
+	watchID    string
+	watchType  watchType
+	customerID string
+	companyID  string
+
+	limit, offset int
+}
+
+// watchUpdate holds the fields of a watch to change, nil fields are left as they are.
+// Empty Lists, NotifyPolicy or Notifier and a MinMatch of zero go back to the defaults.
+type watchUpdate struct {
+	Webhook      *string       `json:"webhook"`
+	AuthToken    *string       `json:"authToken"`
+	Lists        *[]string     `json:"lists"`
+	MinMatch     *float64      `json:"minMatch"`
+	NotifyPolicy *notifyPolicy `json:"notifyPolicy"`
+	Notifier     *notifierType `json:"notifier"`
+}
+
+// validateDestination checks where a new watch is notified and returns its normalized webhook. Watches
+// notified with a webhook need an HTTPS url and auth token, others can leave both out.
+func (req *watchRequest) validateDestination() (string, error) {
+	if req.Notifier != "" && !req.Notifier.valid() {
+		return "", fmt.Errorf("invalid notifier %q", req.Notifier)
+	}
+	notifier := watch{notifier: req.Notifier}.notifierType()
+	if notifier != webhookNotifierType && req.Webhook == "" && req.AuthToken == "" {
+		return "", nil
+	}
+	if req.AuthToken == "" {
+		return "", errNoAuthToken
+	}
+	return validateWebhook(req.Webhook)
+}
+
+// validateOptions checks the optional lists, minMatch and notifyPolicy of a new watch, only name watches have a minMatch
+func (req *watchRequest) validateOptions(nameWatch bool) error {
+	if len(req.Lists) > 0 {
+		lists, err := validateWatchLists(req.Lists)
+		if err != nil {
+			return err
+		}
+		if !nameWatch && len(lists) > 1 {
+			return errors.New("watches of an ID can only select one list")
+		}
+		req.Lists = lists
+	}
+	if req.MinMatch != 0 {
+		if !nameWatch {
+			return errors.New("minMatch is only supported on name watches")
+		}
+		if req.MinMatch < 0 || req.MinMatch > 1 {
+			return fmt.Errorf("invalid minMatch %v", req.MinMatch)
+		}
+	}
+	if req.NotifyPolicy != "" && !req.NotifyPolicy.valid() {
+		return fmt.Errorf("invalid notifyPolicy %q", req.NotifyPolicy)
+	}
+	return nil
+}
+
+// saveWatchOptions stores the lists, minMatch, notifyPolicy and notifier requested when watchID was added
+func saveWatchOptions(repo watchRepository, watchID string, req watchRequest) error {
+	if len(req.Lists) == 0 && req.MinMatch == 0 && req.NotifyPolicy == "" && req.Notifier == "" {
+		return nil
+	}
+	var update watchUpdate
+	if len(req.Lists) > 0 {
+		update.Lists = &req.Lists
+	}
+	if req.MinMatch > 0 {
+		update.MinMatch = &req.MinMatch
+	}
+	if req.NotifyPolicy != "" {
+		update.NotifyPolicy = &req.NotifyPolicy
+	}
+	if req.Notifier != "" {
+		update.Notifier = &req.Notifier
+	}
+	_, err := repo.updateWatch(watchID, update)
+	return err
+}
+
+// completeWatch stores the options requested when watchID was added and gives it its first signing secret
+func completeWatch(repo watchRepository, watchID string, req watchRequest) (*watchSecret, error) {
+	if err := saveWatchOptions(repo, watchID, req); err != nil {
+		return nil, err
+	}
+	return repo.rotateSigningSecret(watchID, time.Now())
+}
+
+// splitWatchLists reads the lists column of a watch
+func splitWatchLists(str string) []string {
+	if str == "" {
+		return nil
+	}
+	return strings.Split(str, ",")
+}
+
+func addWatchRoutes(logger log.Logger, r *mux.Router, watchRepo watchRepository, webhookRepo webhookRepository) {
+	r.Methods("GET").Path("/watches").HandlerFunc(listWatches(logger, watchRepo))
+	r.Methods("GET").Path("/watches/{watchID}").HandlerFunc(getWatch(logger, watchRepo, webhookRepo))
+	r.Methods("PATCH").Path("/watches/{watchID}").HandlerFunc(updateWatch(logger, watchRepo))
+}
+
+func listWatches(logger log.Logger, repo watchRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		q := r.URL.Query()
+		filter := watchFilter{
+			watchType:  watchType(q.Get("type")),
+			customerID: q.Get("customerID"),
+			companyID:  q.Get("companyID"),
+			limit:      extractSearchLimit(r),
+		}
+		if filter.watchType != "" && !filter.watchType.valid() {
+			moovhttp.Problem(w, fmt.Errorf("invalid watch type %q", filter.watchType))
+			return
+		}
+		if v := q.Get("offset"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				moovhttp.Problem(w, fmt.Errorf("invalid offset %q", v))
+				return
+			}
+			filter.offset = n
+		}
+
+		watches, err := repo.listWatches(filter)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if watches == nil {
+			watches = []*watchInfo{}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(watches)
+	}
+}
+
+func getWatch(logger log.Logger, watchRepo watchRepository, webhookRepo webhookRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		watchID := getWatchID(w, r)
+		if watchID == "" {
+			return
+		}
+		info, err := watchRepo.getWatch(watchID)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if info == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		info.RecentWebhooks, err = webhookRepo.recentWebhooks(watchID, watchRecentWebhooks)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(info)
+	}
+}
+
+func updateWatch(logger log.Logger, repo watchRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w = wrapResponseWriter(logger, w, r)
+
+		watchID := getWatchID(w, r)
+		if watchID == "" {
+			return
+		}
+
+		var update watchUpdate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if update.Webhook == nil && update.AuthToken == nil && update.Lists == nil && update.MinMatch == nil && update.NotifyPolicy == nil && update.Notifier == nil {
+			moovhttp.Problem(w, errors.New("no watch fields to update"))
+			return
+		}
+		if update.Webhook != nil {
+			webhook, err := validateWebhook(*update.Webhook)
+			if err != nil {
+				moovhttp.Problem(w, err)
+				return
+			}
+			update.Webhook = &webhook
+		}
+		if update.AuthToken != nil && *update.AuthToken == "" {
+			moovhttp.Problem(w, errNoAuthToken)
+			return
+		}
+		if update.Lists != nil && len(*update.Lists) > 0 {
+			lists, err := validateWatchLists(*update.Lists)
+			if err != nil {
+				moovhttp.Problem(w, err)
+				return
+			}
+			update.Lists = &lists
+		}
+		if update.MinMatch != nil && (*update.MinMatch < 0 || *update.MinMatch > 1) {
+			moovhttp.Problem(w, fmt.Errorf("invalid minMatch %v", *update.MinMatch))
+			return
+		}
+		if update.NotifyPolicy != nil && *update.NotifyPolicy != "" && !update.NotifyPolicy.valid() {
+			moovhttp.Problem(w, fmt.Errorf("invalid notifyPolicy %q", *update.NotifyPolicy))
+			return
+		}
+		if update.Notifier != nil && *update.Notifier != "" && !update.Notifier.valid() {
+			moovhttp.Problem(w, fmt.Errorf("invalid notifier %q", *update.Notifier))
+			return
+		}
+
+		info, err := repo.updateWatch(watchID, update)
+		if err != nil {
+			if errors.Is(err, errWatchNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID": log.String(moovhttp.GetRequestID(r)),
+			"userID":    log.String(moovhttp.GetUserID(r)),
+		}).Logf("updated watch=%s", watchID)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(info)
+	}
+}
+
+// Repository methods
+
+// watchesQuery selects every watch which hasn't been removed with its type
+const watchesQuery = `select id, watch_type, customer_id, company_id, name, webhook, lists, min_match, notify_policy, notifier, created_at from (
+select id, 'customer' as watch_type, customer_id, '' as company_id, '' as name, webhook, lists, null as min_match, notify_policy, notifier, created_at, deleted_at from customer_watches
+union all select id, 'customerName', '', '', name, webhook, lists, min_match, notify_policy, notifier, created_at, deleted_at from customer_name_watches
+union all select id, 'company', '', company_id, '', webhook, lists, null, notify_policy, notifier, created_at, deleted_at from company_watches
+union all select id, 'companyName', '', '', name, webhook, lists, min_match, notify_policy, notifier, created_at, deleted_at from company_name_watches
+) as watches where deleted_at is null`
+
+// listWatches returns the watches matching filter, oldest first
+func (r *sqliteWatchRepository) listWatches(filter watchFilter) ([]*watchInfo, error) {
+	var where []string
+	var args []interface{}
+	if filter.watchID != "" {
+		where = append(where, "id = ?")
+		args = append(args, filter.watchID)
+	}
+	if filter.watchType != "" {
+		where = append(where, "watch_type = ?")
+		args = append(args, string(filter.watchType))
+	}
+	if filter.customerID != "" {
+		where = append(where, "customer_id = ?")
+		args = append(args, filter.customerID)
+	}
+	if filter.companyID != "" {
+		where = append(where, "company_id = ?")
+		args = append(args, filter.companyID)
+	}
+
+	query := watchesQuery
+	if len(where) > 0 {
+		query += " and " + strings.Join(where, " and ")
+	}
+	query += " order by created_at asc, id asc limit ? offset ?;"
+	args = append(args, filter.limit, filter.offset)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*watchInfo
+	for rows.Next() {
+		var info watchInfo
+		var customerID, companyID, name, webhook, lists, policy, notifier sql.NullString
+		var minMatch sql.NullFloat64
+		if err := rows.Scan(&info.WatchID, &info.Type, &customerID, &companyID, &name, &webhook, &lists, &minMatch, &policy, &notifier, &info.CreatedAt); err != nil {
+			return nil, err
+		}
+		info.Lists = splitWatchLists(lists.String)
+		if len(info.Lists) == 0 {
+			info.Lists = watchDefaultLists
+		}
+		info.CustomerID = customerID.String
+		info.CompanyID = companyID.String
+		info.Name = name.String
+		info.MinMatch = minMatch.Float64
+		info.Webhook = webhook.String
+		info.NotifyPolicy = watch{policy: notifyPolicy(policy.String)}.notifyPolicy()
+		info.Notifier = watch{notifier: notifierType(notifier.String)}.notifierType()
+		out = append(out, &info)
+	}
+	return out, rows.Err()
+}
+
+// getWatch returns the watch for watchID or nil if it doesn't exist
+func (r *sqliteWatchRepository) getWatch(watchID string) (*watchInfo, error) {
+	watches, err := r.listWatches(watchFilter{watchID: watchID, limit: 1})
+	if err != nil || len(watches) == 0 {
+		return nil, err
+	}
+	return watches[0], nil
+}
+
+// updateWatch changes the webhook, auth token, lists, minMatch, notifyPolicy or notifier of a watch. Only name watches have a minMatch.
+func (r *sqliteWatchRepository) updateWatch(watchID string, update watchUpdate) (*watchInfo, error) {
+	info, err := r.getWatch(watchID)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, errWatchNotFound
+	}
+
+	var set []string
+	var args []interface{}
+	if update.Webhook != nil {
+		set = append(set, "webhook = ?")
+		args = append(args, *update.Webhook)
+	}
+	if update.AuthToken != nil {
+		set = append(set, "auth_token = ?")
+		args = append(args, *update.AuthToken)
+	}
+	if update.Lists != nil {
+		if len(*update.Lists) > 1 && info.Type != customerNameWatchType && info.Type != companyNameWatchType {
+			return nil, fmt.Errorf("%s watches can only select one list", info.Type)
+		}
+		set = append(set, "lists = ?")
+		if len(*update.Lists) > 0 {
+			args = append(args, strings.Join(*update.Lists, ","))
+		} else {
+			args = append(args, nil)
+		}
+	}
+	if update.MinMatch != nil {
+		if info.Type != customerNameWatchType && info.Type != companyNameWatchType {
+			return nil, fmt.Errorf("minMatch is only supported on name watches, not %s watches", info.Type)
+		}
+		set = append(set, "min_match = ?")
+		if *update.MinMatch > 0 {
+			args = append(args, *update.MinMatch)
+		} else {
+			args = append(args, nil)
+		}
+	}
+	if update.NotifyPolicy != nil {
+		set = append(set, "notify_policy = ?")
+		if *update.NotifyPolicy != "" {
+			args = append(args, string(*update.NotifyPolicy))
+		} else {
+			args = append(args, nil)
+		}
+	}
+	if update.Notifier != nil {
+		notifier := watch{notifier: *update.Notifier}.notifierType()
+		if notifier == webhookNotifierType && info.Webhook == "" && update.Webhook == nil {
+			return nil, errors.New("watches without a webhook can't be notified with one")
+		}
+		set = append(set, "notifier = ?")
+		if *update.Notifier != "" {
+			args = append(args, string(*update.Notifier))
+		} else {
+			args = append(args, nil)
+		}
+	}
+	if len(set) == 0 {
+		return info, nil
+	}
+
+	query := fmt.Sprintf("update %s set %s where id = ? and deleted_at is null;", info.Type.table(), strings.Join(set, ", "))
+	if _, err := r.db.Exec(query, append(args, watchID)...); err != nil {
+		return nil, err
+	}
+	return r.getWatch(watchID)
+}
+
+// recentWebhooks returns the latest attempts at calling a watch's webhook, newest first
+func (r *sqliteWebhookRepository) recentWebhooks(watchID string, limit int) ([]webhookStat, error) {
+	rows, err := r.db.Query(`select attempted_at, status from webhook_stats where watch_id = ? order by attempted_at desc limit ?;`, watchID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []webhookStat
+	for rows.Next() {
+		var stat webhookStat
+		if err := rows.Scan(&stat.AttemptedAt, &stat.Status); err != nil {
+			return nil, err
+		}
+		out = append(out, stat)
+	}
+	return out, rows.Err()
+}
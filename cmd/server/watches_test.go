@@ -0,0 +1,165 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatches__repository(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, repo *sqliteWatchRepository) {
+		customerID := base.ID()
+		customerWatchID, err := repo.addCustomerWatch(customerID, watchRequest{Webhook: "https://example.com/customer", AuthToken: "authToken"})
+		require.NoError(t, err)
+		nameWatchID, err := repo.addCompanyNameWatch("foo", "https://example.com/company", "authToken")
+		require.NoError(t, err)
+
+		watches, err := repo.listWatches(watchFilter{limit: 10})
+		require.NoError(t, err)
+		require.Len(t, watches, 2)
+
+		watches, err = repo.listWatches(watchFilter{customerID: customerID, limit: 10})
+		require.NoError(t, err)
+		require.Len(t, watches, 1)
+		require.Equal(t, customerWatchID, watches[0].WatchID)
+		require.Equal(t, customerWatchType, watches[0].Type)
+		require.Equal(t, "https://example.com/customer", watches[0].Webhook)
+
+		watches, err = repo.listWatches(watchFilter{watchType: companyNameWatchType, limit: 10})
+		require.NoError(t, err)
+		require.Len(t, watches, 1)
+		require.Equal(t, "foo", watches[0].Name)
+
+		// pagination
+		watches, err = repo.listWatches(watchFilter{limit: 1, offset: 1})
+		require.NoError(t, err)
+		require.Len(t, watches, 1)
+
+		// thresholds are only kept on name watches
+		minMatch := 0.95
+		webhook := "https://example.com/other"
+		info, err := repo.updateWatch(nameWatchID, watchUpdate{Webhook: &webhook, MinMatch: &minMatch})
+		require.NoError(t, err)
+		require.Equal(t, webhook, info.Webhook)
+		require.Equal(t, minMatch, info.MinMatch)
+
+		_, err = repo.updateWatch(customerWatchID, watchUpdate{MinMatch: &minMatch})
+		require.ErrorContains(t, err, "only supported on name watches")
+
+		_, err = repo.updateWatch(base.ID(), watchUpdate{Webhook: &webhook})
+		require.ErrorIs(t, err, errWatchNotFound)
+
+		// the cursor applies the threshold
+		batch, err := repo.getWatchesCursor(log.NewNopLogger(), 4).Next()
+		require.NoError(t, err)
+		for _, w := range batch {
+			if w.id == nameWatchID {
+				require.Equal(t, minMatch, w.minMatch)
+				require.Equal(t, webhook, w.webhook)
+			}
+		}
+
+		// removed watches are hidden
+		require.NoError(t, repo.removeCompanyNameWatch(nameWatchID))
+		info, err = repo.getWatch(nameWatchID)
+		require.NoError(t, err)
+		require.Nil(t, info)
+	}
+
+	// SQLite tests
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+	check(t, &sqliteWatchRepository{sqliteDB.DB, log.NewNopLogger()})
+
+	// MySQL tests
+	mysqlDB := database.TestMySQLConnection(t)
+	check(t, &sqliteWatchRepository{mysqlDB, log.NewNopLogger()})
+}
+
+func TestWatches__routes(t *testing.T) {
+	db := database.CreateTestSqliteDB(t)
+	defer db.Close()
+
+	watchRepo := &sqliteWatchRepository{db.DB, log.NewNopLogger()}
+	webhookRepo := &sqliteWebhookRepository{db.DB}
+
+	router := mux.NewRouter()
+	addWatchRoutes(log.NewNopLogger(), router, watchRepo, webhookRepo)
+
+	watchID, err := watchRepo.addCustomerNameWatch("foo", "https://example.com", "secretToken")
+	require.NoError(t, err)
+	require.NoError(t, webhookRepo.recordWebhook(watchID, time.Now(), http.StatusOK))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/watches?type=customerName", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "secretToken")
+
+	var watches []watchInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&watches))
+	require.Len(t, watches, 1)
+	require.Equal(t, watchID, watches[0].WatchID)
+	require.Empty(t, watches[0].RecentWebhooks)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/watches?type=person", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/watches?offset=-1", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/watches/"+watchID, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var info watchInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+	require.Equal(t, "foo", info.Name)
+	require.Len(t, info.RecentWebhooks, 1)
+	require.Equal(t, http.StatusOK, info.RecentWebhooks[0].Status)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/watches/"+base.ID(), nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// updates
+	w = httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"webhook":"https://example.com/new","authToken":"newToken","minMatch":0.9}`)
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+watchID, body))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+	require.Equal(t, "https://example.com/new", info.Webhook)
+	require.Equal(t, 0.9, info.MinMatch)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+watchID, bytes.NewBufferString(`{"minMatch":0}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "minMatch")
+
+	for _, body := range []string{`{}`, `{"webhook":"http://example.com"}`, `{"authToken":""}`, `{"minMatch":1.5}`} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+watchID, bytes.NewBufferString(body)))
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PATCH", "/watches/"+base.ID(), bytes.NewBufferString(`{"authToken":"newToken"}`)))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
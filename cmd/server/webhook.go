@@ -12,9 +12,11 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/moov-io/base/strx"
+	"github.com/moov-io/watchman/client"
 	"go4.org/syncutil"
 )
 
@@ -48,20 +50,26 @@ func init() {
 
 // callWebhook will take `body` as JSON and make a POST request to the provided webhook url.
 // Returned is the HTTP status code.
-func callWebhook(body *bytes.Buffer, webhook string, authToken string) (int, error) {
+//
+// When secrets are given the body is signed with each of them (see client.SignWebhook).
+func callWebhook(body *bytes.Buffer, webhook string, authToken string, secrets ...string) (int, error) {
 	webhook, err := validateWebhook(webhook)
 	if err != nil {
 		return 0, err
 	}
 
 	// Setup HTTP request
-	req, err := http.NewRequest("POST", webhook, body)
+	payload := body.Bytes()
+	req, err := http.NewRequest("POST", webhook, bytes.NewReader(payload))
 	if err != nil {
 		return 0, fmt.Errorf("unknown error webhook: %v", err)
 	}
 	if authToken != "" {
 		req.Header.Set("Authorization", authToken)
 	}
+	if len(secrets) > 0 {
+		signWebhook(req, payload, time.Now(), secrets)
+	}
 
 	// Guard HTTP calls in-flight
 	if webhookGate != nil {
@@ -85,6 +93,25 @@ func callWebhook(body *bytes.Buffer, webhook string, authToken string) (int, err
 	return resp.StatusCode, nil
 }
 
+// signWebhook sets the timestamp and signature headers of a webhook request
+func signWebhook(req *http.Request, body []byte, now time.Time, secrets []string) {
+	signatures := make([]string, len(secrets))
+	for i := range secrets {
+		signatures[i] = client.SignWebhook(secrets[i], now, body)
+	}
+	req.Header.Set(client.WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(client.WebhookSignatureHeader, strings.Join(signatures, ","))
+}
+
+// invalidWebhookError is returned for webhooks which can never be called
+type invalidWebhookError struct {
+	msg string
+}
+
+func (e *invalidWebhookError) Error() string {
+	return e.msg
+}
+
 // validateWebhook performs some basic checks against the incoming webhook and
 // returns a normalized value.
 //
@@ -93,16 +120,26 @@ func callWebhook(body *bytes.Buffer, webhook string, authToken string) (int, err
 func validateWebhook(raw string) (string, error) {
 	u, err := url.Parse(raw)
 	if err != nil {
-		return "", fmt.Errorf("%s is not a valid URL: %v", raw, err)
+		return "", &invalidWebhookError{fmt.Sprintf("%s is not a valid URL: %v", raw, err)}
 	}
 	if u.Scheme != "https" {
-		return "", fmt.Errorf("%s is not an HTTPS url", u.String())
+		return "", &invalidWebhookError{fmt.Sprintf("%s is not an HTTPS url", u.String())}
 	}
 	return u.String(), nil
 }
 
 type webhookRepository interface {
 	recordWebhook(watchID string, attemptedAt time.Time, status int) error
+	recentWebhooks(watchID string, limit int) ([]webhookStat, error)
+
+	// Deliveries are webhook calls which are retried until they succeed
+	enqueueDelivery(d *webhookDelivery) error
+	updateDelivery(d *webhookDelivery) error
+	dueDeliveries(now time.Time, limit int) ([]*webhookDelivery, error)
+	claimDelivery(deliveryID string, now, until time.Time) (bool, error)
+	listDeliveries(filter deliveryFilter) ([]*webhookDelivery, error)
+	getDelivery(deliveryID string) (*webhookDelivery, error)
+	pruneDeliveries(before time.Time) (int64, error)
 }
 
 type sqliteWebhookRepository struct {
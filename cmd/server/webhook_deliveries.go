@@ -0,0 +1,639 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/moov-io/base"
+	moovhttp "github.com/moov-io/base/http"
+	"github.com/moov-io/base/log"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	webhookDeliveriesPath     = "/webhooks/deliveries"
+	webhookDeliveryPath       = "/webhooks/deliveries/{deliveryID}"
+	webhookDeliveryReplayPath = "/webhooks/deliveries/{deliveryID}/replay"
+
+	defaultWebhookMaxAttempts   = 5
+	defaultWebhookRetryBackoff  = 30 * time.Second
+	maxWebhookRetryBackoff      = 6 * time.Hour
+	defaultWebhookQueueInterval = 5 * time.Second
+	defaultWebhookRetention     = 7 * 24 * time.Hour
+
+	webhookQueueBatchSize = 100
+)
+
+var (
+	errDeliveryNotDead = errors.New("only dead deliveries can be replayed")
+)
+
+type deliveryStatus string
+
+const (
+	deliveryPending   deliveryStatus = "pending"
+	deliveryDelivered deliveryStatus = "delivered"
+	deliveryDead      deliveryStatus = "dead"
+)
+
+func (s deliveryStatus) valid() bool {
+	switch s {
+	case deliveryPending, deliveryDelivered, deliveryDead:
+		return true
+	}
+	return false
+}
+
+// webhookDelivery is a watch's (or download subscriber's) webhook call which is retried until it
+// succeeds or runs out of attempts
+type webhookDelivery struct {
+	ID           string          `json:"id"`
+	WatchID      string          `json:"watchID,omitempty"`
+	SubscriberID string          `json:"subscriberID,omitempty"`
+	Notifier     notifierType    `json:"notifier"`
+	Webhook      string          `json:"webhook,omitempty"`
+	authToken    string          // never returned from the admin endpoints
+	Body         json.RawMessage `json:"body,omitempty"`
+	Status       deliveryStatus  `json:"status"`
+
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt  *time.Time `json:"lastAttemptAt,omitempty"`
+	LastStatusCode int        `json:"lastStatusCode,omitempty"`
+	LastError      string     `json:"lastError,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type deliveryFilter struct {
+	status       deliveryStatus
+	watchID      string
+	subscriberID string
+	limit        int
+}
+
+// webhookQueue delivers queued webhooks, retrying failed calls with exponential backoff
+// until they succeed or are marked dead.
+type webhookQueue struct {
+	repo           webhookRepository
+	watchRepo      watchRepository
+	subscriberRepo downloadSubscriberRepository
+	notifiers      map[notifierType]notifier
+	logger         log.Logger
+
+	maxAttempts int
+	backoff     time.Duration
+	interval    time.Duration
+	retention   time.Duration
+	lease       time.Duration
+
+	wake chan struct{}
+}
+
+// newWebhookQueue reads the delivery config from environment variables
+func newWebhookQueue(logger log.Logger, repo webhookRepository, watchRepo watchRepository, subscriberRepo downloadSubscriberRepository) (*webhookQueue, error) {
+	q := &webhookQueue{
+		repo:           repo,
+		watchRepo:      watchRepo,
+		subscriberRepo: subscriberRepo,
+		logger:         logger,
+		maxAttempts:    defaultWebhookMaxAttempts,
+		backoff:        defaultWebhookRetryBackoff,
+		interval:       defaultWebhookQueueInterval,
+		retention:      defaultWebhookRetention,
+		lease:          watchLeaseDuration,
+		wake:           make(chan struct{}, 1),
+	}
+	notifiers, err := newNotifiers()
+	if err != nil {
+		return nil, err
+	}
+	q.notifiers = notifiers
+	if v := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid WEBHOOK_MAX_ATTEMPTS=%q", v)
+		}
+		q.maxAttempts = n
+	}
+	durations := []struct {
+		name string
+		dur  *time.Duration
+	}{
+		{"WEBHOOK_RETRY_BACKOFF", &q.backoff},
+		{"WEBHOOK_QUEUE_INTERVAL", &q.interval},
+		{"WEBHOOK_DELIVERY_RETENTION", &q.retention},
+	}
+	for _, d := range durations {
+		v := os.Getenv(d.name)
+		if v == "" {
+			continue
+		}
+		dur, err := time.ParseDuration(v)
+		if err != nil || dur <= 0 {
+			return nil, fmt.Errorf("invalid %s=%q", d.name, v)
+		}
+		*d.dur = dur
+	}
+	return q, nil
+}
+
+// enqueue stores a webhook call for w which is attempted on the next run of the queue
+func (q *webhookQueue) enqueue(w watch, body *bytes.Buffer) (*webhookDelivery, error) {
+	return q.enqueueDelivery(&webhookDelivery{
+		WatchID:   w.id,
+		Notifier:  w.notifierType(),
+		Webhook:   w.webhook,
+		authToken: w.authToken,
+	}, body)
+}
+
+// enqueueSubscriber stores a webhook call for a download subscriber
+func (q *webhookQueue) enqueueSubscriber(sub *downloadSubscriber, body *bytes.Buffer) (*webhookDelivery, error) {
+	return q.enqueueDelivery(&webhookDelivery{
+		SubscriberID: sub.ID,
+		Notifier:     webhookNotifierType,
+		Webhook:      sub.Webhook,
+		authToken:    sub.authToken,
+	}, body)
+}
+
+func (q *webhookQueue) enqueueDelivery(d *webhookDelivery, body *bytes.Buffer) (*webhookDelivery, error) {
+	now := time.Now()
+	d.ID = base.ID()
+	d.Body = json.RawMessage(bytes.TrimSpace(body.Bytes()))
+	d.Status = deliveryPending
+	d.NextAttemptAt = &now
+	d.CreatedAt = now
+	d.UpdatedAt = now
+	if err := q.repo.enqueueDelivery(d); err != nil {
+		return nil, err
+	}
+	q.notify()
+	return d, nil
+}
+
+// notify wakes the queue without waiting for its next tick
+func (q *webhookQueue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run attempts due deliveries every interval, or sooner when deliveries are enqueued
+func (q *webhookQueue) run() {
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+
+	lastPrune := time.Time{}
+	for {
+		select {
+		case <-ticker.C:
+		case <-q.wake:
+		}
+		if err := q.deliverDue(time.Now()); err != nil {
+			q.logger.Error().LogErrorf("problem delivering webhooks: %v", err)
+		}
+		if time.Since(lastPrune) > time.Hour {
+			lastPrune = time.Now()
+			n, err := q.repo.pruneDeliveries(lastPrune.Add(-q.retention))
+			if err != nil {
+				q.logger.Error().LogErrorf("problem pruning webhook deliveries: %v", err)
+			} else if n > 0 {
+				q.logger.Logf("pruned %d delivered webhooks", n)
+			}
+		}
+	}
+}
+
+// deliverDue attempts each delivery which is due by now. Webhooks are called concurrently
+// (limited by WEBHOOK_MAX_WORKERS) and their outcomes are written once the batch finishes.
+//
+// Deliveries are claimed first so replicas sharing a database never attempt the same one.
+// A claim pushes the delivery's next attempt out by the lease, so deliveries claimed by a
+// replica which dies are attempted again once it passes.
+func (q *webhookQueue) deliverDue(now time.Time) error {
+	for {
+		due, err := q.repo.dueDeliveries(now, webhookQueueBatchSize)
+		if err != nil {
+			return err
+		}
+		deliveries := make([]*webhookDelivery, 0, len(due))
+		for i := range due {
+			claimed, err := q.repo.claimDelivery(due[i].ID, now, now.Add(q.lease))
+			if err != nil {
+				return fmt.Errorf("claiming delivery %s: %v", due[i].ID, err)
+			}
+			if claimed {
+				deliveries = append(deliveries, due[i])
+			}
+		}
+
+		secrets := make([][]string, len(deliveries))
+		for i := range deliveries {
+			secrets[i], err = q.signingSecrets(deliveries[i], now)
+			if err != nil {
+				return fmt.Errorf("reading signing secrets for delivery %s: %v", deliveries[i].ID, err)
+			}
+		}
+
+		attempts := make([]webhookAttempt, len(deliveries))
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				attempts[i] = q.send(deliveries[i], secrets[i])
+			}(i)
+		}
+		wg.Wait()
+
+		for i := range deliveries {
+			q.record(deliveries[i], attempts[i])
+		}
+		if len(due) < webhookQueueBatchSize {
+			return nil
+		}
+	}
+}
+
+type webhookAttempt struct {
+	attemptedAt time.Time
+	status      int
+	err         error
+}
+
+// signingSecrets returns the secrets of the watch or download subscriber d is for
+func (q *webhookQueue) signingSecrets(d *webhookDelivery, now time.Time) ([]string, error) {
+	if d.SubscriberID != "" {
+		if q.subscriberRepo == nil {
+			return nil, nil
+		}
+		return q.subscriberRepo.getSigningSecrets(d.SubscriberID, now)
+	}
+	if q.watchRepo == nil {
+		return nil, nil
+	}
+	return q.watchRepo.getSigningSecrets(d.WatchID, now)
+}
+
+// send hands the delivery to its notifier, signed with the watch's secrets. Each attempt is
+// signed with a new timestamp so retries aren't rejected as replays.
+func (q *webhookQueue) send(d *webhookDelivery, secrets []string) webhookAttempt {
+	attemptedAt := time.Now()
+
+	kind := d.Notifier
+	if kind == "" {
+		kind = webhookNotifierType
+	}
+	n, ok := q.notifiers[kind]
+	if !ok {
+		err := &invalidWebhookError{fmt.Sprintf("%s notifier isn't configured", kind)}
+		return webhookAttempt{attemptedAt: attemptedAt, err: err}
+	}
+	status, err := n.notify(notification{
+		deliveryID: d.ID,
+		watchID:    d.WatchID,
+		webhook:    d.Webhook,
+		authToken:  d.authToken,
+		body:       d.Body,
+		secrets:    secrets,
+	})
+	return webhookAttempt{attemptedAt: attemptedAt, status: status, err: err}
+}
+
+// record stores the outcome of an attempt and schedules the next one if the delivery failed
+func (q *webhookQueue) record(d *webhookDelivery, attempt webhookAttempt) {
+	if d.WatchID != "" {
+		if err := q.repo.recordWebhook(d.WatchID, attempt.attemptedAt, attempt.status); err != nil {
+			q.logger.Logf("async: problem writing watch (%s) webhook status: %v", d.WatchID, err)
+		}
+	}
+
+	d.Attempts++
+	d.LastAttemptAt = &attempt.attemptedAt
+	d.LastStatusCode = attempt.status
+	d.LastError = ""
+	d.NextAttemptAt = nil
+	d.UpdatedAt = attempt.attemptedAt
+
+	switch {
+	case attempt.err == nil:
+		d.Status = deliveryDelivered
+
+	case d.Attempts >= q.maxAttempts || !retryableWebhook(attempt.status, attempt.err):
+		d.Status = deliveryDead
+		d.LastError = attempt.err.Error()
+		q.logger.Warn().With(log.Fields{
+			"watchID":      log.String(d.WatchID),
+			"subscriberID": log.String(d.SubscriberID),
+			"deliveryID":   log.String(d.ID),
+		}).Logf("webhook delivery failed after %d attempts: %v", d.Attempts, attempt.err)
+
+	default:
+		next := attempt.attemptedAt.Add(q.backoffFor(d.Attempts))
+		d.Status = deliveryPending
+		d.LastError = attempt.err.Error()
+		d.NextAttemptAt = &next
+	}
+	if err := q.repo.updateDelivery(d); err != nil {
+		q.logger.Error().LogErrorf("problem updating webhook delivery %s: %v", d.ID, err)
+	}
+}
+
+// backoffFor returns how long to wait after the given number of failed attempts
+func (q *webhookQueue) backoffFor(attempts int) time.Duration {
+	backoff := q.backoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxWebhookRetryBackoff {
+			return maxWebhookRetryBackoff
+		}
+	}
+	return backoff
+}
+
+// retryableWebhook reports if a failed webhook call could succeed on another attempt.
+// Invalid webhook URLs and client errors other than timeouts and rate limits won't.
+func retryableWebhook(status int, err error) bool {
+	if status == 0 {
+		var urlErr *invalidWebhookError
+		return !errors.As(err, &urlErr)
+	}
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return status >= 500
+}
+
+// replay resets a dead delivery so it's attempted again
+func (q *webhookQueue) replay(deliveryID string) (*webhookDelivery, error) {
+	d, err := q.repo.getDelivery(deliveryID)
+	if err != nil || d == nil {
+		return nil, err
+	}
+	if d.Status != deliveryDead {
+		return nil, errDeliveryNotDead
+	}
+
+	now := time.Now()
+	d.Status = deliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = &now
+	d.UpdatedAt = now
+	if err := q.repo.updateDelivery(d); err != nil {
+		return nil, err
+	}
+	q.notify()
+	return d, nil
+}
+
+// Repository methods
+
+const webhookDeliveryColumns = `id, watch_id, subscriber_id, notifier, webhook, auth_token, status, attempts, next_attempt_at, last_attempt_at, last_status_code, last_error, created_at, updated_at`
+
+func (r *sqliteWebhookRepository) enqueueDelivery(d *webhookDelivery) error {
+	query := `insert into webhook_deliveries (id, watch_id, subscriber_id, notifier, webhook, auth_token, body, status, attempts, next_attempt_at, created_at, updated_at) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(d.ID, d.WatchID, d.SubscriberID, d.Notifier, d.Webhook, d.authToken, string(d.Body), d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt, d.UpdatedAt)
+	return err
+}
+
+func (r *sqliteWebhookRepository) updateDelivery(d *webhookDelivery) error {
+	query := `update webhook_deliveries set status = ?, attempts = ?, next_attempt_at = ?, last_attempt_at = ?, last_status_code = ?, last_error = ?, updated_at = ? where id = ?;`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(d.Status, d.Attempts, d.NextAttemptAt, d.LastAttemptAt, d.LastStatusCode, d.LastError, d.UpdatedAt, d.ID)
+	return err
+}
+
+// dueDeliveries returns pending deliveries whose next attempt is at or before now, oldest first
+func (r *sqliteWebhookRepository) dueDeliveries(now time.Time, limit int) ([]*webhookDelivery, error) {
+	query := `select ` + webhookDeliveryColumns + `, body from webhook_deliveries where status = ? and next_attempt_at <= ? order by next_attempt_at asc limit ?;`
+	return r.queryDeliveries(true, query, deliveryPending, now, limit)
+}
+
+// claimDelivery moves the next attempt of a due delivery to until and reports if it was still due,
+// so only one replica attempts it.
+func (r *sqliteWebhookRepository) claimDelivery(deliveryID string, now, until time.Time) (bool, error) {
+	query := `update webhook_deliveries set next_attempt_at = ?, updated_at = ? where id = ? and status = ? and next_attempt_at <= ?;`
+	res, err := r.db.Exec(query, until, now, deliveryID, deliveryPending, now)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// listDeliveries returns deliveries without their bodies, newest first
+func (r *sqliteWebhookRepository) listDeliveries(filter deliveryFilter) ([]*webhookDelivery, error) {
+	query := `select ` + webhookDeliveryColumns + ` from webhook_deliveries where 1=1`
+	var args []interface{}
+	if filter.status != "" {
+		query += ` and status = ?`
+		args = append(args, filter.status)
+	}
+	if filter.watchID != "" {
+		query += ` and watch_id = ?`
+		args = append(args, filter.watchID)
+	}
+	if filter.subscriberID != "" {
+		query += ` and subscriber_id = ?`
+		args = append(args, filter.subscriberID)
+	}
+	query += ` order by created_at desc limit ?;`
+	args = append(args, filter.limit)
+
+	return r.queryDeliveries(false, query, args...)
+}
+
+// getDelivery returns the delivery with its body, or nil if it doesn't exist
+func (r *sqliteWebhookRepository) getDelivery(deliveryID string) (*webhookDelivery, error) {
+	query := `select ` + webhookDeliveryColumns + `, body from webhook_deliveries where id = ? limit 1;`
+	deliveries, err := r.queryDeliveries(true, query, deliveryID)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	return deliveries[0], nil
+}
+
+// pruneDeliveries removes delivered webhooks last updated before the cutoff. Dead deliveries
+// are kept so they can be inspected and replayed.
+func (r *sqliteWebhookRepository) pruneDeliveries(before time.Time) (int64, error) {
+	query := `delete from webhook_deliveries where status = ? and updated_at < ?;`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(deliveryDelivered, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *sqliteWebhookRepository) queryDeliveries(withBody bool, query string, args ...interface{}) ([]*webhookDelivery, error) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*webhookDelivery
+	for rows.Next() {
+		var d webhookDelivery
+		var watchID, subscriberID, notifier, authToken, lastError, body sql.NullString
+		var nextAttemptAt, lastAttemptAt sql.NullTime
+		dest := []interface{}{
+			&d.ID, &watchID, &subscriberID, &notifier, &d.Webhook, &authToken, &d.Status, &d.Attempts, &nextAttemptAt, &lastAttemptAt,
+			&d.LastStatusCode, &lastError, &d.CreatedAt, &d.UpdatedAt,
+		}
+		if withBody {
+			dest = append(dest, &body)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		d.WatchID = watchID.String
+		d.SubscriberID = subscriberID.String
+		d.Notifier = notifierType(notifier.String)
+		if d.Notifier == "" {
+			d.Notifier = webhookNotifierType
+		}
+		d.authToken = authToken.String
+		d.LastError = lastError.String
+		if nextAttemptAt.Valid {
+			d.NextAttemptAt = &nextAttemptAt.Time
+		}
+		if lastAttemptAt.Valid {
+			d.LastAttemptAt = &lastAttemptAt.Time
+		}
+		if body.Valid && body.String != "" {
+			d.Body = json.RawMessage(body.String)
+		}
+		out = append(out, &d)
+	}
+	return out, rows.Err()
+}
+
+// Admin handlers
+
+func listWebhookDeliveriesHandler(logger log.Logger, repo webhookRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter := deliveryFilter{
+			status:       deliveryStatus(r.URL.Query().Get("status")),
+			watchID:      r.URL.Query().Get("watchID"),
+			subscriberID: r.URL.Query().Get("subscriberID"),
+			limit:        extractSearchLimit(r),
+		}
+		if filter.status != "" && !filter.status.valid() {
+			moovhttp.Problem(w, fmt.Errorf("invalid status %q", filter.status))
+			return
+		}
+		deliveries, err := repo.listDeliveries(filter)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+
+		if requestID := moovhttp.GetRequestID(r); requestID != "" {
+			logger.Info().With(log.Fields{
+				"requestID": log.String(requestID),
+			}).Log("list webhook deliveries")
+		}
+
+		if deliveries == nil {
+			deliveries = []*webhookDelivery{}
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(deliveries)
+	}
+}
+
+func getWebhookDeliveryHandler(logger log.Logger, repo webhookRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deliveryID := mux.Vars(r)["deliveryID"]
+		d, err := repo.getDelivery(deliveryID)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if d == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if requestID := moovhttp.GetRequestID(r); requestID != "" {
+			logger.Info().With(log.Fields{
+				"requestID":  log.String(requestID),
+				"deliveryID": log.String(deliveryID),
+			}).Log("get webhook delivery")
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(d)
+	}
+}
+
+func replayWebhookDeliveryHandler(logger log.Logger, queue *webhookQueue) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		deliveryID := mux.Vars(r)["deliveryID"]
+		d, err := queue.replay(deliveryID)
+		if err != nil {
+			moovhttp.Problem(w, err)
+			return
+		}
+		if d == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Info().With(log.Fields{
+			"requestID":  log.String(moovhttp.GetRequestID(r)),
+			"deliveryID": log.String(deliveryID),
+		}).Log("replaying webhook delivery")
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(d)
+	}
+}
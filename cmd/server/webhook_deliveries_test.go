@@ -0,0 +1,277 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestWebhookQueue(t *testing.T) *webhookQueue {
+	t.Helper()
+
+	db := database.CreateTestSqliteDB(t)
+	t.Cleanup(func() { db.Close() })
+
+	queue, err := newWebhookQueue(log.NewNopLogger(), &sqliteWebhookRepository{db.DB}, &sqliteWatchRepository{db.DB, log.NewNopLogger()}, &sqliteDownloadSubscriberRepository{db.DB})
+	require.NoError(t, err)
+	return queue
+}
+
+// trustTestServer makes webhookHTTPClient trust the certificate of server
+func trustTestServer(t *testing.T, server *httptest.Server) {
+	t.Helper()
+
+	tr, ok := webhookHTTPClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	ctr, ok := server.Client().Transport.(*http.Transport)
+	require.True(t, ok)
+
+	prev := tr.TLSClientConfig
+	tr.TLSClientConfig = &tls.Config{RootCAs: ctr.TLSClientConfig.RootCAs}
+	t.Cleanup(func() { tr.TLSClientConfig = prev })
+}
+
+func TestWebhookQueue__config(t *testing.T) {
+	queue, err := newWebhookQueue(log.NewNopLogger(), nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, defaultWebhookMaxAttempts, queue.maxAttempts)
+	require.Equal(t, defaultWebhookRetryBackoff, queue.backoff)
+
+	t.Setenv("WEBHOOK_MAX_ATTEMPTS", "0")
+	_, err = newWebhookQueue(log.NewNopLogger(), nil, nil, nil)
+	require.ErrorContains(t, err, "invalid WEBHOOK_MAX_ATTEMPTS")
+
+	t.Setenv("WEBHOOK_MAX_ATTEMPTS", "3")
+	t.Setenv("WEBHOOK_RETRY_BACKOFF", "later")
+	_, err = newWebhookQueue(log.NewNopLogger(), nil, nil, nil)
+	require.ErrorContains(t, err, "invalid WEBHOOK_RETRY_BACKOFF")
+
+	t.Setenv("WEBHOOK_RETRY_BACKOFF", "1m")
+	queue, err = newWebhookQueue(log.NewNopLogger(), nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, queue.maxAttempts)
+	require.Equal(t, time.Minute, queue.backoff)
+}
+
+func TestWebhookQueue__backoff(t *testing.T) {
+	queue := &webhookQueue{backoff: 30 * time.Second}
+	require.Equal(t, 30*time.Second, queue.backoffFor(1))
+	require.Equal(t, time.Minute, queue.backoffFor(2))
+	require.Equal(t, 4*time.Minute, queue.backoffFor(4))
+	require.Equal(t, maxWebhookRetryBackoff, queue.backoffFor(20))
+}
+
+func TestWebhookQueue__retryable(t *testing.T) {
+	require.True(t, retryableWebhook(0, errors.New("connection refused")))
+	require.False(t, retryableWebhook(0, &invalidWebhookError{"not an HTTPS url"}))
+	require.True(t, retryableWebhook(http.StatusTooManyRequests, errors.New("bogus status code")))
+	require.True(t, retryableWebhook(http.StatusBadGateway, errors.New("bogus status code")))
+	require.False(t, retryableWebhook(http.StatusNotFound, errors.New("bogus status code")))
+}
+
+func TestWebhookQueue__deliver(t *testing.T) {
+	var calls int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.Equal(t, "authToken", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	trustTestServer(t, server)
+
+	queue := createTestWebhookQueue(t)
+	d, err := queue.enqueue(watch{id: base.ID(), webhook: server.URL, authToken: "authToken"}, bytes.NewBufferString(`{"id":"306"}`+"\n"))
+	require.NoError(t, err)
+
+	// the first attempt fails and is retried after a backoff
+	now := time.Now()
+	require.NoError(t, queue.deliverDue(now))
+	d, err = queue.repo.getDelivery(d.ID)
+	require.NoError(t, err)
+	require.Equal(t, deliveryPending, d.Status)
+	require.Equal(t, 1, d.Attempts)
+	require.Equal(t, http.StatusServiceUnavailable, d.LastStatusCode)
+	require.NotEmpty(t, d.LastError)
+	require.True(t, d.NextAttemptAt.After(now))
+	require.JSONEq(t, `{"id":"306"}`, string(d.Body))
+
+	require.NoError(t, queue.deliverDue(now))
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	require.NoError(t, queue.deliverDue(now.Add(queue.backoff+time.Second)))
+	d, err = queue.repo.getDelivery(d.ID)
+	require.NoError(t, err)
+	require.Equal(t, deliveryDelivered, d.Status)
+	require.Equal(t, 2, d.Attempts)
+	require.Nil(t, d.NextAttemptAt)
+	require.Empty(t, d.LastError)
+}
+
+func TestWebhookQueue__deadAndReplay(t *testing.T) {
+	queue := createTestWebhookQueue(t)
+	queue.maxAttempts = 1
+
+	d, err := queue.enqueue(watch{id: base.ID(), webhook: "https://localhost/12345"}, bytes.NewBufferString(`{}`))
+	require.NoError(t, err)
+
+	_, err = queue.replay(d.ID)
+	require.ErrorIs(t, err, errDeliveryNotDead)
+
+	require.NoError(t, queue.deliverDue(time.Now()))
+	d, err = queue.repo.getDelivery(d.ID)
+	require.NoError(t, err)
+	require.Equal(t, deliveryDead, d.Status)
+	require.Nil(t, d.NextAttemptAt)
+
+	d, err = queue.replay(d.ID)
+	require.NoError(t, err)
+	require.Equal(t, deliveryPending, d.Status)
+	require.Zero(t, d.Attempts)
+
+	due, err := queue.repo.dueDeliveries(time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	d, err = queue.replay(base.ID())
+	require.NoError(t, err)
+	require.Nil(t, d)
+}
+
+func TestWebhookQueue__handlers(t *testing.T) {
+	queue := createTestWebhookQueue(t)
+	queue.maxAttempts = 1
+
+	watchID := base.ID()
+	dead, err := queue.enqueue(watch{id: watchID, webhook: "http://example.com/insecure", authToken: "secret"}, bytes.NewBufferString(`{"id":"306"}`))
+	require.NoError(t, err)
+	require.NoError(t, queue.deliverDue(time.Now()))
+	_, err = queue.enqueue(watch{id: base.ID(), webhook: "https://example.com"}, bytes.NewBufferString(`{}`))
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(webhookDeliveriesPath, listWebhookDeliveriesHandler(log.NewNopLogger(), queue.repo))
+	router.HandleFunc(webhookDeliveryPath, getWebhookDeliveryHandler(log.NewNopLogger(), queue.repo))
+	router.HandleFunc(webhookDeliveryReplayPath, replayWebhookDeliveryHandler(log.NewNopLogger(), queue))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/webhooks/deliveries?status=dead", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "secret")
+
+	var deliveries []webhookDelivery
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&deliveries))
+	require.Len(t, deliveries, 1)
+	require.Equal(t, dead.ID, deliveries[0].ID)
+	require.Equal(t, watchID, deliveries[0].WatchID)
+	require.Contains(t, deliveries[0].LastError, "is not an HTTPS url")
+	require.Empty(t, deliveries[0].Body)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/webhooks/deliveries?status=failed", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/webhooks/deliveries/"+dead.ID, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"body":{"id":"306"}`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/webhooks/deliveries/"+base.ID(), nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/webhooks/deliveries/"+dead.ID+"/replay", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks/deliveries/"+dead.ID+"/replay", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, strings.Contains(w.Body.String(), `"status":"pending"`))
+
+	// only dead deliveries are replayed
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks/deliveries/"+dead.ID+"/replay", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestWebhookDeliveries__repository(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, repo *sqliteWebhookRepository) {
+		now := time.Now().Truncate(time.Millisecond)
+		earlier := now.Add(-time.Hour)
+		d := &webhookDelivery{
+			ID:            base.ID(),
+			WatchID:       base.ID(),
+			Webhook:       "https://example.com",
+			authToken:     "authToken",
+			Body:          json.RawMessage(`{"id":"306"}`),
+			Status:        deliveryPending,
+			NextAttemptAt: &earlier,
+			CreatedAt:     earlier,
+			UpdatedAt:     earlier,
+		}
+		require.NoError(t, repo.enqueueDelivery(d))
+
+		due, err := repo.dueDeliveries(now, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		require.Equal(t, "authToken", due[0].authToken)
+
+		d.Status = deliveryDelivered
+		d.Attempts = 1
+		d.NextAttemptAt = nil
+		d.LastAttemptAt = &now
+		d.LastStatusCode = http.StatusOK
+		d.UpdatedAt = earlier
+		require.NoError(t, repo.updateDelivery(d))
+
+		due, err = repo.dueDeliveries(now, 10)
+		require.NoError(t, err)
+		require.Empty(t, due)
+
+		found, err := repo.listDeliveries(deliveryFilter{watchID: d.WatchID, limit: 10})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.Equal(t, deliveryDelivered, found[0].Status)
+		require.True(t, now.Equal(*found[0].LastAttemptAt))
+
+		n, err := repo.pruneDeliveries(now)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+
+		got, err := repo.getDelivery(d.ID)
+		require.NoError(t, err)
+		require.Nil(t, got)
+	}
+
+	// SQLite tests
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+	check(t, &sqliteWebhookRepository{sqliteDB.DB})
+
+	// MySQL tests
+	mysqlDB := database.TestMySQLConnection(t)
+	check(t, &sqliteWebhookRepository{mysqlDB})
+}
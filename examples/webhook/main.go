@@ -22,6 +22,8 @@ import (
 
 var (
 	httpAddr = flag.String("http.addr", ":10101", "HTTP listen address")
+
+	signingSecret = flag.String("signing.secret", os.Getenv("WEBHOOK_SIGNING_SECRET"), "Secret returned when creating the watch, used to verify webhook signatures")
 )
 
 func main() {
@@ -41,7 +43,10 @@ func main() {
 	// Setup HTTP handler
 	handler := mux.NewRouter()
 	addPingRoute(handler)
-	addWebhookRoute(logger, handler)
+	if *signingSecret == "" {
+		logger.Log("WARNING: webhook signatures aren't verified, set -signing.secret")
+	}
+	addWebhookRoute(logger, handler, *signingSecret)
 
 	// Create main HTTP server
 	serve := &http.Server{
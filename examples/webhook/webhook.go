@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/client"
 	"github.com/moov-io/watchman/pkg/ofac"
 
 	"github.com/gorilla/mux"
@@ -33,15 +34,34 @@ type Company struct {
 	Match     float64                   `json:"match,omitempty"`
 }
 
-func addWebhookRoute(logger log.Logger, r *mux.Router) {
+// addWebhookRoute handles webhooks from Watchman. When signingSecret is set (it's returned when
+// the watch is created) webhooks without a valid signature are rejected.
+func addWebhookRoute(logger log.Logger, r *mux.Router, signingSecret string) {
 	r.Methods("POST").Path("/ofac").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		bs, err := io.ReadAll(io.LimitReader(r.Body, 5*1024*1024))
+		r.Body = http.MaxBytesReader(w, r.Body, 5*1024*1024)
+
+		var bs []byte
+		var err error
+		if signingSecret != "" {
+			bs, err = client.VerifyWebhookRequest(r, signingSecret, client.DefaultWebhookTolerance)
+			if err != nil {
+				logger.Logf("rejected webhook: %v", err)
+
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(fmt.Sprintf(`{"error": "%s"}`, err)))
+				return
+			}
+		} else {
+			bs, err = io.ReadAll(r.Body)
+		}
 		if err != nil {
 			logger.Logf("problem reading request: %v", err)
 
 			w.Header().Set("Content-Type", "application/json; charset=utf-8")
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(fmt.Sprintf(`{"error": "%s"}`, err)))
+			return
 		}
 
 		if cust := readCustomer(bytes.NewReader(bs)); cust != nil {
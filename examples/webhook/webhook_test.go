@@ -9,10 +9,13 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/client"
 	"github.com/moov-io/watchman/pkg/ofac"
 
 	"github.com/gorilla/mux"
@@ -59,7 +62,7 @@ func TestWebhookRoute(t *testing.T) {
 	}
 
 	router := mux.NewRouter()
-	addWebhookRoute(logger, router)
+	addWebhookRoute(logger, router, "")
 
 	req := httptest.NewRequest("POST", "/ofac", &body)
 	router.ServeHTTP(w, req)
@@ -74,7 +77,7 @@ func TestWebhookRoute__bad(t *testing.T) {
 	logger := log.NewNopLogger()
 
 	router := mux.NewRouter()
-	addWebhookRoute(logger, router)
+	addWebhookRoute(logger, router, "")
 
 	// no body
 	w := httptest.NewRecorder()
@@ -96,3 +99,42 @@ func TestWebhookRoute__bad(t *testing.T) {
 		t.Errorf("bogus status code: %d", w.Code)
 	}
 }
+
+func TestWebhookRoute__signed(t *testing.T) {
+	logger := log.NewNopLogger()
+
+	router := mux.NewRouter()
+	addWebhookRoute(logger, router, "secret")
+
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(exampleCustomer); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	// unsigned
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/ofac", bytes.NewReader(body.Bytes())))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("bogus status code: %d", w.Code)
+	}
+
+	// signed with another secret
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/ofac", bytes.NewReader(body.Bytes()))
+	req.Header.Set(client.WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(client.WebhookSignatureHeader, client.SignWebhook("other", now, body.Bytes()))
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("bogus status code: %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/ofac", bytes.NewReader(body.Bytes()))
+	req.Header.Set(client.WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(client.WebhookSignatureHeader, client.SignWebhook("secret", now, body.Bytes()))
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("bogus status code: %d", w.Code)
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package bundle writes and reads offline data bundles. A bundle is a tar.gz archive of
+// every list's source files along with a manifest of their SHA-256 checksums. The manifest
+// is signed with an ed25519 key so servers without internet access can verify bundles
+// which were copied in from elsewhere.
+//
+// Archives hold manifest.json, then manifest.json.sig, then each file under data/.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FormatVersion is the version of the archive layout and manifest written by this package
+const FormatVersion = 1
+
+const (
+	idFormat = "20060102T150405Z"
+
+	manifestName  = "manifest.json"
+	signatureName = "manifest.json.sig"
+	dataDir       = "data"
+
+	// maxManifestSize limits how much is read before the manifest is verified
+	maxManifestSize = 1 << 20
+)
+
+var (
+	// ErrInvalidSignature is returned when a bundle's manifest wasn't signed by the expected key
+	ErrInvalidSignature = errors.New("bundle: invalid signature")
+)
+
+// Manifest describes the files in a bundle
+type Manifest struct {
+	// Version is the FormatVersion the bundle was written with
+	Version int `json:"version"`
+
+	// ID is the bundle's version, taken from when it was created
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// WatchmanVersion is the version of watchman which created the bundle
+	WatchmanVersion string `json:"watchmanVersion,omitempty"`
+
+	Lists map[string]List `json:"lists"`
+}
+
+// List holds the files of one list in a bundle
+type List struct {
+	Files []File `json:"files"`
+}
+
+// File is a data file in a bundle
+type File struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Filename is the name bundles are written under
+func Filename(id string) string {
+	return fmt.Sprintf("watchman-%s.tar.gz", id)
+}
+
+// Write creates a bundle of each list's files and signs it with key. Files are stored by
+// their base name, which must be unique across lists.
+func Write(w io.Writer, key ed25519.PrivateKey, watchmanVersion string, createdAt time.Time, lists map[string][]string) (*Manifest, error) {
+	createdAt = createdAt.In(time.UTC).Truncate(time.Second)
+	manifest := &Manifest{
+		Version:         FormatVersion,
+		ID:              createdAt.Format(idFormat),
+		CreatedAt:       createdAt,
+		WatchmanVersion: watchmanVersion,
+		Lists:           make(map[string]List),
+	}
+
+	paths := make(map[string]string)
+	for name, files := range lists {
+		var list List
+		for _, p := range files {
+			file, err := describe(p)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := paths[file.Name]; exists {
+				return nil, fmt.Errorf("bundle: %s is included more than once", file.Name)
+			}
+			paths[file.Name] = p
+			list.Files = append(list.Files, file)
+		}
+		manifest.Lists[name] = list
+	}
+
+	bs, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %v", err)
+	}
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(key, bs))
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := writeEntry(tw, manifestName, createdAt, int64(len(bs)), bytes.NewReader(bs)); err != nil {
+		return nil, err
+	}
+	if err := writeEntry(tw, signatureName, createdAt, int64(len(sig)), strings.NewReader(sig)); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeFile(tw, name, paths[name], createdAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("bundle: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("bundle: %v", err)
+	}
+	return manifest, nil
+}
+
+func describe(p string) (File, error) {
+	fd, err := os.Open(p)
+	if err != nil {
+		return File{}, fmt.Errorf("bundle: %v", err)
+	}
+	defer fd.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, fd)
+	if err != nil {
+		return File{}, fmt.Errorf("bundle: reading %s: %v", p, err)
+	}
+	return File{
+		Name:   filepath.Base(p),
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+		Size:   n,
+	}, nil
+}
+
+func writeFile(tw *tar.Writer, name, p string, modTime time.Time) error {
+	fd, err := os.Open(p)
+	if err != nil {
+		return fmt.Errorf("bundle: %v", err)
+	}
+	defer fd.Close()
+
+	info, err := fd.Stat()
+	if err != nil {
+		return fmt.Errorf("bundle: %v", err)
+	}
+	return writeEntry(tw, path.Join(dataDir, name), modTime, info.Size(), fd)
+}
+
+func writeEntry(tw *tar.Writer, name string, modTime time.Time, size int64, r io.Reader) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     size,
+		ModTime:  modTime,
+	})
+	if err != nil {
+		return fmt.Errorf("bundle: writing %s: %v", name, err)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("bundle: writing %s: %v", name, err)
+	}
+	return nil
+}
+
+// Extract verifies a bundle against key and writes its files into dir. The manifest's
+// signature is checked before any files are written and every file must match its
+// checksum. Files are given the bundle's creation time as their modification time.
+//
+// Callers are expected to remove dir when an error is returned.
+func Extract(r io.Reader, key ed25519.PublicKey, dir string) (*Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %v", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	manifestBytes, err := readEntry(tr, manifestName)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := readEntry(tr, signatureName)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := verify(manifestBytes, sig, key)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := make(map[string]File)
+	for _, list := range manifest.Lists {
+		for _, f := range list.Files {
+			if f.Name != filepath.Base(f.Name) || f.Name == "." || f.Name == ".." {
+				return nil, fmt.Errorf("bundle: invalid file name %q", f.Name)
+			}
+			expected[f.Name] = f
+		}
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bundle: %v", err)
+		}
+		dirName, name := path.Split(hdr.Name)
+		file, exists := expected[name]
+		if hdr.Typeflag != tar.TypeReg || dirName != dataDir+"/" || !exists {
+			return nil, fmt.Errorf("bundle: unexpected entry %s", hdr.Name)
+		}
+		delete(expected, name)
+
+		if err := extractFile(tr, filepath.Join(dir, name), file, manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	if len(expected) > 0 {
+		missing := make([]string, 0, len(expected))
+		for name := range expected {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("bundle: missing %s", strings.Join(missing, ", "))
+	}
+	return manifest, nil
+}
+
+// ReadManifest verifies a bundle against key and returns its manifest without reading any files
+func ReadManifest(r io.Reader, key ed25519.PublicKey) (*Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %v", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	manifestBytes, err := readEntry(tr, manifestName)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := readEntry(tr, signatureName)
+	if err != nil {
+		return nil, err
+	}
+	return verify(manifestBytes, sig, key)
+}
+
+func readEntry(tr *tar.Reader, name string) ([]byte, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("bundle: reading %s: %v", name, err)
+	}
+	if hdr.Name != name {
+		return nil, fmt.Errorf("bundle: expected %s but found %s", name, hdr.Name)
+	}
+	if hdr.Size > maxManifestSize {
+		return nil, fmt.Errorf("bundle: %s is too large", name)
+	}
+	bs, err := io.ReadAll(io.LimitReader(tr, maxManifestSize))
+	if err != nil {
+		return nil, fmt.Errorf("bundle: reading %s: %v", name, err)
+	}
+	return bs, nil
+}
+
+func verify(manifestBytes, sig []byte, key ed25519.PublicKey) (*Manifest, error) {
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	if !ed25519.Verify(key, manifestBytes, signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("bundle: reading manifest: %v", err)
+	}
+	if manifest.Version != FormatVersion {
+		return nil, fmt.Errorf("bundle: unsupported version %d", manifest.Version)
+	}
+	return &manifest, nil
+}
+
+func extractFile(r io.Reader, where string, file File, modTime time.Time) error {
+	fd, err := os.OpenFile(where, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("bundle: %v", err)
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(fd, h), io.LimitReader(r, file.Size+1))
+	if cerr := fd.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("bundle: extracting %s: %v", file.Name, err)
+	}
+	if n != file.Size {
+		return fmt.Errorf("bundle: %s is %d bytes but expected %d", file.Name, n, file.Size)
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != file.SHA256 {
+		return fmt.Errorf("bundle: %s has checksum %s but expected %s", file.Name, sum, file.SHA256)
+	}
+	return os.Chtimes(where, modTime, modTime)
+}
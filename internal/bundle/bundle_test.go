@@ -0,0 +1,161 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFiles(t *testing.T) map[string][]string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"sdn.csv": "1,NICOLAS MADURO MOROS\n",
+		"add.csv": "1,Caracas\n",
+		"dpl.txt": "Name\tStreet_Address\n",
+	}
+	for name, contents := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+	}
+	return map[string][]string{
+		"SDNs": {filepath.Join(dir, "sdn.csv"), filepath.Join(dir, "add.csv")},
+		"DPs":  {filepath.Join(dir, "dpl.txt")},
+	}
+}
+
+func TestBundle__WriteAndExtract(t *testing.T) {
+	pub, key, err := GenerateKey()
+	require.NoError(t, err)
+
+	when := time.Date(2023, time.March, 3, 9, 4, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	written, err := Write(&buf, key, "v0.24.0", when, writeTestFiles(t))
+	require.NoError(t, err)
+	require.Equal(t, "20230303T090400Z", written.ID)
+	require.Equal(t, "watchman-20230303T090400Z.tar.gz", Filename(written.ID))
+	require.Len(t, written.Lists["SDNs"].Files, 2)
+
+	manifest, err := ReadManifest(bytes.NewReader(buf.Bytes()), pub)
+	require.NoError(t, err)
+	require.Equal(t, written, manifest)
+
+	dir := t.TempDir()
+	manifest, err = Extract(bytes.NewReader(buf.Bytes()), pub, dir)
+	require.NoError(t, err)
+	require.Equal(t, written, manifest)
+
+	bs, err := os.ReadFile(filepath.Join(dir, "sdn.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "1,NICOLAS MADURO MOROS\n", string(bs))
+
+	info, err := os.Stat(filepath.Join(dir, "dpl.txt"))
+	require.NoError(t, err)
+	require.True(t, when.Equal(info.ModTime()))
+}
+
+func TestBundle__signature(t *testing.T) {
+	_, key, err := GenerateKey()
+	require.NoError(t, err)
+	other, _, err := GenerateKey()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = Write(&buf, key, "", time.Now(), writeTestFiles(t))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	_, err = Extract(bytes.NewReader(buf.Bytes()), other, dir)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	// nothing is extracted before the signature is checked
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestBundle__tampered(t *testing.T) {
+	pub, key, err := GenerateKey()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = Write(&buf, key, "", time.Now(), writeTestFiles(t))
+	require.NoError(t, err)
+
+	// rewrite the archive with different contents for sdn.csv
+	gz, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	var out bytes.Buffer
+	gzw := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gzw)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		bs, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		if hdr.Name == "data/sdn.csv" {
+			bs = []byte("1,NICOLAS MADURO MOROZ\n")
+			hdr.Size = int64(len(bs))
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err = tw.Write(bs)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	_, err = Extract(&out, pub, t.TempDir())
+	require.ErrorContains(t, err, "sdn.csv has checksum")
+}
+
+func TestBundle__duplicateNames(t *testing.T) {
+	_, key, err := GenerateKey()
+	require.NoError(t, err)
+
+	lists := writeTestFiles(t)
+	lists["CSL"] = lists["DPs"]
+
+	_, err = Write(io.Discard, key, "", time.Now(), lists)
+	require.ErrorContains(t, err, "dpl.txt is included more than once")
+}
+
+func TestBundle__keys(t *testing.T) {
+	pub, key, err := GenerateKey()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	bs, err := MarshalPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bundle.key"), bs, 0600))
+
+	bs, err = MarshalPublicKey(pub)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bundle.pub"), bs, 0644))
+
+	readKey, err := ReadPrivateKey(filepath.Join(dir, "bundle.key"))
+	require.NoError(t, err)
+	require.Equal(t, key, readKey)
+
+	readPub, err := ReadPublicKey(filepath.Join(dir, "bundle.pub"))
+	require.NoError(t, err)
+	require.Equal(t, pub, readPub)
+
+	_, err = ReadPublicKey(filepath.Join(dir, "bundle.key"))
+	require.Error(t, err)
+}
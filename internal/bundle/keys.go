@@ -0,0 +1,82 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package bundle
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// GenerateKey creates a key pair for signing bundles
+func GenerateKey() (ed25519.PublicKey, ed25519.PrivateKey, error) {
+	return ed25519.GenerateKey(rand.Reader)
+}
+
+// MarshalPrivateKey encodes key as a PKCS #8 PEM block, which is the same format as
+// `openssl genpkey -algorithm ed25519` writes.
+func MarshalPrivateKey(key ed25519.PrivateKey) ([]byte, error) {
+	bs, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: bs}), nil
+}
+
+// MarshalPublicKey encodes key as a PKIX PEM block
+func MarshalPublicKey(key ed25519.PublicKey) ([]byte, error) {
+	bs, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: bs}), nil
+}
+
+// ReadPrivateKey reads a PEM encoded ed25519 private key from path
+func ReadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: reading %s: %v", path, err)
+	}
+	if k, ok := key.(ed25519.PrivateKey); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("bundle: %s is a %T, not an ed25519 key", path, key)
+}
+
+// ReadPublicKey reads a PEM encoded ed25519 public key from path
+func ReadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: reading %s: %v", path, err)
+	}
+	if k, ok := key.(ed25519.PublicKey); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("bundle: %s is a %T, not an ed25519 key", path, key)
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %v", err)
+	}
+	block, _ := pem.Decode(bs)
+	if block == nil {
+		return nil, fmt.Errorf("bundle: %s is not PEM encoded", path)
+	}
+	return block, nil
+}
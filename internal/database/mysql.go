@@ -80,6 +80,154 @@ var (
 			"add__bis_entities__to_download_stats",
 			"alter table download_stats add column bis_entities integer not null default 0;",
 		),
+		execsql(
+			"add__quality__to_download_stats",
+			"alter table download_stats add column quality text;",
+		),
+		execsql(
+			"add__freshness__to_download_stats",
+			"alter table download_stats add column freshness text;",
+		),
+		execsql(
+			"add__download_id__to_download_stats",
+			"alter table download_stats add column download_id varchar(40);",
+		),
+		execsql(
+			"add__changes__to_download_stats",
+			"alter table download_stats add column changes text;",
+		),
+		execsql(
+			"create_download_changes",
+			`create table if not exists download_changes(download_id varchar(40), list varchar(40), change_type varchar(10), entity_id varchar(100), name text);`,
+		),
+		execsql(
+			"create_download_changes__download_id__index",
+			"create index download_changes__download_id on download_changes(download_id);",
+		),
+		execsql(
+			"create_webhook_deliveries",
+			`create table if not exists webhook_deliveries(id varchar(40) primary key, watch_id varchar(40), webhook varchar(512), auth_token varchar(128), body mediumtext, status varchar(10), attempts integer not null default 0, next_attempt_at timestamp(3) null, last_attempt_at timestamp(3) null, last_status_code integer not null default 0, last_error text, created_at timestamp(3), updated_at timestamp(3));`,
+		),
+		execsql(
+			"create_webhook_deliveries__status__index",
+			"create index webhook_deliveries__status on webhook_deliveries(status, next_attempt_at);",
+		),
+		execsql(
+			"create_watch_signing_secrets",
+			`create table if not exists watch_signing_secrets(watch_id varchar(40) primary key, secret varchar(128), previous_secret varchar(128), previous_expires_at timestamp(3) null, created_at timestamp(3), rotated_at timestamp(3) null);`,
+		),
+		execsql(
+			"add__min_match__to_customer_name_watches",
+			"alter table customer_name_watches add column min_match double null;",
+		),
+		execsql(
+			"add__min_match__to_company_name_watches",
+			"alter table company_name_watches add column min_match double null;",
+		),
+		execsql(
+			"add__lists__to_customer_watches",
+			"alter table customer_watches add column lists varchar(512) null;",
+		),
+		execsql(
+			"add__lists__to_customer_name_watches",
+			"alter table customer_name_watches add column lists varchar(512) null;",
+		),
+		execsql(
+			"add__lists__to_company_watches",
+			"alter table company_watches add column lists varchar(512) null;",
+		),
+		execsql(
+			"add__lists__to_company_name_watches",
+			"alter table company_name_watches add column lists varchar(512) null;",
+		),
+		execsql(
+			"add__notify_policy__to_customer_watches",
+			"alter table customer_watches add column notify_policy varchar(20) null;",
+		),
+		execsql(
+			"add__notify_policy__to_customer_name_watches",
+			"alter table customer_name_watches add column notify_policy varchar(20) null;",
+		),
+		execsql(
+			"add__notify_policy__to_company_watches",
+			"alter table company_watches add column notify_policy varchar(20) null;",
+		),
+		execsql(
+			"add__notify_policy__to_company_name_watches",
+			"alter table company_name_watches add column notify_policy varchar(20) null;",
+		),
+		execsql(
+			"create_watch_results",
+			`create table if not exists watch_results(watch_id varchar(40) primary key, results mediumtext, notified_at timestamp(3) null);`,
+		),
+		execsql(
+			"add__notifier__to_customer_watches",
+			"alter table customer_watches add column notifier varchar(20) null;",
+		),
+		execsql(
+			"add__notifier__to_customer_name_watches",
+			"alter table customer_name_watches add column notifier varchar(20) null;",
+		),
+		execsql(
+			"add__notifier__to_company_watches",
+			"alter table company_watches add column notifier varchar(20) null;",
+		),
+		execsql(
+			"add__notifier__to_company_name_watches",
+			"alter table company_name_watches add column notifier varchar(20) null;",
+		),
+		execsql(
+			"add__notifier__to_webhook_deliveries",
+			"alter table webhook_deliveries add column notifier varchar(20) null;",
+		),
+		execsql(
+			"create_leases",
+			`create table if not exists leases(name varchar(100) primary key, owner varchar(255), expires_at timestamp(3) null);`,
+		),
+		execsql(
+			"create_download_subscribers",
+			`create table if not exists download_subscribers(id varchar(40) primary key, webhook varchar(512), auth_token varchar(128), lists varchar(512) null, created_at timestamp(3), deleted_at timestamp(3) null);`,
+		),
+		execsql(
+			"add__subscriber_id__to_webhook_deliveries",
+			"alter table webhook_deliveries add column subscriber_id varchar(40) null;",
+		),
+		execsql(
+			"create_monitored_entities",
+			`create table if not exists monitored_entities(id varchar(128) not null, entity_type varchar(10) not null, name varchar(512), date_of_birth varchar(10) null, country varchar(64) null, identifiers text null, results mediumtext null, screened_at timestamp(3) null, created_at timestamp(3), updated_at timestamp(3), primary key (entity_type, id));`,
+		),
+		execsql(
+			"create_screening_alerts",
+			`create table if not exists screening_alerts(id varchar(40) primary key, origin varchar(10) not null default 'monitoring', monitored_type varchar(10), monitored_id varchar(128), search_query varchar(512) null, list varchar(40), source varchar(100), entity_id varchar(100), name varchar(512), match_score double, identifier_match boolean not null default false, country_match boolean not null default false, download_id varchar(40), entity mediumtext, status varchar(20) not null default 'open', assignee varchar(128) null, open_key varchar(64) null, created_at timestamp(3), updated_at timestamp(3) null);`,
+		),
+		execsql(
+			"create_screening_alerts__monitored__index",
+			"create index screening_alerts__monitored on screening_alerts(monitored_type, monitored_id, created_at);",
+		),
+		execsql(
+			"create_screening_alerts__status__index",
+			"create index screening_alerts__status on screening_alerts(status, created_at);",
+		),
+		execsql(
+			"create_screening_alerts__open_key__index",
+			"create unique index screening_alerts__open_key on screening_alerts(open_key);",
+		),
+		execsql(
+			"create_alert_comments",
+			`create table if not exists alert_comments(id varchar(40) primary key, alert_id varchar(40) not null, author varchar(128), body text, created_at timestamp(3));`,
+		),
+		execsql(
+			"create_alert_comments__alert_id__index",
+			"create index alert_comments__alert_id on alert_comments(alert_id, created_at);",
+		),
+		execsql(
+			"create_alert_audit_log",
+			`create table if not exists alert_audit_log(id varchar(40) primary key, alert_id varchar(40) not null, actor varchar(128), action varchar(20), previous_value varchar(512), value varchar(512), created_at timestamp(3));`,
+		),
+		execsql(
+			"create_alert_audit_log__alert_id__index",
+			"create index alert_audit_log__alert_id on alert_audit_log(alert_id, created_at);",
+		),
 	)
 )
 
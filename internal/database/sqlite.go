@@ -79,6 +79,154 @@ var (
 			"add__bis_entities__to_download_stats",
 			"alter table download_stats add column bis_entities default 0;",
 		),
+		execsql(
+			"add__quality__to_download_stats",
+			"alter table download_stats add column quality;",
+		),
+		execsql(
+			"add__freshness__to_download_stats",
+			"alter table download_stats add column freshness;",
+		),
+		execsql(
+			"add__download_id__to_download_stats",
+			"alter table download_stats add column download_id;",
+		),
+		execsql(
+			"add__changes__to_download_stats",
+			"alter table download_stats add column changes;",
+		),
+		execsql(
+			"create_download_changes",
+			`create table if not exists download_changes(download_id, list, change_type, entity_id, name);`,
+		),
+		execsql(
+			"create_download_changes__download_id__index",
+			"create index download_changes__download_id on download_changes(download_id);",
+		),
+		execsql(
+			"create_webhook_deliveries",
+			`create table if not exists webhook_deliveries(id primary key, watch_id, webhook, auth_token, body, status, attempts integer default 0, next_attempt_at datetime, last_attempt_at datetime, last_status_code integer default 0, last_error, created_at datetime, updated_at datetime);`,
+		),
+		execsql(
+			"create_webhook_deliveries__status__index",
+			"create index webhook_deliveries__status on webhook_deliveries(status, next_attempt_at);",
+		),
+		execsql(
+			"create_watch_signing_secrets",
+			`create table if not exists watch_signing_secrets(watch_id primary key, secret, previous_secret, previous_expires_at datetime, created_at datetime, rotated_at datetime);`,
+		),
+		execsql(
+			"add__min_match__to_customer_name_watches",
+			"alter table customer_name_watches add column min_match;",
+		),
+		execsql(
+			"add__min_match__to_company_name_watches",
+			"alter table company_name_watches add column min_match;",
+		),
+		execsql(
+			"add__lists__to_customer_watches",
+			"alter table customer_watches add column lists;",
+		),
+		execsql(
+			"add__lists__to_customer_name_watches",
+			"alter table customer_name_watches add column lists;",
+		),
+		execsql(
+			"add__lists__to_company_watches",
+			"alter table company_watches add column lists;",
+		),
+		execsql(
+			"add__lists__to_company_name_watches",
+			"alter table company_name_watches add column lists;",
+		),
+		execsql(
+			"add__notify_policy__to_customer_watches",
+			"alter table customer_watches add column notify_policy;",
+		),
+		execsql(
+			"add__notify_policy__to_customer_name_watches",
+			"alter table customer_name_watches add column notify_policy;",
+		),
+		execsql(
+			"add__notify_policy__to_company_watches",
+			"alter table company_watches add column notify_policy;",
+		),
+		execsql(
+			"add__notify_policy__to_company_name_watches",
+			"alter table company_name_watches add column notify_policy;",
+		),
+		execsql(
+			"create_watch_results",
+			`create table if not exists watch_results(watch_id primary key, results, notified_at datetime);`,
+		),
+		execsql(
+			"add__notifier__to_customer_watches",
+			"alter table customer_watches add column notifier;",
+		),
+		execsql(
+			"add__notifier__to_customer_name_watches",
+			"alter table customer_name_watches add column notifier;",
+		),
+		execsql(
+			"add__notifier__to_company_watches",
+			"alter table company_watches add column notifier;",
+		),
+		execsql(
+			"add__notifier__to_company_name_watches",
+			"alter table company_name_watches add column notifier;",
+		),
+		execsql(
+			"add__notifier__to_webhook_deliveries",
+			"alter table webhook_deliveries add column notifier;",
+		),
+		execsql(
+			"create_leases",
+			`create table if not exists leases(name primary key, owner, expires_at datetime);`,
+		),
+		execsql(
+			"create_download_subscribers",
+			`create table if not exists download_subscribers(id primary key, webhook, auth_token, lists, created_at datetime, deleted_at datetime);`,
+		),
+		execsql(
+			"add__subscriber_id__to_webhook_deliveries",
+			"alter table webhook_deliveries add column subscriber_id;",
+		),
+		execsql(
+			"create_monitored_entities",
+			`create table if not exists monitored_entities(id, entity_type, name, date_of_birth, country, identifiers, results, screened_at datetime, created_at datetime, updated_at datetime, primary key (entity_type, id));`,
+		),
+		execsql(
+			"create_screening_alerts",
+			`create table if not exists screening_alerts(id primary key, origin not null default 'monitoring', monitored_type, monitored_id, search_query, list, source, entity_id, name, match_score, identifier_match, country_match not null default false, download_id, entity, status not null default 'open', assignee, open_key, created_at datetime, updated_at datetime);`,
+		),
+		execsql(
+			"create_screening_alerts__monitored__index",
+			"create index screening_alerts__monitored on screening_alerts(monitored_type, monitored_id, created_at);",
+		),
+		execsql(
+			"create_screening_alerts__status__index",
+			"create index screening_alerts__status on screening_alerts(status, created_at);",
+		),
+		execsql(
+			"create_screening_alerts__open_key__index",
+			"create unique index screening_alerts__open_key on screening_alerts(open_key);",
+		),
+		execsql(
+			"create_alert_comments",
+			`create table if not exists alert_comments(id primary key, alert_id, author, body, created_at datetime);`,
+		),
+		execsql(
+			"create_alert_comments__alert_id__index",
+			"create index alert_comments__alert_id on alert_comments(alert_id, created_at);",
+		),
+		execsql(
+			"create_alert_audit_log",
+			`create table if not exists alert_audit_log(id primary key, alert_id, actor, action, previous_value, value, created_at datetime);`,
+		),
+		execsql(
+			"create_alert_audit_log__alert_id__index",
+			"create index alert_audit_log__alert_id on alert_audit_log(alert_id, created_at);",
+		),
 	)
 )
 
@@ -0,0 +1,122 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package schema checks the header rows of delimited list files against the
+// columns their readers expect.
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDrift is matched (with errors.Is) by errors for files whose header is missing columns a reader needs
+var ErrDrift = errors.New("schema drift")
+
+// DriftError lists the expected columns which couldn't be found in a file's header
+type DriftError struct {
+	Missing []string
+}
+
+func (e *DriftError) Error() string {
+	const limit = 5
+
+	var missing []string
+	for i := 0; i < len(e.Missing) && i < limit; i++ {
+		missing = append(missing, fmt.Sprintf("%q", e.Missing[i]))
+	}
+	if len(e.Missing) > limit {
+		missing = append(missing, fmt.Sprintf("and %d more", len(e.Missing)-limit))
+	}
+	return fmt.Sprintf("%v: header is missing %s", ErrDrift, strings.Join(missing, ", "))
+}
+
+func (e *DriftError) Is(target error) bool {
+	return target == ErrDrift
+}
+
+// Layout is where each expected column was found in a file
+type Layout struct {
+	indexes []int // -1 for optional columns which are missing
+	moved   bool
+}
+
+// Resolve finds each expected column by name in header. Columns which moved are read from
+// their new position by Row. A missing column is a *DriftError unless it's optional.
+func Resolve(expected, header []string, optional ...string) (*Layout, error) {
+	found := make(map[string]int, len(header))
+	for idx, name := range header {
+		key := Normalize(name)
+		if _, exists := found[key]; !exists {
+			found[key] = idx
+		}
+	}
+	skippable := make(map[string]bool, len(optional))
+	for _, name := range optional {
+		skippable[Normalize(name)] = true
+	}
+
+	layout := &Layout{indexes: make([]int, len(expected))}
+	var missing []string
+	for i, name := range expected {
+		idx, exists := found[Normalize(name)]
+		if !exists {
+			if !skippable[Normalize(name)] {
+				missing = append(missing, name)
+			}
+			idx = -1
+		}
+		layout.indexes[i] = idx
+		if idx != i {
+			layout.moved = true
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &DriftError{Missing: missing}
+	}
+	return layout, nil
+}
+
+// Row returns record with its columns in the expected order
+func (l *Layout) Row(record []string) []string {
+	if l == nil || !l.moved {
+		return record
+	}
+	out := make([]string, len(l.indexes))
+	for i, idx := range l.indexes {
+		if idx >= 0 && idx < len(record) {
+			out[i] = record[idx]
+		}
+	}
+	return out
+}
+
+// Drift describes each expected column which isn't at its expected position in header.
+// Columns appended after the expected ones are ignored.
+func Drift(expected, header []string) []string {
+	var out []string
+	for i := range expected {
+		if i >= len(header) {
+			out = append(out, fmt.Sprintf("column %d: expected %q, but it is missing", i, expected[i]))
+			continue
+		}
+		if Normalize(header[i]) != Normalize(expected[i]) {
+			out = append(out, fmt.Sprintf("column %d: expected %q, found %q", i, expected[i], header[i]))
+		}
+	}
+	return out
+}
+
+// Normalize lowercases a column name and drops everything but letters and digits,
+// so "Post/Zip Code" and "post_zip_code" are the same column.
+func Normalize(name string) string {
+	var buf strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	expected := []string{"name", "city", "post_code"}
+
+	layout, err := Resolve(expected, []string{"Name", "City", "Post Code", "extra"})
+	require.NoError(t, err)
+	row := []string{"a", "b", "c", "d"}
+	require.Equal(t, row, layout.Row(row))
+
+	layout, err = Resolve(expected, []string{"Post/Code", "extra", "NAME", "city"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, layout.Row([]string{"c", "d", "a", "b"}))
+	require.Equal(t, []string{"", "", "c"}, layout.Row([]string{"c"}))
+
+	layout, err = Resolve(expected, []string{"city", "name"}, "post_code")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", ""}, layout.Row([]string{"b", "a"}))
+
+	_, err = Resolve(expected, []string{"city"})
+	require.True(t, errors.Is(err, ErrDrift))
+	require.Equal(t, `schema drift: header is missing "name", "post_code"`, err.Error())
+
+	var nilLayout *Layout
+	require.Equal(t, row, nilLayout.Row(row))
+}
+
+func TestDriftError(t *testing.T) {
+	err := &DriftError{Missing: []string{"a", "b", "c", "d", "e", "f", "g"}}
+	require.Equal(t, `schema drift: header is missing "a", "b", "c", "d", "e", and 2 more`, err.Error())
+}
+
+func TestDrift(t *testing.T) {
+	require.Empty(t, Drift([]string{"a", "b"}, []string{"\ufeffA", " b ", "c"}))
+	require.Equal(t, []string{
+		`column 0: expected "a", found "b"`,
+		`column 1: expected "b", found "a"`,
+		`column 2: expected "c", but it is missing`,
+	}, Drift([]string{"a", "b", "c"}, []string{"b", "a"}))
+}
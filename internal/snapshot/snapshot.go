@@ -0,0 +1,324 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package snapshot stores the files of each data refresh on local disk as versioned,
+// content-addressed snapshots. File contents are kept once under their SHA-256 digest
+// so lists which don't change between refreshes don't use more space.
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when a snapshot doesn't exist
+var ErrNotFound = errors.New("snapshot not found")
+
+const idFormat = "20060102T150405Z"
+
+var validID = regexp.MustCompile(`^[0-9]{8}T[0-9]{6}Z(-[0-9]+)?$`)
+
+// Snapshot describes the files stored for each list during a refresh
+type Snapshot struct {
+	ID        string          `json:"id"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Lists     map[string]List `json:"lists"`
+}
+
+// List holds the files of one list in a snapshot
+type List struct {
+	// RefreshedAt is when the list's files were downloaded. Lists which failed to refresh
+	// carry their files forward from an older snapshot.
+	RefreshedAt time.Time `json:"refreshedAt"`
+
+	// Files are the raw files the list was read from
+	Files []File `json:"files,omitempty"`
+
+	// Parsed is the list's records after parsing
+	Parsed *File `json:"parsed,omitempty"`
+}
+
+// File is stored content addressed by its digest
+type File struct {
+	Name   string `json:"name"`
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
+
+// Size is the total size of every file referenced by the snapshot
+func (s Snapshot) Size() int64 {
+	var n int64
+	for _, list := range s.Lists {
+		for _, f := range list.Files {
+			n += f.Size
+		}
+		if list.Parsed != nil {
+			n += list.Parsed.Size
+		}
+	}
+	return n
+}
+
+// Retention limits how many snapshots are kept. Zero values don't limit.
+type Retention struct {
+	// MaxCount is how many of the newest snapshots are kept
+	MaxCount int
+	// MaxAge is how long snapshots are kept after they're created
+	MaxAge time.Duration
+}
+
+// Store keeps snapshots inside a directory
+type Store struct {
+	dir string
+	mu  sync.Mutex // protects snapshot IDs in Save
+
+	// writing is held by each Write and exclusively by Prune, so Prune doesn't remove the
+	// files of a snapshot which is being written
+	writing sync.RWMutex
+}
+
+// New opens (and creates if needed) a snapshot store in dir
+func New(dir string) (*Store, error) {
+	if dir == "" {
+		return nil, errors.New("snapshot: empty directory")
+	}
+	for _, sub := range []string{"blobs", "snapshots"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, fmt.Errorf("snapshot: %v", err)
+		}
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Put stores the contents of r under their digest
+func (s *Store) Put(name string, r io.Reader) (File, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, "blobs"), "put-*")
+	if err != nil {
+		return File{}, fmt.Errorf("snapshot: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return File{}, fmt.Errorf("snapshot: writing %s: %v", name, err)
+	}
+
+	file := File{
+		Name:   filepath.Base(name),
+		Digest: "sha256:" + hex.EncodeToString(h.Sum(nil)),
+		Size:   n,
+	}
+	path := s.blobPath(file.Digest)
+	if _, err := os.Stat(path); err == nil {
+		return file, nil // already stored
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return File{}, fmt.Errorf("snapshot: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return File{}, fmt.Errorf("snapshot: storing %s: %v", name, err)
+	}
+	return file, nil
+}
+
+// PutFile stores the file at path
+func (s *Store) PutFile(path string) (File, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return File{}, fmt.Errorf("snapshot: %v", err)
+	}
+	defer fd.Close()
+	return s.Put(path, fd)
+}
+
+// Open returns the contents of a stored file
+func (s *Store) Open(file File) (io.ReadCloser, error) {
+	fd, err := os.Open(s.blobPath(file.Digest))
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: opening %s: %v", file.Name, err)
+	}
+	return fd, nil
+}
+
+func (s *Store) blobPath(digest string) string {
+	hash := strings.TrimPrefix(digest, "sha256:")
+	if len(hash) < 2 {
+		hash = "__" + hash
+	}
+	return filepath.Join(s.dir, "blobs", hash[:2], hash)
+}
+
+// Write runs fn, which stores files with Put and records them in a snapshot with Save. Prune waits
+// for fn to return, so files which are stored or carried forward from another snapshot aren't removed
+// before fn saves the snapshot using them.
+func (s *Store) Write(fn func() error) error {
+	s.writing.RLock()
+	defer s.writing.RUnlock()
+
+	return fn()
+}
+
+// Save records a new snapshot created at createdAt. Every file must already be stored with Put.
+func (s *Store) Save(createdAt time.Time, lists map[string]List) (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := &Snapshot{
+		CreatedAt: createdAt.UTC(),
+		Lists:     lists,
+	}
+	base := snap.CreatedAt.Format(idFormat)
+	snap.ID = base
+	for i := 2; ; i++ {
+		if _, err := os.Stat(s.manifestPath(snap.ID)); os.IsNotExist(err) {
+			break
+		}
+		snap.ID = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	bs, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %v", err)
+	}
+	tmp := s.manifestPath(snap.ID) + ".tmp"
+	if err := os.WriteFile(tmp, bs, 0644); err != nil {
+		return nil, fmt.Errorf("snapshot: %v", err)
+	}
+	if err := os.Rename(tmp, s.manifestPath(snap.ID)); err != nil {
+		return nil, fmt.Errorf("snapshot: %v", err)
+	}
+	return snap, nil
+}
+
+func (s *Store) manifestPath(id string) string {
+	return filepath.Join(s.dir, "snapshots", id+".json")
+}
+
+// Get returns a snapshot by its ID
+func (s *Store) Get(id string) (*Snapshot, error) {
+	if !validID.MatchString(id) {
+		return nil, ErrNotFound
+	}
+	bs, err := os.ReadFile(s.manifestPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("snapshot: %v", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(bs, &snap); err != nil {
+		return nil, fmt.Errorf("snapshot: reading %s: %v", id, err)
+	}
+	return &snap, nil
+}
+
+// List returns every snapshot, newest first
+func (s *Store) List() ([]*Snapshot, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, "snapshots"))
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %v", err)
+	}
+	var out []*Snapshot
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+		snap, err := s.Get(id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		out = append(out, snap)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID > out[j].ID
+		}
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+// Latest returns the newest snapshot, or nil if there are none
+func (s *Store) Latest() (*Snapshot, error) {
+	snaps, err := s.List()
+	if err != nil || len(snaps) == 0 {
+		return nil, err
+	}
+	return snaps[0], nil
+}
+
+// Prune removes the snapshots outside of retention as of now along with any stored
+// files no remaining snapshot uses. The newest snapshot is always kept.
+//
+// Files are only stored safely while Prune runs when they're stored within Write.
+func (s *Store) Prune(retention Retention, now time.Time) ([]string, error) {
+	s.writing.Lock()
+	defer s.writing.Unlock()
+
+	snaps, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	inUse := make(map[string]bool)
+	for i, snap := range snaps {
+		expired := retention.MaxCount > 0 && i >= retention.MaxCount
+		if retention.MaxAge > 0 && now.Sub(snap.CreatedAt) > retention.MaxAge {
+			expired = true
+		}
+		if i > 0 && expired {
+			if err := os.Remove(s.manifestPath(snap.ID)); err != nil && !os.IsNotExist(err) {
+				return removed, fmt.Errorf("snapshot: removing %s: %v", snap.ID, err)
+			}
+			removed = append(removed, snap.ID)
+			continue
+		}
+		for _, list := range snap.Lists {
+			for _, f := range list.Files {
+				inUse[s.blobPath(f.Digest)] = true
+			}
+			if list.Parsed != nil {
+				inUse[s.blobPath(list.Parsed.Digest)] = true
+			}
+		}
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	err = filepath.WalkDir(filepath.Join(s.dir, "blobs"), func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || inUse[path] {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), "put-") {
+			return nil // being written by Put
+		}
+		return os.Remove(path)
+	})
+	if err != nil {
+		return removed, fmt.Errorf("snapshot: removing unused files: %v", err)
+	}
+	return removed, nil
+}
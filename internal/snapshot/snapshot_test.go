@@ -0,0 +1,162 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore__PutAndOpen(t *testing.T) {
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	first, err := store.Put("sdn.csv", strings.NewReader("a,b,c"))
+	require.NoError(t, err)
+	require.Equal(t, "sdn.csv", first.Name)
+	require.Equal(t, int64(5), first.Size)
+	require.True(t, strings.HasPrefix(first.Digest, "sha256:"))
+
+	// the same contents are stored once
+	second, err := store.Put("other/sdn.csv", strings.NewReader("a,b,c"))
+	require.NoError(t, err)
+	require.Equal(t, first.Digest, second.Digest)
+
+	r, err := store.Open(first)
+	require.NoError(t, err)
+	defer r.Close()
+	bs, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "a,b,c", string(bs))
+}
+
+func TestStore__SaveAndList(t *testing.T) {
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	file, err := store.Put("sdn.csv", strings.NewReader("a,b,c"))
+	require.NoError(t, err)
+
+	when := time.Date(2023, time.March, 3, 9, 4, 0, 0, time.UTC)
+	lists := map[string]List{
+		"SDNs": {RefreshedAt: when, Files: []File{file}},
+	}
+	first, err := store.Save(when, lists)
+	require.NoError(t, err)
+	require.Equal(t, "20230303T090400Z", first.ID)
+	require.Equal(t, int64(5), first.Size())
+
+	// a second snapshot in the same second gets its own ID
+	second, err := store.Save(when, lists)
+	require.NoError(t, err)
+	require.Equal(t, "20230303T090400Z-2", second.ID)
+
+	snaps, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, snaps, 2)
+	require.Equal(t, second.ID, snaps[0].ID)
+
+	found, err := store.Get(first.ID)
+	require.NoError(t, err)
+	require.Equal(t, file.Digest, found.Lists["SDNs"].Files[0].Digest)
+
+	_, err = store.Get("20230101T000000Z")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = store.Get("../../etc/passwd")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore__Prune(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(dir)
+	require.NoError(t, err)
+
+	start := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	var ids []string
+	var files []File
+	for i := 0; i < 4; i++ {
+		file, err := store.Put("sdn.csv", strings.NewReader(strings.Repeat("x", i+1)))
+		require.NoError(t, err)
+		files = append(files, file)
+
+		snap, err := store.Save(start.Add(time.Duration(i)*24*time.Hour), map[string]List{
+			"SDNs": {Files: []File{file}},
+		})
+		require.NoError(t, err)
+		ids = append(ids, snap.ID)
+	}
+
+	// keep the newest three
+	removed, err := store.Prune(Retention{MaxCount: 3}, start.Add(4*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[0]}, removed)
+	_, err = os.Stat(store.blobPath(files[0].Digest))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(store.blobPath(files[1].Digest))
+	require.NoError(t, err)
+
+	// snapshots older than two days, but the newest is always kept
+	removed, err = store.Prune(Retention{MaxAge: 48 * time.Hour}, start.Add(10*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[2], ids[1]}, removed)
+
+	snaps, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, snaps, 1)
+	require.Equal(t, ids[3], snaps[0].ID)
+
+	blobs, err := filepath.Glob(filepath.Join(dir, "blobs", "*", "*"))
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+}
+
+func TestStore__PruneWaitsForWrite(t *testing.T) {
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	start := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		file, err := store.Put("sdn.csv", strings.NewReader(strings.Repeat("x", i+1)))
+		require.NoError(t, err)
+		_, err = store.Save(start.Add(time.Duration(i)*time.Hour), map[string]List{"SDNs": {Files: []File{file}}})
+		require.NoError(t, err)
+	}
+
+	pruned := make(chan []string)
+	err = store.Write(func() error {
+		file, err := store.Put("dpl.txt", strings.NewReader("not saved yet"))
+		require.NoError(t, err)
+
+		go func() {
+			removed, _ := store.Prune(Retention{MaxCount: 1}, start.Add(time.Hour))
+			pruned <- removed
+		}()
+		select {
+		case <-pruned:
+			t.Fatal("pruned while a snapshot was written")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		_, err = os.Stat(store.blobPath(file.Digest))
+		require.NoError(t, err)
+		_, err = store.Save(start.Add(2*time.Hour), map[string]List{"DPs": {Files: []File{file}}})
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, <-pruned, 2)
+
+	snaps, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, snaps, 1)
+	r, err := store.Open(snaps[0].Lists["DPs"].Files[0])
+	require.NoError(t, err)
+	r.Close()
+}
@@ -17,8 +17,16 @@ type CSL struct {
 	DTCs    []*DTC    // ITAR Debarred (DTC) - State Department
 	CMICs   []*CMIC   // Non-SDN Chinese Military-Industrial Complex Companies List (CMIC) - Treasury Department
 	NS_MBSs []*NS_MBS // Non-SDN Menu-Based Sanctions List (NS-MBS List) - Treasury Department
+
+	// Skipped counts the rows left out while parsing, by reason
+	Skipped map[string]int
+	// HeaderDrift describes how the file's header row differs from CSLHeader
+	HeaderDrift []string
 }
 
+// SkipMalformedRow is recorded for rows the CSV reader couldn't parse
+const SkipMalformedRow = "malformed row"
+
 // This is the order of the columns in the CSL
 // Source: https://legacy.trade.gov/CSL_Download_Instructions.pdf
 const (
@@ -74,6 +82,8 @@ type EL struct {
 	SourceListURL string `json:"sourceListURL"`
 	// SourceInfoURL is a link to information about the list
 	SourceInfoURL string `json:"sourceInfoURL"`
+
+	Details
 }
 
 type MEU struct {
@@ -83,6 +93,8 @@ type MEU struct {
 	FRNotice  string `json:"FRNotice"`
 	StartDate string `json:"startDate"`
 	EndDate   string `json:"endDate"`
+
+	Details
 }
 
 // SSI is the Sectoral Sanctions Identifications List - Treasury Department
@@ -107,6 +119,8 @@ type SSI struct {
 	SourceListURL string `json:"sourceListURL"`
 	// SourceInfoURL is a link to information about the list
 	SourceInfoURL string `json:"sourceInfoURL"`
+
+	Details
 }
 
 type UVL struct {
@@ -115,6 +129,8 @@ type UVL struct {
 	Addresses     []string `json:"addresses"`
 	SourceListURL string   `json:"sourceListURL"`
 	SourceInfoURL string   `json:"sourceInfoURL"`
+
+	Details
 }
 
 type ISN struct {
@@ -127,6 +143,8 @@ type ISN struct {
 	SourceListURL         string   `json:"sourceListURL"`
 	AlternateNames        []string `json:"alternateNames,omitempty"`
 	SourceInfoURL         string   `json:"sourceInfoURL"`
+
+	Details
 }
 
 type FSE struct {
@@ -137,10 +155,10 @@ type FSE struct {
 	Name          string   `json:"name"`
 	Addresses     []string `json:"addresses,omitempty"`
 	SourceListURL string   `json:"sourceListURL"`
-	Citizenships  string   `json:"citizenships,omitempty"`
-	DatesOfBirth  string   `json:"datesOfBirth"`
 	SourceInfoURL string   `json:"sourceInfoURL"`
 	IDs           []string `json:"IDs"`
+
+	Details
 }
 
 type PLC struct {
@@ -153,9 +171,9 @@ type PLC struct {
 	Remarks        string   `json:"remarks"`
 	SourceListURL  string   `json:"sourceListURL"`
 	AlternateNames []string `json:"alternateNames,omitempty"`
-	DatesOfBirth   string   `json:"datesOfBirth,omitempty"`
-	PlacesOfBirth  string   `json:"placesOfBirth,omitempty"`
 	SourceInfoURL  string   `json:"sourceInfoURL"`
+
+	Details
 }
 
 type CAP struct {
@@ -170,6 +188,8 @@ type CAP struct {
 	AlternateNames []string `json:"alternamteNames,omitempty"`
 	SourceInfoURL  string   `json:"sourceInfoURL"`
 	IDs            []string `json:"IDs"`
+
+	Details
 }
 
 type DTC struct {
@@ -179,6 +199,8 @@ type DTC struct {
 	SourceListURL         string   `json:"sourceListURL"`
 	AlternateNames        []string `json:"alternateNames,omitempty"`
 	SourceInfoURL         string   `json:"sourceInfoURL"`
+
+	Details
 }
 
 type CMIC struct {
@@ -193,6 +215,8 @@ type CMIC struct {
 	AlternateNames []string `json:"alternateNames"`
 	SourceInfoURL  string   `json:"sourceInfoURL"`
 	IDs            []string `json:"IDs"`
+
+	Details
 }
 
 type NS_MBS struct {
@@ -206,4 +230,63 @@ type NS_MBS struct {
 	AlternateNames []string `json:"alternateNames,omitempty"`
 	SourceInfoURL  string   `json:"sourceInfoURL"`
 	IDs            []string `json:"IDs"`
+
+	Details
+}
+
+// CSLIdentification is a form of identification from the CSL "ids" column.
+//
+// Values are formatted as "[country, ]number, type" (e.g. "RU, 1137746390572, Registration ID").
+type CSLIdentification struct {
+	// Country is the ISO 3166-1 alpha-2 code of the issuing country, if known
+	Country string `json:"country,omitempty"`
+	// Number is the identifying value (e.g. passport or tax ID number)
+	Number string `json:"number"`
+	// Type describes the identification (e.g. Passport, Tax ID No.)
+	Type string `json:"type,omitempty"`
+}
+
+// Details are the citizenship, nationality, birth and identification data parsed from a US CSL record.
+// Every record type embeds them, so they're encoded alongside the record's other fields.
+type Details struct {
+	// Citizenships are the countries the individual holds citizenship in
+	Citizenships []string `json:"citizenships,omitempty"`
+	// DatesOfBirth are the full or partial (e.g. year only) dates of birth of the individual
+	DatesOfBirth []string `json:"datesOfBirth,omitempty"`
+	// Nationalities are the nationalities of the individual
+	Nationalities []string `json:"nationalities,omitempty"`
+	// PlacesOfBirth are the places of birth of the individual
+	PlacesOfBirth []string `json:"placesOfBirth,omitempty"`
+	// Identifications are the structured forms of identification on file for the entity
+	Identifications []CSLIdentification `json:"identifications,omitempty"`
+}
+
+// CSLDetails returns d, so records can be read through Detailed
+func (d Details) CSLDetails() Details {
+	return d
+}
+
+// ListedBirthDates returns the dates of birth listed for the entity
+func (d Details) ListedBirthDates() []string {
+	return d.DatesOfBirth
+}
+
+// ListedIDNumbers returns the numbers of the passports, tax IDs, etc listed for the entity
+func (d Details) ListedIDNumbers() []string {
+	var out []string
+	for i := range d.Identifications {
+		out = append(out, d.Identifications[i].Number)
+	}
+	return out
+}
+
+// ListedCountries returns the citizenships and nationalities listed for the entity
+func (d Details) ListedCountries() []string {
+	return append(append([]string{}, d.Citizenships...), d.Nationalities...)
+}
+
+// Detailed is implemented by every US CSL record type, so their details can be read without
+// knowing which list a record is from.
+type Detailed interface {
+	CSLDetails() Details
 }
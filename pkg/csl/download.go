@@ -21,6 +21,9 @@ var (
 
 func Download(logger log.Logger, initialDir string) (string, error) {
 	dl := download.New(logger, download.HTTPClient)
+	dl.Expectations = map[string]download.Expectations{
+		"csl.csv": {MinRecords: 1000},
+	}
 
 	cslURL, err := buildDownloadURL(usDownloadURL)
 	if err != nil {
@@ -7,6 +7,7 @@ package csl
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/base/strx"
@@ -20,13 +21,40 @@ var (
 	publicEUDownloadURL = fmt.Sprintf("https://webgate.ec.europa.eu/fsd/fsf/public/files/csvFullSanctionsList_1_1/content?token=%s", token)
 
 	euDownloadURL = strx.Or(os.Getenv("EU_CSL_DOWNLOAD_URL"), publicEUDownloadURL)
+
+	publicEUXMLDownloadURL = fmt.Sprintf("https://webgate.ec.europa.eu/fsd/fsf/public/files/xmlFullSanctionsList_1_1/content?token=%s", token)
+
+	euXMLDownloadURL = strx.Or(os.Getenv("EU_CSL_XML_DOWNLOAD_URL"), publicEUXMLDownloadURL)
 )
 
+const (
+	EUFormatCSV = "csv"
+	EUFormatXML = "xml"
+)
+
+// EUFormat returns which export of the EU list to download, read from EU_CSL_FORMAT.
+// The CSV export is used unless "xml" is set.
+func EUFormat() string {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("EU_CSL_FORMAT")), EUFormatXML) {
+		return EUFormatXML
+	}
+	return EUFormatCSV
+}
+
+// DownloadEU retrieves the EU Consolidated Sanctions List in the format from EUFormat,
+// as either eu_csl.csv or eu_csl.xml.
 func DownloadEU(logger log.Logger, initialDir string) (string, error) {
 	dl := download.New(logger, download.HTTPClient)
+	dl.Expectations = map[string]download.Expectations{
+		"eu_csl.csv": {MinRecords: 1000},
+	}
 
 	euCSLNameAndSource := make(map[string]string)
-	euCSLNameAndSource["eu_csl.csv"] = euDownloadURL
+	if EUFormat() == EUFormatXML {
+		euCSLNameAndSource["eu_csl.xml"] = euXMLDownloadURL
+	} else {
+		euCSLNameAndSource["eu_csl.csv"] = euDownloadURL
+	}
 
 	file, err := dl.GetFiles(initialDir, euCSLNameAndSource)
 	if len(file) == 0 || err != nil {
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEUDownload(t *testing.T) {
@@ -71,3 +72,19 @@ func TestEUDownload_initialDir(t *testing.T) {
 		t.Fatalf("unknown file: %v", file)
 	}
 }
+
+func TestEUDownload_xml(t *testing.T) {
+	t.Setenv("EU_CSL_FORMAT", "XML")
+	require.Equal(t, EUFormatXML, EUFormat())
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "eu_csl.xml"), []byte("<export/>"), 0600)
+	require.NoError(t, err)
+
+	file, err := DownloadEU(log.NewNopLogger(), dir)
+	require.NoError(t, err)
+	require.Equal(t, "eu_csl.xml", filepath.Base(file))
+
+	t.Setenv("EU_CSL_FORMAT", "")
+	require.Equal(t, EUFormatCSV, EUFormat())
+}
@@ -7,6 +7,7 @@ package csl
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/base/strx"
@@ -19,12 +20,35 @@ var (
 	ukCSLDownloadURL       = strx.Or(os.Getenv("UK_CSL_DOWNLOAD_URL"), publicUKCSLDownloadURL)
 
 	// https://www.gov.uk/government/publications/the-uk-sanctions-list
-	publicUKSanctionsListURL = "https://assets.publishing.service.gov.uk/government/uploads/system/uploads/attachment_data/file/1121113/UK_Sanctions_List.ods"
-	ukSanctionsListURL       = strx.Or(os.Getenv("UK_SANCTIONS_LIST_URL"), publicUKSanctionsListURL)
+	// The FCDO publishes the list at stable addresses in several formats.
+	publicUKSanctionsListURLs = map[string]string{
+		UKSanctionsListFormatCSV: "https://docs.fcdo.gov.uk/docs/UK-Sanctions-List.csv",
+		UKSanctionsListFormatXML: "https://docs.fcdo.gov.uk/docs/UK-Sanctions-List.xml",
+		UKSanctionsListFormatODS: "https://docs.fcdo.gov.uk/docs/UK-Sanctions-List.ods",
+	}
+)
+
+const (
+	UKSanctionsListFormatCSV = "csv"
+	UKSanctionsListFormatXML = "xml"
+	UKSanctionsListFormatODS = "ods"
 )
 
+// UKSanctionsListFormat returns which publication of the UK Sanctions List to download,
+// read from UK_SANCTIONS_LIST_FORMAT. The CSV publication is used by default.
+func UKSanctionsListFormat() string {
+	format := strings.ToLower(strings.TrimSpace(os.Getenv("UK_SANCTIONS_LIST_FORMAT")))
+	if _, exists := publicUKSanctionsListURLs[format]; exists {
+		return format
+	}
+	return UKSanctionsListFormatCSV
+}
+
 func DownloadUKCSL(logger log.Logger, initialDir string) (string, error) {
 	dl := download.New(logger, download.HTTPClient)
+	dl.Expectations = map[string]download.Expectations{
+		"ConList.csv": {MinRecords: 1000},
+	}
 
 	ukCSLNameAndSource := make(map[string]string)
 	ukCSLNameAndSource["ConList.csv"] = ukCSLDownloadURL
@@ -36,11 +60,19 @@ func DownloadUKCSL(logger log.Logger, initialDir string) (string, error) {
 	return file[0], nil
 }
 
+// DownloadUKSanctionsList retrieves the UK Sanctions List in the format from UKSanctionsListFormat.
+// UK_SANCTIONS_LIST_URL overrides where it's downloaded from.
 func DownloadUKSanctionsList(logger log.Logger, initialDir string) (string, error) {
 	dl := download.New(logger, download.HTTPClient)
+	dl.Expectations = map[string]download.Expectations{
+		"UK_Sanctions_List.csv": {MinRecords: 1000},
+	}
+
+	format := UKSanctionsListFormat()
+	url := strx.Or(os.Getenv("UK_SANCTIONS_LIST_URL"), publicUKSanctionsListURLs[format])
 
 	ukSanctionsNameAndSource := make(map[string]string)
-	ukSanctionsNameAndSource["UK_Sanctions_List.ods"] = ukSanctionsListURL
+	ukSanctionsNameAndSource["UK_Sanctions_List."+format] = url
 
 	file, err := dl.GetFiles(initialDir, ukSanctionsNameAndSource)
 	if len(file) == 0 || err != nil {
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUKCSLDownload(t *testing.T) {
@@ -87,12 +88,14 @@ func TestUKSanctionsListDownload(t *testing.T) {
 	}
 	defer os.RemoveAll(filepath.Dir(file))
 
-	if !strings.EqualFold("UK_Sanctions_List.ods", filepath.Base(file)) {
+	if !strings.EqualFold("UK_Sanctions_List.csv", filepath.Base(file)) {
 		t.Errorf("unknown file %s", file)
 	}
 }
 
 func TestUKSanctionsListDownload_initialDir(t *testing.T) {
+	t.Setenv("UK_SANCTIONS_LIST_FORMAT", "ods")
+
 	dir, err := os.MkdirTemp("", "iniital-dir")
 	if err != nil {
 		t.Fatal(err)
@@ -129,3 +132,14 @@ func TestUKSanctionsListDownload_initialDir(t *testing.T) {
 		t.Fatalf("unknown file: %v", file)
 	}
 }
+
+func TestUKSanctionsListFormat(t *testing.T) {
+	t.Setenv("UK_SANCTIONS_LIST_FORMAT", "")
+	require.Equal(t, UKSanctionsListFormatCSV, UKSanctionsListFormat())
+
+	t.Setenv("UK_SANCTIONS_LIST_FORMAT", "XML")
+	require.Equal(t, UKSanctionsListFormatXML, UKSanctionsListFormat())
+
+	t.Setenv("UK_SANCTIONS_LIST_FORMAT", "pdf")
+	require.Equal(t, UKSanctionsListFormatCSV, UKSanctionsListFormat())
+}
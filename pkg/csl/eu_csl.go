@@ -23,16 +23,32 @@ type EUCSLRecord struct {
 	BirthCities                []string          `json:"birthCities"`
 	BirthCountries             []string          `json:"birthCountries"`
 	ValidFromTo                map[string]string `json:"validFromTo"`
+
+	EntityUnitedNationID     string            `json:"entityUnitedNationId,omitempty"`
+	EntityDesignationDate    string            `json:"entityDesignationDate,omitempty"`
+	EntityDesignationDetails string            `json:"entityDesignationDetails,omitempty"`
+	EntityRegulation         *Regulation       `json:"entityRegulation,omitempty"`
+	Identifications          []*Identification `json:"identifications,omitempty"`
+	Citizenships             []*Citizenship    `json:"citizenships,omitempty"`
 }
 
 // header indicies
 const (
-	FileGenerationDateIdx             = 0
-	EntityLogicalIdx                  = 1
-	ReferenceNumberIdx                = 2
-	EntityRemarkIdx                   = 6
-	EntitySubjectTypeIdx              = 8
-	EntityRegulationPublicationURLIdx = 15
+	FileGenerationDateIdx                 = 0
+	EntityLogicalIdx                      = 1
+	ReferenceNumberIdx                    = 2
+	EntityUnitedNationIDIdx               = 3
+	EntityDesignationDateIdx              = 4
+	EntityDesignationDetailsIdx           = 5
+	EntityRemarkIdx                       = 6
+	EntitySubjectTypeIdx                  = 8
+	EntityRegulationTypeIdx               = 9
+	EntityRegulationOrganisationTypeIdx   = 10
+	EntityRegulationPublicationDateIdx    = 11
+	EntityRegulationEntryIntoForceDateIdx = 12
+	EntityRegulationNumberTitleIdx        = 13
+	EntityRegulationProgrammeIdx          = 14
+	EntityRegulationPublicationURLIdx     = 15
 
 	NameAliasWholeNameIdx = 19
 	NameAliasTitleIdx     = 22
@@ -47,45 +63,68 @@ const (
 	BirthDateCityIdx    = 65
 	BirthDateCountryIdx = 67
 
-	IdentificationValidFromIdx = 86
-	IdentificationValidToIdx   = 87
+	IdentificationNumberIdx             = 78
+	IdentificationDiplomaticIdx         = 79
+	IdentificationKnownExpiredIdx       = 80
+	IdentificationKnownFalseIdx         = 81
+	IdentificationReportedLostIdx       = 82
+	IdentificationRevokedByIssuerIdx    = 83
+	IdentificationIssuedByIdx           = 84
+	IdentificationIssuedDateIdx         = 85
+	IdentificationValidFromIdx          = 86
+	IdentificationValidToIdx            = 87
+	IdentificationNameOnDocumentIdx     = 89
+	IdentificationTypeCodeIdx           = 90
+	IdentificationTypeDescriptionIdx    = 91
+	IdentificationRegionIdx             = 92
+	IdentificationCountryIso2CodeIdx    = 93
+	IdentificationCountryDescriptionIdx = 94
+	IdentificationLogicalIdx            = 95
+	IdentificationRemarkIdx             = 97
+
+	CitizenshipRegionIdx             = 105
+	CitizenshipCountryIso2CodeIdx    = 106
+	CitizenshipCountryDescriptionIdx = 107
+	CitizenshipLogicalIdx            = 108
+	CitizenshipRemarkIdx             = 110
 )
 
-// below is the original struct used to parse the document
+// EUCSLRow is a single line of the EU CSV file. The file repeats an entity once for
+// each name alias, address, birth date, identification and citizenship, so ParseEU
+// reads each line into an EUCSLRow and then merges the rows into an EUCSLRecord.
 // fields commented out are not parsed
-// this was refactored to be a flatter structure but is left in for documentation
-// use the EUCSLRecord struct above
 type EUCSLRow struct {
-	FileGenerationDate string            `json:"fileGenerationDate"`
-	Entity             *Entity           `json:"entity"`
-	NameAliases        []*NameAlias      `json:"nameAliases"`
-	Addresses          []*Address        `json:"addresses"`
-	BirthDates         []*BirthDate      `json:"birthDates"`
-	Identifications    []*Identification `json:"identifications"`
+	FileGenerationDate string          `json:"fileGenerationDate"`
+	Entity             *Entity         `json:"entity"`
+	NameAlias          *NameAlias      `json:"nameAlias"`
+	Address            *Address        `json:"address"`
+	BirthDate          *BirthDate      `json:"birthDate"`
+	Identification     *Identification `json:"identification"`
+	Citizenship        *Citizenship    `json:"citizenship"`
 }
 
 type Entity struct {
-	LogicalID       int    `json:"logicalId"`
-	ReferenceNumber string `json:"referenceNumber"`
-	// UnitiedNationsID   string
-	// DesignationDate    string
-	// DesignationDetails string
-	Remark      string       `json:"remark"`
-	SubjectType *SubjectType `json:"subjectType"`
-	Regulation  *Regulation  `json:"regulation"`
+	LogicalID          int          `json:"logicalId"`
+	ReferenceNumber    string       `json:"referenceNumber"`
+	UnitedNationID     string       `json:"unitedNationId"`
+	DesignationDate    string       `json:"designationDate"`
+	DesignationDetails string       `json:"designationDetails"`
+	Remark             string       `json:"remark"`
+	SubjectType        *SubjectType `json:"subjectType"`
+	Regulation         *Regulation  `json:"regulation"`
 }
 type SubjectType struct {
 	// SingleLetter       string
 	ClassificationCode string `json:"classificationCode"`
 }
 type Regulation struct {
-	// Type               string
-	// OrganizationType   string
-	// PublicationDate    string
-	// EntryInfoForceDate string
-	// NumberTitle        string
-	// Programme          string
-	PublicationURL string `json:"publicationURL"`
+	Type               string `json:"type"`
+	OrganisationType   string `json:"organisationType"`
+	PublicationDate    string `json:"publicationDate"`
+	EntryIntoForceDate string `json:"entryIntoForceDate"`
+	NumberTitle        string `json:"numberTitle"`
+	Programme          string `json:"programme"`
+	PublicationURL     string `json:"publicationURL"`
 }
 
 type NameAlias struct { // AltNames
@@ -137,25 +176,65 @@ type BirthDate struct {
 	// Regulation         *Regulation
 }
 
+// Identification is an identity document (passport, national ID, etc) listed for an entity
 type Identification struct {
 	// Regulation         *Regulation
-	// Number             int64
-	// KnownExpired       bool
-	// KnownFalse         bool
-	// ReportedLost       bool
-	// RevokedByIssuer    bool
-	// LogicalID          int64
-	// Diplomatic         string
-	// IssuedBy           string
-	// IssuedDate         string
-	ValidFrom string `json:"validFrom"`
-	ValidTo   string `json:"validTo"`
-	// NameOnDocument     string
-	// TypeCode           string
-	// TypeDescription    string
-	// Region             string
-	// CountryIso2code    string
-	// CountryDescription string
+	LogicalID          int    `json:"logicalId"`
+	Number             string `json:"number"`
+	KnownExpired       bool   `json:"knownExpired"`
+	KnownFalse         bool   `json:"knownFalse"`
+	ReportedLost       bool   `json:"reportedLost"`
+	RevokedByIssuer    bool   `json:"revokedByIssuer"`
+	Diplomatic         bool   `json:"diplomatic"`
+	IssuedBy           string `json:"issuedBy"`
+	IssuedDate         string `json:"issuedDate"`
+	ValidFrom          string `json:"validFrom"`
+	ValidTo            string `json:"validTo"`
+	NameOnDocument     string `json:"nameOnDocument"`
+	TypeCode           string `json:"typeCode"`
+	TypeDescription    string `json:"typeDescription"`
+	Region             string `json:"region"`
+	CountryIso2Code    string `json:"countryIso2Code"`
+	CountryDescription string `json:"countryDescription"`
 	// RegulationLanguage string
-	// Remark             string
+	Remark string `json:"remark"`
+}
+
+// Citizenship is a country an entity is listed as a citizen of
+type Citizenship struct {
+	// Regulation         *Regulation
+	LogicalID          int    `json:"logicalId"`
+	Region             string `json:"region"`
+	CountryIso2Code    string `json:"countryIso2Code"`
+	CountryDescription string `json:"countryDescription"`
+	// RegulationLanguage string
+	Remark string `json:"remark"`
+}
+
+// ListedBirthDates returns the dates of birth listed for the entity
+func (r EUCSLRecord) ListedBirthDates() []string {
+	return r.BirthDates
+}
+
+// ListedIDNumbers returns the numbers of the passports, national IDs, etc listed for the entity
+func (r EUCSLRecord) ListedIDNumbers() []string {
+	var out []string
+	for _, id := range r.Identifications {
+		if id != nil {
+			out = append(out, id.Number)
+		}
+	}
+	return out
+}
+
+// ListedCountries returns the citizenships, countries of birth and address countries listed for the entity
+func (r EUCSLRecord) ListedCountries() []string {
+	var out []string
+	for _, c := range r.Citizenships {
+		if c != nil {
+			out = append(out, c.CountryIso2Code, c.CountryDescription)
+		}
+	}
+	out = append(out, r.BirthCountries...)
+	return append(out, r.AddressCountryDescriptions...)
 }
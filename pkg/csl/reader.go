@@ -3,9 +3,12 @@ package csl
 import (
 	"encoding/csv"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strings"
+
+	"github.com/moov-io/watchman/internal/schema"
 )
 
 func ReadFile(path string) (*CSL, error) {
@@ -21,7 +24,10 @@ func ReadFile(path string) (*CSL, error) {
 func Parse(r io.Reader) (*CSL, error) {
 	reader := csv.NewReader(r)
 
-	var report CSL
+	report := CSL{
+		Skipped: make(map[string]int),
+	}
+	var layout *schema.Layout
 	for {
 		record, err := reader.Read()
 		if err != nil {
@@ -33,6 +39,7 @@ func Parse(r io.Reader) (*CSL, error) {
 			if errors.Is(err, csv.ErrFieldCount) ||
 				errors.Is(err, csv.ErrBareQuote) ||
 				errors.Is(err, csv.ErrQuote) {
+				report.Skipped[SkipMalformedRow]++
 				continue
 			}
 			return nil, err
@@ -42,6 +49,16 @@ func Parse(r io.Reader) (*CSL, error) {
 			continue // skip empty records
 		}
 
+		if expected, ok := cslHeader(record); ok {
+			report.HeaderDrift = HeaderDrift(expected, record)
+			layout, err = schema.Resolve(expected, record)
+			if err != nil {
+				return nil, fmt.Errorf("CSL: %w", err)
+			}
+			continue
+		}
+		record = layout.Row(record)
+
 		// CSL datafiles have added a unique identifier as the first column. Thus
 		// we need to check either column 0 or 1 contains the identifier.
 		for i := 0; i <= 1; i++ {
@@ -98,6 +115,22 @@ func Parse(r io.Reader) (*CSL, error) {
 	return &report, nil
 }
 
+// cslHeader reports if record is the header row and returns the columns expected in it,
+// which start with "_id" in the newer files.
+func cslHeader(record []string) ([]string, bool) {
+	names := make(map[string]bool, len(record))
+	for i := range record {
+		names[schema.Normalize(record[i])] = true
+	}
+	if !names["source"] || !names["name"] {
+		return nil, false
+	}
+	if names["id"] {
+		return append([]string{"_id"}, CSLHeader...), true
+	}
+	return CSLHeader, true
+}
+
 func unmarshalEL(row []string, offset int) *EL {
 	id := ""
 	if offset == 1 {
@@ -114,6 +147,8 @@ func unmarshalEL(row []string, offset int) *EL {
 		FRNotice:           row[FRNoticeIdx+offset],
 		SourceListURL:      row[SourceListURLIdx+offset],
 		SourceInfoURL:      row[SourceInformationURLIdx+offset],
+
+		Details: readDetails(row, offset),
 	}
 }
 
@@ -125,6 +160,8 @@ func unmarshalMEU(record []string, offset int) *MEU {
 		FRNotice:  record[FRNoticeIdx+offset],
 		StartDate: record[StartDateIdx+offset],
 		EndDate:   record[EndDateIdx+offset],
+
+		Details: readDetails(record, offset),
 	}
 }
 
@@ -140,6 +177,8 @@ func unmarshalSSI(record []string, offset int) *SSI {
 		IDsOnRecord:    expandField(record[IDsIdx+offset]),
 		SourceListURL:  record[SourceListURLIdx+offset],
 		SourceInfoURL:  record[SourceInformationURLIdx+offset],
+
+		Details: readDetails(record, offset),
 	}
 }
 
@@ -150,6 +189,8 @@ func unmarshalUVL(record []string, offset int) *UVL {
 		Addresses:     expandField(record[AddressesIdx+offset]),
 		SourceListURL: record[SourceListURLIdx+offset],
 		SourceInfoURL: record[SourceInformationURLIdx+offset],
+
+		Details: readDetails(record, offset),
 	}
 }
 
@@ -164,6 +205,8 @@ func unmarshalISN(record []string, offset int) *ISN {
 		SourceListURL:         record[SourceListURLIdx+offset],
 		AlternateNames:        expandField(record[AltNamesIdx+offset]),
 		SourceInfoURL:         record[SourceInformationURLIdx+offset],
+
+		Details: readDetails(record, offset),
 	}
 }
 
@@ -176,10 +219,10 @@ func unmarshalFSE(record []string, offset int) *FSE {
 		Name:          record[NameIdx+offset],
 		Addresses:     expandField(record[AddressesIdx+offset]),
 		SourceListURL: record[SourceListURLIdx+offset],
-		Citizenships:  record[CitizenshipsIdx+offset],
-		DatesOfBirth:  record[DatesOfBirthIdx+offset],
 		SourceInfoURL: record[SourceInformationURLIdx+offset],
 		IDs:           expandField(record[IDsIdx+offset]),
+
+		Details: readDetails(record, offset),
 	}
 }
 
@@ -194,9 +237,9 @@ func unmarshalPLC(record []string, offset int) *PLC {
 		Remarks:        record[RemarksIdx+offset],
 		SourceListURL:  record[SourceListURLIdx+offset],
 		AlternateNames: expandField(record[AltNamesIdx+offset]),
-		DatesOfBirth:   record[DatesOfBirthIdx+offset],
-		PlacesOfBirth:  record[PlacesOfBirthIdx+offset],
 		SourceInfoURL:  record[SourceInformationURLIdx+offset],
+
+		Details: readDetails(record, offset),
 	}
 }
 
@@ -213,6 +256,8 @@ func unmarshalCAP(record []string, offset int) *CAP {
 		AlternateNames: expandField(record[AltNamesIdx+offset]),
 		SourceInfoURL:  record[SourceInformationURLIdx+offset],
 		IDs:            expandField(record[IDsIdx+offset]),
+
+		Details: readDetails(record, offset),
 	}
 }
 
@@ -228,6 +273,8 @@ func unmarshalNS_MBS(record []string, offset int) *NS_MBS {
 		AlternateNames: expandField(record[AltNamesIdx+offset]),
 		SourceInfoURL:  record[SourceInformationURLIdx+offset],
 		IDs:            expandField(record[IDsIdx+offset]),
+
+		Details: readDetails(record, offset),
 	}
 }
 
@@ -244,6 +291,8 @@ func unmarshalCMIC(record []string, offset int) *CMIC {
 		AlternateNames: expandField(record[AltNamesIdx+offset]),
 		SourceInfoURL:  record[SourceInformationURLIdx+offset],
 		IDs:            expandField(record[IDsIdx+offset]),
+
+		Details: readDetails(record, offset),
 	}
 }
 
@@ -255,9 +304,30 @@ func unmarshalDTC(record []string, offset int) *DTC {
 		SourceListURL:         record[SourceListURLIdx+offset],
 		AlternateNames:        expandField(record[AltNamesIdx+offset]),
 		SourceInfoURL:         record[SourceInformationURLIdx+offset],
+
+		Details: readDetails(record, offset),
 	}
 }
 
+// readDetails parses the citizenship, birth, nationality and ID columns every US CSL record has
+func readDetails(record []string, offset int) Details {
+	return Details{
+		Citizenships:    expandField(column(record, CitizenshipsIdx+offset)),
+		DatesOfBirth:    expandField(column(record, DatesOfBirthIdx+offset)),
+		Nationalities:   expandField(column(record, NationalitiesIdx+offset)),
+		PlacesOfBirth:   expandField(column(record, PlacesOfBirthIdx+offset)),
+		Identifications: expandIdentifications(column(record, IDsIdx+offset)),
+	}
+}
+
+// column returns the value at idx or an empty string for rows which are missing trailing columns.
+func column(record []string, idx int) string {
+	if idx < len(record) {
+		return record[idx]
+	}
+	return ""
+}
+
 // Some columns in a CSL row are actually lists delimited by ';'.
 // These helper methods split these fields out and clean up the results.
 
@@ -271,6 +341,47 @@ func expandField(addrs string) []string {
 	return result
 }
 
+// expandIdentifications parses the "ids" column, formatted as "[country, ]number, type" and
+// delimited by ';', into structured CSLIdentifications.
+func expandIdentifications(ids string) []CSLIdentification {
+	var result []CSLIdentification
+	for _, id := range expandField(ids) {
+		parts := strings.Split(id, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		if len(parts) == 1 {
+			result = append(result, CSLIdentification{Number: parts[0]})
+			continue
+		}
+
+		var ident CSLIdentification
+		ident.Type = parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+
+		if len(parts) > 1 && isCountryCode(parts[0]) {
+			ident.Country = parts[0]
+			parts = parts[1:]
+		}
+		ident.Number = strings.Join(parts, ", ")
+
+		result = append(result, ident)
+	}
+	return result
+}
+
+func isCountryCode(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
 var prgmReplacer = strings.NewReplacer("]", "", "[", "")
 
 func expandProgramsList(prgms string) []string {
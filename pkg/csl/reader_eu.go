@@ -6,25 +6,64 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/schema"
 )
 
+// EUParseReport describes the rows of an EU list file which could not be parsed
+type EUParseReport struct {
+	Format  string         `json:"format"`
+	Skipped []EUSkippedRow `json:"skipped"`
+
+	// HeaderDrift describes how the CSV header row differs from EUHeader
+	HeaderDrift []string `json:"headerDrift,omitempty"`
+}
+
+// EUSkippedRow is a row (CSV) or sanctionEntity (XML) left out of the parsed records
+type EUSkippedRow struct {
+	// Line is the line number the row started on
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+func (r *EUParseReport) skip(line int, reason string) {
+	r.Skipped = append(r.Skipped, EUSkippedRow{Line: line, Reason: reason})
+}
+
 func ReadEUFile(path string) ([]*EUCSLRecord, EUCSL, error) {
-	fd, err := os.Open(path)
+	rows, rowsMap, _, err := ReadEUFileWithReport(path)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer fd.Close()
+	return rows, rowsMap, nil
+}
 
-	rows, rowsMap, err := ParseEU(fd)
+// ReadEUFileWithReport parses an EU list file, choosing the CSV or XML parser from the
+// file's extension, and returns a report of the rows which were skipped.
+func ReadEUFileWithReport(path string) ([]*EUCSLRecord, EUCSL, *EUParseReport, error) {
+	fd, err := os.Open(path)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
+	defer fd.Close()
 
-	return rows, rowsMap, nil
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		return ParseEUXML(fd)
+	}
+	return ParseEUCSV(fd)
 }
 
 func ParseEU(r io.Reader) ([]*EUCSLRecord, EUCSL, error) {
+	rows, rowsMap, _, err := ParseEUCSV(r)
+	return rows, rowsMap, err
+}
+
+// ParseEUCSV reads the semicolon delimited EU export. Malformed rows are skipped
+// and recorded in the returned report.
+func ParseEUCSV(r io.Reader) ([]*EUCSLRecord, EUCSL, *EUParseReport, error) {
 	reader := csv.NewReader(r)
 	// sets comma delim to ; and ignores " in non quoted field and size of columns
 	// https://stackoverflow.com/questions/31326659/golang-csv-error-bare-in-non-quoted-field
@@ -33,9 +72,15 @@ func ParseEU(r io.Reader) ([]*EUCSLRecord, EUCSL, error) {
 	reader.LazyQuotes = true
 
 	report := make(EUCSL)
-	_, err := reader.Read()
+	parseReport := &EUParseReport{Format: EUFormatCSV}
+	header, err := reader.Read()
 	if err != nil {
-		return nil, report, fmt.Errorf("failed to read csv: %w", err)
+		return nil, report, parseReport, fmt.Errorf("failed to read csv: %w", err)
+	}
+	parseReport.HeaderDrift = HeaderDrift(EUHeader, header)
+	layout, err := schema.Resolve(EUHeader, header)
+	if err != nil {
+		return nil, nil, parseReport, fmt.Errorf("EU CSL: %w", err)
 	}
 	for {
 		record, err := reader.Read()
@@ -48,116 +93,218 @@ func ParseEU(r io.Reader) ([]*EUCSLRecord, EUCSL, error) {
 			if errors.Is(err, csv.ErrFieldCount) ||
 				errors.Is(err, csv.ErrBareQuote) ||
 				errors.Is(err, csv.ErrQuote) {
+				var perr *csv.ParseError
+				if errors.As(err, &perr) {
+					parseReport.skip(perr.StartLine, perr.Err.Error())
+				} else {
+					parseReport.skip(0, err.Error())
+				}
 				continue
 			}
-			return nil, nil, err
+			return nil, nil, parseReport, err
 		}
 
 		if len(record) <= 1 {
 			continue // skip empty records
 		}
+		record = layout.Row(record)
 
 		// merge rows at this point
 		// for each record we need to add that to the map
-		logicalID, _ := strconv.Atoi(record[EntityLogicalIdx])
-		// check if entry does not exist
-		if val, ok := report[logicalID]; !ok {
-			// creates the initial record
-			row := new(EUCSLRecord)
-			unmarshalRecord(record, row)
-
-			report[logicalID] = row
-		} else {
-			// we found an entry in the map and need to append
-			unmarshalRecord(record, val)
+		logicalID, err := strconv.Atoi(record[EntityLogicalIdx])
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			parseReport.skip(line, fmt.Sprintf("invalid Entity_LogicalId %q", record[EntityLogicalIdx]))
+			continue
 		}
+		mergeEUEntityRow(report, logicalID, unmarshalEURow(record))
+	}
+	return euRecords(report), report, parseReport, nil
+}
 
+// mergeEUEntityRow adds row to the record for logicalID, creating it if needed
+func mergeEUEntityRow(report EUCSL, logicalID int, row *EUCSLRow) {
+	// check if entry does not exist
+	if val, ok := report[logicalID]; !ok {
+		// creates the initial record
+		rec := new(EUCSLRecord)
+		mergeEURow(row, rec)
+
+		report[logicalID] = rec
+	} else {
+		// we found an entry in the map and need to append
+		mergeEURow(row, val)
 	}
+}
+
+func euRecords(report EUCSL) []*EUCSLRecord {
 	var totalReport []*EUCSLRecord
 	for _, row := range report {
 		totalReport = append(totalReport, row)
 	}
-	return totalReport, report, nil
+	return totalReport
 }
 
-func unmarshalRecord(csvRecord []string, euCSLRecord *EUCSLRecord) {
-	euCSLRecord.EntityLogicalID, _ = strconv.Atoi(csvRecord[EntityLogicalIdx])
-
-	// entity
-	if csvRecord[FileGenerationDateIdx] != "" {
-		euCSLRecord.FileGenerationDate = csvRecord[FileGenerationDateIdx]
-	}
-	if csvRecord[ReferenceNumberIdx] != "" {
-		euCSLRecord.EntityReferenceNumber = csvRecord[ReferenceNumberIdx]
-	}
-	if csvRecord[EntityRemarkIdx] != "" {
-		euCSLRecord.EntityRemark = csvRecord[EntityRemarkIdx]
-	}
-	if csvRecord[EntitySubjectTypeIdx] != "" {
-		euCSLRecord.EntitySubjectType = csvRecord[EntitySubjectTypeIdx]
-	}
-	if csvRecord[EntityRegulationPublicationURLIdx] != "" {
-		euCSLRecord.EntityPublicationURL = csvRecord[EntityRegulationPublicationURLIdx]
+// unmarshalEURow reads a single line of the EU CSV file into its structured form.
+func unmarshalEURow(csvRecord []string) *EUCSLRow {
+	row := &EUCSLRow{
+		FileGenerationDate: column(csvRecord, FileGenerationDateIdx),
+		Entity: &Entity{
+			ReferenceNumber:    column(csvRecord, ReferenceNumberIdx),
+			UnitedNationID:     column(csvRecord, EntityUnitedNationIDIdx),
+			DesignationDate:    column(csvRecord, EntityDesignationDateIdx),
+			DesignationDetails: column(csvRecord, EntityDesignationDetailsIdx),
+			Remark:             column(csvRecord, EntityRemarkIdx),
+			SubjectType: &SubjectType{
+				ClassificationCode: column(csvRecord, EntitySubjectTypeIdx),
+			},
+			Regulation: &Regulation{
+				Type:               column(csvRecord, EntityRegulationTypeIdx),
+				OrganisationType:   column(csvRecord, EntityRegulationOrganisationTypeIdx),
+				PublicationDate:    column(csvRecord, EntityRegulationPublicationDateIdx),
+				EntryIntoForceDate: column(csvRecord, EntityRegulationEntryIntoForceDateIdx),
+				NumberTitle:        column(csvRecord, EntityRegulationNumberTitleIdx),
+				Programme:          column(csvRecord, EntityRegulationProgrammeIdx),
+				PublicationURL:     column(csvRecord, EntityRegulationPublicationURLIdx),
+			},
+		},
+		NameAlias: &NameAlias{
+			WholeName: column(csvRecord, NameAliasWholeNameIdx),
+			Title:     column(csvRecord, NameAliasTitleIdx),
+		},
+		Address: &Address{
+			City:               column(csvRecord, AddressCityIdx),
+			Street:             column(csvRecord, AddressStreetIdx),
+			PoBox:              column(csvRecord, AddressPoBoxIdx),
+			ZipCode:            column(csvRecord, AddressZipCodeIdx),
+			CountryDescription: column(csvRecord, AddressCountryDescriptionIdx),
+		},
+		BirthDate: &BirthDate{
+			BirthDate:          column(csvRecord, BirthDateIdx),
+			City:               column(csvRecord, BirthDateCityIdx),
+			CountryDescription: column(csvRecord, BirthDateCountryIdx),
+		},
 	}
+	row.Entity.LogicalID, _ = strconv.Atoi(column(csvRecord, EntityLogicalIdx))
 
-	// name alias
-	if csvRecord[NameAliasWholeNameIdx] != "" {
-		if !arrayContains(euCSLRecord.NameAliasWholeNames, csvRecord[NameAliasWholeNameIdx]) {
-			euCSLRecord.NameAliasWholeNames = append(euCSLRecord.NameAliasWholeNames, csvRecord[NameAliasWholeNameIdx])
+	// identifications and citizenships are only present on some lines
+	if column(csvRecord, IdentificationLogicalIdx) != "" || column(csvRecord, IdentificationNumberIdx) != "" || column(csvRecord, IdentificationValidFromIdx) != "" {
+		id := &Identification{
+			Number:             column(csvRecord, IdentificationNumberIdx),
+			Diplomatic:         parseEUBool(column(csvRecord, IdentificationDiplomaticIdx)),
+			KnownExpired:       parseEUBool(column(csvRecord, IdentificationKnownExpiredIdx)),
+			KnownFalse:         parseEUBool(column(csvRecord, IdentificationKnownFalseIdx)),
+			ReportedLost:       parseEUBool(column(csvRecord, IdentificationReportedLostIdx)),
+			RevokedByIssuer:    parseEUBool(column(csvRecord, IdentificationRevokedByIssuerIdx)),
+			IssuedBy:           column(csvRecord, IdentificationIssuedByIdx),
+			IssuedDate:         column(csvRecord, IdentificationIssuedDateIdx),
+			ValidFrom:          column(csvRecord, IdentificationValidFromIdx),
+			ValidTo:            column(csvRecord, IdentificationValidToIdx),
+			NameOnDocument:     column(csvRecord, IdentificationNameOnDocumentIdx),
+			TypeCode:           column(csvRecord, IdentificationTypeCodeIdx),
+			TypeDescription:    column(csvRecord, IdentificationTypeDescriptionIdx),
+			Region:             column(csvRecord, IdentificationRegionIdx),
+			CountryIso2Code:    column(csvRecord, IdentificationCountryIso2CodeIdx),
+			CountryDescription: column(csvRecord, IdentificationCountryDescriptionIdx),
+			Remark:             column(csvRecord, IdentificationRemarkIdx),
 		}
+		id.LogicalID, _ = strconv.Atoi(column(csvRecord, IdentificationLogicalIdx))
+		row.Identification = id
 	}
-	if csvRecord[NameAliasTitleIdx] != "" {
-		if !arrayContains(euCSLRecord.NameAliasTitles, csvRecord[NameAliasTitleIdx]) {
-			euCSLRecord.NameAliasTitles = append(euCSLRecord.NameAliasTitles, csvRecord[NameAliasTitleIdx])
+	if column(csvRecord, CitizenshipLogicalIdx) != "" || column(csvRecord, CitizenshipCountryDescriptionIdx) != "" {
+		cit := &Citizenship{
+			Region:             column(csvRecord, CitizenshipRegionIdx),
+			CountryIso2Code:    column(csvRecord, CitizenshipCountryIso2CodeIdx),
+			CountryDescription: column(csvRecord, CitizenshipCountryDescriptionIdx),
+			Remark:             column(csvRecord, CitizenshipRemarkIdx),
 		}
+		cit.LogicalID, _ = strconv.Atoi(column(csvRecord, CitizenshipLogicalIdx))
+		row.Citizenship = cit
 	}
-	// address
-	if csvRecord[AddressCityIdx] != "" {
-		if !arrayContains(euCSLRecord.AddressCities, csvRecord[AddressCityIdx]) {
-			euCSLRecord.AddressCities = append(euCSLRecord.AddressCities, csvRecord[AddressCityIdx])
-		}
-	}
-	if csvRecord[AddressStreetIdx] != "" {
-		if !arrayContains(euCSLRecord.AddressStreets, csvRecord[AddressStreetIdx]) {
-			euCSLRecord.AddressStreets = append(euCSLRecord.AddressStreets, csvRecord[AddressStreetIdx])
-		}
-	}
-	if csvRecord[AddressPoBoxIdx] != "" {
-		if !arrayContains(euCSLRecord.AddressPoBoxes, csvRecord[AddressPoBoxIdx]) {
-			euCSLRecord.AddressPoBoxes = append(euCSLRecord.AddressPoBoxes, csvRecord[AddressPoBoxIdx])
-		}
+	return row
+}
+
+func parseEUBool(v string) bool {
+	b, _ := strconv.ParseBool(v)
+	return b
+}
+
+// mergeEURow folds a single line of the EU CSV file into the record for its entity.
+func mergeEURow(row *EUCSLRow, euCSLRecord *EUCSLRecord) {
+	// entity
+	euCSLRecord.EntityLogicalID = row.Entity.LogicalID
+	setIfEmpty(&euCSLRecord.FileGenerationDate, row.FileGenerationDate)
+	setIfEmpty(&euCSLRecord.EntityReferenceNumber, row.Entity.ReferenceNumber)
+	setIfEmpty(&euCSLRecord.EntityUnitedNationID, row.Entity.UnitedNationID)
+	setIfEmpty(&euCSLRecord.EntityDesignationDate, row.Entity.DesignationDate)
+	setIfEmpty(&euCSLRecord.EntityDesignationDetails, row.Entity.DesignationDetails)
+	setIfEmpty(&euCSLRecord.EntityRemark, row.Entity.Remark)
+	setIfEmpty(&euCSLRecord.EntitySubjectType, row.Entity.SubjectType.ClassificationCode)
+	setIfEmpty(&euCSLRecord.EntityPublicationURL, row.Entity.Regulation.PublicationURL)
+	if euCSLRecord.EntityRegulation == nil && *row.Entity.Regulation != (Regulation{}) {
+		euCSLRecord.EntityRegulation = row.Entity.Regulation
 	}
-	if csvRecord[AddressZipCodeIdx] != "" {
-		if !arrayContains(euCSLRecord.AddressZipCodes, csvRecord[AddressZipCodeIdx]) {
-			euCSLRecord.AddressZipCodes = append(euCSLRecord.AddressZipCodes, csvRecord[AddressZipCodeIdx])
+
+	// name alias
+	euCSLRecord.NameAliasWholeNames = appendUnique(euCSLRecord.NameAliasWholeNames, row.NameAlias.WholeName)
+	euCSLRecord.NameAliasTitles = appendUnique(euCSLRecord.NameAliasTitles, row.NameAlias.Title)
+
+	// address
+	euCSLRecord.AddressCities = appendUnique(euCSLRecord.AddressCities, row.Address.City)
+	euCSLRecord.AddressStreets = appendUnique(euCSLRecord.AddressStreets, row.Address.Street)
+	euCSLRecord.AddressPoBoxes = appendUnique(euCSLRecord.AddressPoBoxes, row.Address.PoBox)
+	euCSLRecord.AddressZipCodes = appendUnique(euCSLRecord.AddressZipCodes, row.Address.ZipCode)
+	euCSLRecord.AddressCountryDescriptions = appendUnique(euCSLRecord.AddressCountryDescriptions, row.Address.CountryDescription)
+
+	// birthdate
+	euCSLRecord.BirthDates = appendUnique(euCSLRecord.BirthDates, row.BirthDate.BirthDate)
+	euCSLRecord.BirthCities = appendUnique(euCSLRecord.BirthCities, row.BirthDate.City)
+	euCSLRecord.BirthCountries = appendUnique(euCSLRecord.BirthCountries, row.BirthDate.CountryDescription)
+
+	// identifications
+	if id := row.Identification; id != nil && !containsEUIdentification(euCSLRecord.Identifications, id) {
+		euCSLRecord.Identifications = append(euCSLRecord.Identifications, id)
+		if id.ValidFrom != "" {
+			if euCSLRecord.ValidFromTo == nil {
+				euCSLRecord.ValidFromTo = make(map[string]string)
+			}
+			euCSLRecord.ValidFromTo[id.ValidFrom] = id.ValidTo
 		}
 	}
-	if csvRecord[AddressCountryDescriptionIdx] != "" {
-		if !arrayContains(euCSLRecord.AddressCountryDescriptions, csvRecord[AddressCountryDescriptionIdx]) {
-			euCSLRecord.AddressCountryDescriptions = append(euCSLRecord.AddressCountryDescriptions, csvRecord[AddressCountryDescriptionIdx])
-		}
+
+	// citizenships
+	if cit := row.Citizenship; cit != nil && !containsEUCitizenship(euCSLRecord.Citizenships, cit) {
+		euCSLRecord.Citizenships = append(euCSLRecord.Citizenships, cit)
 	}
+}
 
-	// birthdate
-	if csvRecord[BirthDateIdx] != "" {
-		if !arrayContains(euCSLRecord.BirthDates, csvRecord[BirthDateIdx]) {
-			euCSLRecord.BirthDates = append(euCSLRecord.BirthDates, csvRecord[BirthDateIdx])
-		}
+func setIfEmpty(dst *string, v string) {
+	if *dst == "" && v != "" {
+		*dst = v
 	}
-	if csvRecord[BirthDateCityIdx] != "" {
-		if !arrayContains(euCSLRecord.BirthCities, csvRecord[BirthDateCityIdx]) {
-			euCSLRecord.BirthCities = append(euCSLRecord.BirthCities, csvRecord[BirthDateCityIdx])
-		}
+}
+
+func appendUnique(list []string, v string) []string {
+	if arrayContains(list, v) {
+		return list
 	}
-	if csvRecord[BirthDateCountryIdx] != "" {
-		if !arrayContains(euCSLRecord.BirthCountries, csvRecord[BirthDateCountryIdx]) {
-			euCSLRecord.BirthCountries = append(euCSLRecord.BirthCountries, csvRecord[BirthDateCountryIdx])
+	return append(list, v)
+}
+
+func containsEUIdentification(ids []*Identification, id *Identification) bool {
+	for i := range ids {
+		if *ids[i] == *id {
+			return true
 		}
 	}
+	return false
+}
 
-	// identifications
-	if csvRecord[IdentificationValidFromIdx] != "" {
-		euCSLRecord.ValidFromTo = make(map[string]string)
-		euCSLRecord.ValidFromTo[csvRecord[IdentificationValidFromIdx]] = csvRecord[IdentificationValidToIdx]
+func containsEUCitizenship(cits []*Citizenship, cit *Citizenship) bool {
+	for i := range cits {
+		if *cits[i] == *cit {
+			return true
+		}
 	}
+	return false
 }
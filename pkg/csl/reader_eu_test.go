@@ -1,10 +1,13 @@
 package csl
 
 import (
+	"encoding/csv"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestReadEU(t *testing.T) {
@@ -63,3 +66,119 @@ func TestReadEU(t *testing.T) {
 	assert.Equal(t, expectedBirthCity, euCSLMap[testLogicalID].BirthCities[0])
 	assert.Equal(t, expectedBirthCountryDescription, euCSLMap[testLogicalID].BirthCountries[0])
 }
+
+func TestReadEU__Structured(t *testing.T) {
+	_, euCSLMap, err := ReadEUFile(filepath.Join("..", "..", "test", "testdata", "eu_csl.csv"))
+	require.NoError(t, err)
+	require.Len(t, euCSLMap, 3)
+
+	record := euCSLMap[2653]
+	require.NotNil(t, record)
+
+	assert.Equal(t, "QDi.142", record.EntityUnitedNationID)
+	assert.Equal(t, "2008-02-13", record.EntityDesignationDate)
+	require.NotNil(t, record.EntityRegulation)
+	assert.Equal(t, "TAQA", record.EntityRegulation.Programme)
+	assert.Equal(t, "2008-02-15", record.EntityRegulation.PublicationDate)
+	assert.Equal(t, record.EntityPublicationURL, record.EntityRegulation.PublicationURL)
+
+	require.Len(t, record.Identifications, 2)
+	passport := record.Identifications[0]
+	assert.Equal(t, "A1234567", passport.Number)
+	assert.Equal(t, "passport", passport.TypeCode)
+	assert.Equal(t, "EG", passport.CountryIso2Code)
+	assert.Equal(t, "2005-06-12", passport.ValidFrom)
+	assert.Equal(t, "2010-06-11", passport.ValidTo)
+	assert.False(t, passport.KnownFalse)
+	assert.True(t, record.Identifications[1].KnownFalse)
+	assert.Equal(t, map[string]string{"2005-06-12": "2010-06-11"}, record.ValidFromTo)
+
+	// duplicate citizenship rows are merged
+	require.Len(t, record.Citizenships, 1)
+	assert.Equal(t, "EGYPT", record.Citizenships[0].CountryDescription)
+
+	saddam := euCSLMap[13]
+	require.Len(t, saddam.Citizenships, 1)
+	assert.Equal(t, "IQ", saddam.Citizenships[0].CountryIso2Code)
+	assert.Empty(t, saddam.Identifications)
+	assert.Nil(t, saddam.ValidFromTo)
+
+	company := euCSLMap[3089]
+	assert.Equal(t, "enterprise", company.EntitySubjectType)
+	assert.Equal(t, []string{"Tehran"}, company.AddressCities)
+	assert.Empty(t, company.Citizenships)
+}
+
+func TestReadEU__XML(t *testing.T) {
+	_, csvMap, err := ReadEUFile(filepath.Join("..", "..", "test", "testdata", "eu_csl.csv"))
+	require.NoError(t, err)
+
+	_, xmlMap, report, err := ReadEUFileWithReport(filepath.Join("..", "..", "test", "testdata", "eu_csl.xml"))
+	require.NoError(t, err)
+
+	// both exports produce the same records
+	require.Equal(t, csvMap, xmlMap)
+
+	require.Equal(t, EUFormatXML, report.Format)
+	require.Len(t, report.Skipped, 1)
+	require.Contains(t, report.Skipped[0].Reason, `invalid logicalId "not-a-number"`)
+	require.Equal(t, 35, report.Skipped[0].Line)
+
+	_, _, _, err = ParseEUXML(strings.NewReader(`<export><sanctionEntity logicalId="1">`))
+	require.Error(t, err)
+}
+
+func TestReadEU__CSVReport(t *testing.T) {
+	row := func(fields ...string) string {
+		for len(fields) < len(EUHeader) {
+			fields = append(fields, "")
+		}
+		return strings.Join(fields, ";")
+	}
+	input := strings.Join([]string{
+		strings.Join(EUHeader, ";"),
+		row("28/10/2022", "13", "EU.27.28"),
+		"28/10/2022;14",
+		row("28/10/2022", "abc", "EU.1.1"),
+		row("28/10/2022", "15", `EU."1.2`),
+	}, "\n")
+
+	records, _, report, err := ParseEUCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	require.Equal(t, EUFormatCSV, report.Format)
+	require.Len(t, report.Skipped, 2)
+	require.Equal(t, 3, report.Skipped[0].Line)
+	require.Equal(t, csv.ErrFieldCount.Error(), report.Skipped[0].Reason)
+	require.Equal(t, 4, report.Skipped[1].Line)
+	require.Equal(t, `invalid Entity_LogicalId "abc"`, report.Skipped[1].Reason)
+	require.Empty(t, report.HeaderDrift)
+
+	_, _, report, err = ReadEUFileWithReport(filepath.Join("..", "..", "test", "testdata", "eu_csl.csv"))
+	require.NoError(t, err)
+	require.Empty(t, report.HeaderDrift)
+}
+
+func TestReadEU__SchemaDrift(t *testing.T) {
+	// a truncated header can't be read
+	input := "fileGenerationDate;Entity_LogicalId;Entity_EU_ReferenceNumber\n28/10/2022;13;EU.27.28"
+	_, _, report, err := ParseEUCSV(strings.NewReader(input))
+	require.ErrorIs(t, err, ErrSchemaDrift)
+	require.Contains(t, err.Error(), `header is missing "Entity_UnitedNationId"`)
+	require.Equal(t, `column 3: expected "Entity_UnitedNationId", but it is missing`, report.HeaderDrift[0])
+
+	// columns which moved are read from their new position
+	header := append([]string{}, EUHeader...)
+	header[NameAliasWholeNameIdx], header[NameAliasTitleIdx] = header[NameAliasTitleIdx], header[NameAliasWholeNameIdx]
+	fields := make([]string, len(header))
+	fields[FileGenerationDateIdx], fields[EntityLogicalIdx] = "28/10/2022", "13"
+	fields[NameAliasTitleIdx], fields[NameAliasWholeNameIdx] = "Saddam Hussein Al-Tikriti", "President"
+
+	records, _, report, err := ParseEUCSV(strings.NewReader(strings.Join(header, ";") + "\n" + strings.Join(fields, ";")))
+	require.NoError(t, err)
+	require.Len(t, report.HeaderDrift, 2)
+	require.Len(t, records, 1)
+	require.Equal(t, []string{"Saddam Hussein Al-Tikriti"}, records[0].NameAliasWholeNames)
+	require.Equal(t, []string{"President"}, records[0].NameAliasTitles)
+}
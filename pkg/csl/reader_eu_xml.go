@@ -0,0 +1,251 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// euXMLEntity is a sanctionEntity element from the EU Financial Sanctions (FSD) XML export
+type euXMLEntity struct {
+	LogicalID          string   `xml:"logicalId,attr"`
+	ReferenceNumber    string   `xml:"euReferenceNumber,attr"`
+	UnitedNationID     string   `xml:"unitedNationId,attr"`
+	DesignationDate    string   `xml:"designationDate,attr"`
+	DesignationDetails string   `xml:"designationDetails,attr"`
+	Remarks            []string `xml:"remark"`
+
+	Regulations []euXMLRegulation `xml:"regulation"`
+	SubjectType struct {
+		Code string `xml:"code,attr"`
+	} `xml:"subjectType"`
+
+	NameAliases []struct {
+		WholeName string `xml:"wholeName,attr"`
+		Title     string `xml:"title,attr"`
+	} `xml:"nameAlias"`
+
+	Addresses []struct {
+		City               string `xml:"city,attr"`
+		Street             string `xml:"street,attr"`
+		PoBox              string `xml:"poBox,attr"`
+		ZipCode            string `xml:"zipCode,attr"`
+		CountryDescription string `xml:"countryDescription,attr"`
+	} `xml:"address"`
+
+	BirthDates []struct {
+		BirthDate          string `xml:"birthdate,attr"`
+		City               string `xml:"city,attr"`
+		CountryDescription string `xml:"countryDescription,attr"`
+	} `xml:"birthdate"`
+
+	Identifications []struct {
+		LogicalID          string   `xml:"logicalId,attr"`
+		Number             string   `xml:"number,attr"`
+		Diplomatic         string   `xml:"diplomatic,attr"`
+		KnownExpired       string   `xml:"knownExpired,attr"`
+		KnownFalse         string   `xml:"knownFalse,attr"`
+		ReportedLost       string   `xml:"reportedLost,attr"`
+		RevokedByIssuer    string   `xml:"revokedByIssuer,attr"`
+		IssuedBy           string   `xml:"issuedBy,attr"`
+		IssuedDate         string   `xml:"issuedDate,attr"`
+		ValidFrom          string   `xml:"validFrom,attr"`
+		ValidTo            string   `xml:"validTo,attr"`
+		NameOnDocument     string   `xml:"nameOnDocument,attr"`
+		TypeCode           string   `xml:"identificationTypeCode,attr"`
+		TypeDescription    string   `xml:"identificationTypeDescription,attr"`
+		Region             string   `xml:"region,attr"`
+		CountryIso2Code    string   `xml:"countryIso2Code,attr"`
+		CountryDescription string   `xml:"countryDescription,attr"`
+		Remarks            []string `xml:"remark"`
+	} `xml:"identification"`
+
+	Citizenships []struct {
+		LogicalID          string   `xml:"logicalId,attr"`
+		Region             string   `xml:"region,attr"`
+		CountryIso2Code    string   `xml:"countryIso2Code,attr"`
+		CountryDescription string   `xml:"countryDescription,attr"`
+		Remarks            []string `xml:"remark"`
+	} `xml:"citizen"`
+}
+
+type euXMLRegulation struct {
+	Type               string `xml:"regulationType,attr"`
+	OrganisationType   string `xml:"organisationType,attr"`
+	PublicationDate    string `xml:"publicationDate,attr"`
+	EntryIntoForceDate string `xml:"entryIntoForceDate,attr"`
+	NumberTitle        string `xml:"numberTitle,attr"`
+	Programme          string `xml:"programme,attr"`
+	PublicationURL     string `xml:"publicationUrl"`
+}
+
+// ParseEUXML reads the EU Financial Sanctions XML export into the same records as
+// ParseEUCSV. Entities which can't be decoded are skipped and recorded in the report.
+func ParseEUXML(r io.Reader) ([]*EUCSLRecord, EUCSL, *EUParseReport, error) {
+	decoder := xml.NewDecoder(r)
+
+	report := make(EUCSL)
+	parseReport := &EUParseReport{Format: EUFormatXML}
+
+	var generationDate string
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, nil, parseReport, fmt.Errorf("failed to read xml: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		line, _ := decoder.InputPos()
+		switch start.Name.Local {
+		case "export":
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "generationDate" {
+					generationDate = euGenerationDate(attr.Value)
+				}
+			}
+
+		case "sanctionEntity":
+			var entity euXMLEntity
+			if err := decoder.DecodeElement(&entity, &start); err != nil {
+				var serr *xml.SyntaxError
+				if errors.As(err, &serr) {
+					return nil, nil, parseReport, fmt.Errorf("failed to read xml: %w", err)
+				}
+				parseReport.skip(line, err.Error())
+				continue
+			}
+			logicalID, err := strconv.Atoi(entity.LogicalID)
+			if err != nil {
+				parseReport.skip(line, fmt.Sprintf("invalid logicalId %q", entity.LogicalID))
+				continue
+			}
+			for _, row := range entity.rows(logicalID, generationDate) {
+				mergeEUEntityRow(report, logicalID, row)
+			}
+		}
+	}
+	return euRecords(report), report, parseReport, nil
+}
+
+// rows flattens the entity into the lines the CSV export would contain for it
+func (e euXMLEntity) rows(logicalID int, generationDate string) []*EUCSLRow {
+	// the first regulation is the one which listed the entity, later ones amend it
+	var reg euXMLRegulation
+	if len(e.Regulations) > 0 {
+		reg = e.Regulations[0]
+	}
+	newRow := func() *EUCSLRow {
+		return &EUCSLRow{
+			FileGenerationDate: generationDate,
+			Entity: &Entity{
+				LogicalID:          logicalID,
+				ReferenceNumber:    e.ReferenceNumber,
+				UnitedNationID:     e.UnitedNationID,
+				DesignationDate:    e.DesignationDate,
+				DesignationDetails: e.DesignationDetails,
+				Remark:             strings.Join(e.Remarks, " "),
+				SubjectType: &SubjectType{
+					ClassificationCode: e.SubjectType.Code,
+				},
+				Regulation: &Regulation{
+					Type:               reg.Type,
+					OrganisationType:   reg.OrganisationType,
+					PublicationDate:    reg.PublicationDate,
+					EntryIntoForceDate: reg.EntryIntoForceDate,
+					NumberTitle:        reg.NumberTitle,
+					Programme:          reg.Programme,
+					PublicationURL:     strings.TrimSpace(reg.PublicationURL),
+				},
+			},
+			NameAlias: &NameAlias{},
+			Address:   &Address{},
+			BirthDate: &BirthDate{},
+		}
+	}
+
+	out := []*EUCSLRow{newRow()}
+	for _, alias := range e.NameAliases {
+		row := newRow()
+		row.NameAlias = &NameAlias{WholeName: alias.WholeName, Title: alias.Title}
+		out = append(out, row)
+	}
+	for _, addr := range e.Addresses {
+		row := newRow()
+		row.Address = &Address{
+			City:               addr.City,
+			Street:             addr.Street,
+			PoBox:              addr.PoBox,
+			ZipCode:            addr.ZipCode,
+			CountryDescription: addr.CountryDescription,
+		}
+		out = append(out, row)
+	}
+	for _, bd := range e.BirthDates {
+		row := newRow()
+		row.BirthDate = &BirthDate{
+			BirthDate:          bd.BirthDate,
+			City:               bd.City,
+			CountryDescription: bd.CountryDescription,
+		}
+		out = append(out, row)
+	}
+	for _, id := range e.Identifications {
+		row := newRow()
+		row.Identification = &Identification{
+			Number:             id.Number,
+			Diplomatic:         parseEUBool(id.Diplomatic),
+			KnownExpired:       parseEUBool(id.KnownExpired),
+			KnownFalse:         parseEUBool(id.KnownFalse),
+			ReportedLost:       parseEUBool(id.ReportedLost),
+			RevokedByIssuer:    parseEUBool(id.RevokedByIssuer),
+			IssuedBy:           id.IssuedBy,
+			IssuedDate:         id.IssuedDate,
+			ValidFrom:          id.ValidFrom,
+			ValidTo:            id.ValidTo,
+			NameOnDocument:     id.NameOnDocument,
+			TypeCode:           id.TypeCode,
+			TypeDescription:    id.TypeDescription,
+			Region:             id.Region,
+			CountryIso2Code:    id.CountryIso2Code,
+			CountryDescription: id.CountryDescription,
+			Remark:             strings.Join(id.Remarks, " "),
+		}
+		row.Identification.LogicalID, _ = strconv.Atoi(id.LogicalID)
+		out = append(out, row)
+	}
+	for _, cit := range e.Citizenships {
+		row := newRow()
+		row.Citizenship = &Citizenship{
+			Region:             cit.Region,
+			CountryIso2Code:    cit.CountryIso2Code,
+			CountryDescription: cit.CountryDescription,
+			Remark:             strings.Join(cit.Remarks, " "),
+		}
+		row.Citizenship.LogicalID, _ = strconv.Atoi(cit.LogicalID)
+		out = append(out, row)
+	}
+	return out
+}
+
+// euGenerationDate formats the XML export's timestamp the way the CSV export writes it (DD/MM/YYYY)
+func euGenerationDate(v string) string {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.Format("02/01/2006")
+		}
+	}
+	return v
+}
@@ -2,6 +2,7 @@ package csl
 
 import (
 	"compress/gzip"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -147,6 +148,16 @@ func Test_unmarshalSSI(t *testing.T) {
 		IDsOnRecord:    []string{"1027700049486, Registration ID", "00044463, Government Gazette Number", "7706061801, Tax ID No.", "transneft@ak.transneft.ru, Email Address", "www.transneft.ru, Website", "Subject to Directive 2, Executive Order 13662 Directive Determination -"},
 		SourceListURL:  "http://bit.ly/1QWTIfE",
 		SourceInfoURL:  "http://bit.ly/1MLgou0",
+		Details: Details{
+			Identifications: []CSLIdentification{
+				{Number: "1027700049486", Type: "Registration ID"},
+				{Number: "00044463", Type: "Government Gazette Number"},
+				{Number: "7706061801", Type: "Tax ID No."},
+				{Number: "transneft@ak.transneft.ru", Type: "Email Address"},
+				{Number: "www.transneft.ru", Type: "Website"},
+				{Number: "Subject to Directive 2", Type: "Executive Order 13662 Directive Determination -"},
+			},
+		},
 	}
 
 	actualSSI := unmarshalSSI(record, 0)
@@ -216,10 +227,15 @@ func Test_unmarshalFSE(t *testing.T) {
 		Name:          "BEKTAS, Halis",
 		Addresses:     nil,
 		SourceListURL: "https://bit.ly/1QWTIfE",
-		Citizenships:  "CH",
-		DatesOfBirth:  "1966-02-13",
 		SourceInfoURL: "http://bit.ly/1N1docf",
 		IDs:           []string{"CH, X0906223, Passport"},
+		Details: Details{
+			Citizenships: []string{"CH"},
+			DatesOfBirth: []string{"1966-02-13"},
+			Identifications: []CSLIdentification{
+				{Country: "CH", Number: "X0906223", Type: "Passport"},
+			},
+		},
 	}
 
 	actualFSE := unmarshalFSE(record, 1)
@@ -227,6 +243,11 @@ func Test_unmarshalFSE(t *testing.T) {
 	if !reflect.DeepEqual(expectedFSE, actualFSE) {
 		t.Errorf("Expected: %#v\nFound: %#v\n", expectedFSE, actualFSE)
 	}
+
+	// details are encoded alongside the record's other fields
+	bs, err := json.Marshal(actualFSE)
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"citizenships":["CH"],"datesOfBirth":["1966-02-13"]`)
 }
 
 func Test_unmarshalPLC(t *testing.T) {
@@ -246,9 +267,10 @@ func Test_unmarshalPLC(t *testing.T) {
 		Remarks:        "HAMAS - Der al-Balah",
 		SourceListURL:  "https://bit.ly/1QWTIfE",
 		AlternateNames: []string{"SALAMEH, Salem Ahmad Abdel Hadi"},
-		DatesOfBirth:   "1951",
-		PlacesOfBirth:  "",
 		SourceInfoURL:  "http://bit.ly/2tjOLpx",
+		Details: Details{
+			DatesOfBirth: []string{"1951"},
+		},
 	}
 
 	actualPLC := unmarshalPLC(record, 1)
@@ -288,6 +310,17 @@ func Test_unmarshalCAP(t *testing.T) {
 			"Subject to Directive 1, Executive Order 13662 Directive Determination -",
 			"044525219, BIK (RU)",
 			"Financial Institution, Target Type"},
+		Details: Details{
+			Identifications: []CSLIdentification{
+				{Country: "RU", Number: "1027700159497", Type: "Registration Number"},
+				{Country: "RU", Number: "29292940", Type: "Government Gazette Number"},
+				{Number: "MOSWRUMM", Type: "SWIFT/BIC"},
+				{Number: "www.bm.ru", Type: "Website"},
+				{Number: "Subject to Directive 1", Type: "Executive Order 13662 Directive Determination -"},
+				{Number: "044525219", Type: "BIK (RU)"},
+				{Number: "Financial Institution", Type: "Target Type"},
+			},
+		},
 	}
 
 	actualCAP := unmarshalCAP(record, 1)
@@ -343,6 +376,20 @@ func Test_unmarshalCMIC(t *testing.T) {
 		IDs: []string{"Proven Honour Capital Ltd, Issuer Name", "Proven Honour Capital Limited, Issuer Name", "XS1233275194, ISIN",
 			"HK0000216777, ISIN", "Private Company, Target Type", "XS1401816761, ISIN", "HK0000111952, ISIN", "03 Jun 2021, Listing Date (CMIC)",
 			"02 Aug 2021, Effective Date (CMIC)", "03 Jun 2022, Purchase/Sales For Divestment Date (CMIC)"},
+		Details: Details{
+			Identifications: []CSLIdentification{
+				{Number: "Proven Honour Capital Ltd", Type: "Issuer Name"},
+				{Number: "Proven Honour Capital Limited", Type: "Issuer Name"},
+				{Number: "XS1233275194", Type: "ISIN"},
+				{Number: "HK0000216777", Type: "ISIN"},
+				{Number: "Private Company", Type: "Target Type"},
+				{Number: "XS1401816761", Type: "ISIN"},
+				{Number: "HK0000111952", Type: "ISIN"},
+				{Number: "03 Jun 2021", Type: "Listing Date (CMIC)"},
+				{Number: "02 Aug 2021", Type: "Effective Date (CMIC)"},
+				{Number: "03 Jun 2022", Type: "Purchase/Sales For Divestment Date (CMIC)"},
+			},
+		},
 	}
 
 	actualCMIC := unmarshalCMIC(record, 1)
@@ -376,6 +423,21 @@ func Test_unmarshalNS_MBS(t *testing.T) {
 			"Subject to Directive 3 - All transactions in, provision of financing for, and other dealings in new debt of longer than 14 days maturity or new equity where such new debt or new equity is issued on or after the 'Effective Date (EO 14024 Directive)' associated with this name are prohibited., Executive Order 14024 Directive Information",
 			"31 Jul 1990, Organization Established Date", "24 Feb 2022, Listing Date (EO 14024 Directive 3):", "26 Mar 2022, Effective Date (EO 14024 Directive 3):",
 			"For more information on directives, please visit the following link: https://home.treasury.gov/policy-issues/financial-sanctions/sanctions-programs-and-country-information/russian-harmful-foreign-activities-sanctions#directives, Executive Order 14024 Directive Information -"},
+		Details: Details{
+			Identifications: []CSLIdentification{
+				{Country: "RU", Number: "1027700167110", Type: "Registration Number"},
+				{Country: "RU", Number: "09807684", Type: "Government Gazette Number"},
+				{Country: "RU", Number: "7744001497", Type: "Tax ID No."},
+				{Number: "www.gazprombank.ru", Type: "Website"},
+				{Number: "GAZPRUMM", Type: "SWIFT/BIC"},
+				{Number: "Subject to Directive 1", Type: "Executive Order 13662 Directive Determination -"},
+				{Number: "Subject to Directive 3 - All transactions in, provision of financing for, and other dealings in new debt of longer than 14 days maturity or new equity where such new debt or new equity is issued on or after the 'Effective Date (EO 14024 Directive)' associated with this name are prohibited.", Type: "Executive Order 14024 Directive Information"},
+				{Number: "31 Jul 1990", Type: "Organization Established Date"},
+				{Number: "24 Feb 2022", Type: "Listing Date (EO 14024 Directive 3):"},
+				{Number: "26 Mar 2022", Type: "Effective Date (EO 14024 Directive 3):"},
+				{Number: "For more information on directives, please visit the following link: https://home.treasury.gov/policy-issues/financial-sanctions/sanctions-programs-and-country-information/russian-harmful-foreign-activities-sanctions#directives", Type: "Executive Order 14024 Directive Information -"},
+			},
+		},
 	}
 
 	actualNB_MBS := unmarshalNS_MBS(record, 1)
@@ -432,6 +494,26 @@ func Test_expandField(t *testing.T) {
 	}
 }
 
+func Test_expandIdentifications(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []CSLIdentification
+	}{
+		{"", nil},
+		{"21795618, Passport", []CSLIdentification{{Number: "21795618", Type: "Passport"}}},
+		{"CH, CHE-427.006.032, Company Number; CO, 01200175 (Bogota), Matricula Mercantil No", []CSLIdentification{
+			{Country: "CH", Number: "CHE-427.006.032", Type: "Company Number"},
+			{Country: "CO", Number: "01200175 (Bogota)", Type: "Matricula Mercantil No"},
+		}},
+		{"IMO 8898831", []CSLIdentification{{Number: "IMO 8898831"}}},
+	}
+	for _, test := range tests {
+		if got := expandIdentifications(test.input); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("expandIdentifications() = %v, want %v", got, test.want)
+		}
+	}
+}
+
 func Test_expandProgramsList(t *testing.T) {
 	tests := []struct {
 		input string
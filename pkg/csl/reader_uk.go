@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"encoding/csv"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/moov-io/watchman/internal/schema"
+
 	"github.com/knieriem/odf/ods"
 )
 
@@ -32,12 +37,25 @@ func ReadUKCSLFile(path string) ([]*UKCSLRecord, UKCSL, error) {
 
 func ParseUKCSL(r io.Reader) ([]*UKCSLRecord, UKCSL, error) {
 	reader := csv.NewReader(r)
-	reader.FieldsPerRecord = 36
+	reader.FieldsPerRecord = -1 // the report date line is shorter than the rows
 
 	report := make(UKCSL)
-	// read and ignore first two rows
+	// the first two rows are the report date and header
+	var layout *schema.Layout
 	for i := 0; i <= 1; i++ {
-		reader.Read()
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			continue
+		}
+		if isUKCSLHeader(record) {
+			layout, err = schema.Resolve(UKCSLHeader, record)
+			if err != nil {
+				return nil, nil, fmt.Errorf("UK CSL: %w", err)
+			}
+		}
 	}
 
 	for {
@@ -56,8 +74,9 @@ func ParseUKCSL(r io.Reader) ([]*UKCSLRecord, UKCSL, error) {
 			return nil, nil, err
 		}
 
-		if len(record) <= 1 {
-			continue // skip empty records
+		record = layout.Row(record)
+		if len(record) < len(UKCSLHeader) {
+			continue // skip empty and malformed records
 		}
 
 		// merge rows at this point
@@ -87,6 +106,20 @@ func ParseUKCSL(r io.Reader) ([]*UKCSLRecord, UKCSL, error) {
 	return totalReport, report, nil
 }
 
+// isUKCSLHeader reports if record is the header row of the UK Consolidated List
+func isUKCSLHeader(record []string) bool {
+	var name, groupID bool
+	for i := range record {
+		switch schema.Normalize(record[i]) {
+		case "name6":
+			name = true
+		case "groupid":
+			groupID = true
+		}
+	}
+	return name && groupID
+}
+
 func unmarshalUKCSLRecord(csvRecord []string, ukCSLRecord *UKCSLRecord) {
 	var names []string
 	if csvRecord[UKNameIdx] != "" {
@@ -200,10 +233,27 @@ func unmarshalUKCSLRecord(csvRecord []string, ukCSLRecord *UKCSLRecord) {
 	}
 }
 
+// ReadUKSanctionsListFile parses the UK Sanctions List from an ODS, CSV or XML file,
+// chosen by the file's extension.
 func ReadUKSanctionsListFile(path string) ([]*UKSanctionsListRecord, UKSanctionsListMap, error) {
 	if path == "" {
 		return nil, nil, errors.New("path was empty for uk sanctions list file")
 	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".xml":
+		fd, err := os.Open(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer fd.Close()
+
+		if strings.EqualFold(filepath.Ext(path), ".xml") {
+			return ParseUKSanctionsListXML(fd)
+		}
+		return ParseUKSanctionsListCSV(fd)
+	}
+
 	fd, err := ods.Open(path)
 	if err != nil {
 		return nil, nil, err
@@ -225,33 +275,62 @@ func ReadUKSanctionsListFile(path string) ([]*UKSanctionsListRecord, UKSanctions
 }
 
 func parseUKSanctionsList(doc *ods.Doc) ([]*UKSanctionsListRecord, UKSanctionsListMap, error) {
-	// read from the ods document
-	var totalReport []*UKSanctionsListRecord
-	report := UKSanctionsListMap{}
-
-	// unmarshal each row into a uk sanctions list record
+	var rows [][]string
 	if len(doc.Table) > 0 {
-		for i, record := range doc.Table[0].Row {
-
-			// manually skip the header and extra rows
-			if record.IsEmpty() || i <= 2 {
+		b := new(bytes.Buffer)
+		for _, record := range doc.Table[0].Row {
+			if record.IsEmpty() {
 				continue
 			}
+			rows = append(rows, record.Strings(b))
+		}
+	}
+	return parseUKSanctionsListRows(rows)
+}
 
-			// need a length of row check since we are using the string representation
-			uniqueIDCell := record.Cell[UKSL_UniqueIDIdx]
-			b := new(bytes.Buffer)
-			uniqueID := uniqueIDCell.PlainText(b)
-
-			if val, ok := report[uniqueID]; !ok {
-				row := new(UKSanctionsListRecord)
-				row.UniqueID = uniqueID
-				unmarshalUKSanctionsListRecord(record.Cell, row)
+// ParseUKSanctionsListCSV reads the CSV publication of the UK Sanctions List
+func ParseUKSanctionsListCSV(r io.Reader) ([]*UKSanctionsListRecord, UKSanctionsListMap, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // the report date line is shorter than the rows
+	reader.LazyQuotes = true
 
-				report[uniqueID] = row
-			} else {
-				unmarshalUKSanctionsListRecord(record.Cell, val)
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
 			}
+			return nil, nil, err
+		}
+		rows = append(rows, record)
+	}
+	return parseUKSanctionsListRows(rows)
+}
+
+// parseUKSanctionsListRows merges rows (one per name, address, etc) into records by their Unique ID.
+// Columns are found from the header row, falling back to the ODS layout if there isn't one.
+func parseUKSanctionsListRows(rows [][]string) ([]*UKSanctionsListRecord, UKSanctionsListMap, error) {
+	var totalReport []*UKSanctionsListRecord
+	report := UKSanctionsListMap{}
+
+	cols, start, err := findUKSLColumns(rows)
+	if err != nil {
+		return nil, nil, fmt.Errorf("UK Sanctions List: %w", err)
+	}
+	for _, row := range rows[start:] {
+		uniqueID := cols.get(row, cols.uniqueID)
+		if uniqueID == "" {
+			continue
+		}
+		if val, ok := report[uniqueID]; !ok {
+			record := new(UKSanctionsListRecord)
+			record.UniqueID = uniqueID
+			unmarshalUKSanctionsListRecord(row, cols, record)
+
+			report[uniqueID] = record
+		} else {
+			unmarshalUKSanctionsListRecord(row, cols, val)
 		}
 	}
 
@@ -261,118 +340,245 @@ func parseUKSanctionsList(doc *ods.Doc) ([]*UKSanctionsListRecord, UKSanctionsLi
 	return totalReport, report, nil
 }
 
-func unmarshalUKSanctionsListRecord(record []ods.Cell, ukSLRecord *UKSanctionsListRecord) {
-	if len(record) < UKSL_CountryOfBirthIdx {
-		return
+// ukSLColumns holds the index of each UK Sanctions List column, or -1 when it's missing
+type ukSLColumns struct {
+	lastUpdated, uniqueID, ofsiGroupID, unReferenceNumber int
+
+	names                 []int // Name 6, then Name 1 through Name 5
+	nameType, title       int
+	nonLatinScript        int
+	regimeName            int
+	entityType            int
+	designationSource     int
+	dateDesignated        int
+	otherInformation      int
+	statementOfReasons    int
+	addressLines          []int // Address Line 1 through 6
+	postalCode            int
+	addressCountry        int
+	dateOfBirth           int
+	nationality           int
+	nationalIdentifier    int
+	nationalIdentifierExt int
+	passportNumber        int
+	passportExt           int
+	position              int
+	gender                int
+	townOfBirth           int
+	countryOfBirth        int
+}
+
+// defaultUKSLColumns is the layout of the ODS publication, used when no header row is found
+var defaultUKSLColumns = ukSLColumns{
+	lastUpdated:           UKSL_LastUpdatedIdx,
+	uniqueID:              UKSL_UniqueIDIdx,
+	ofsiGroupID:           UKSL_OFSI_GroupIDIdx,
+	unReferenceNumber:     UKSL_UNReferenceNumberIdx,
+	names:                 []int{UKSL_Name6Idx, UKSL_Name1Idx, UKSL_Name2Idx, UKSL_Name3Idx, UKSL_Name4Idx, UKSL_Name5Idx},
+	nameType:              UKSL_NameTypeIdx,
+	title:                 -1,
+	nonLatinScript:        UKSL_NonLatinScriptIdx,
+	regimeName:            -1,
+	entityType:            UKSL_EntityTypeIdx,
+	designationSource:     -1,
+	dateDesignated:        -1,
+	otherInformation:      UKSL_OtherInfoIdx,
+	statementOfReasons:    -1,
+	addressLines:          []int{UKSL_AddressLine1Idx, UKSL_AddressLine2Idx, UKSL_AddressLine3Idx, UKSL_AddressLine4Idx, UKSL_AddressLine5Idx, UKSL_AddressLine6Idx},
+	postalCode:            UKSL_PostalCodeIdx,
+	addressCountry:        UKSL_AddressCountryIdx,
+	dateOfBirth:           -1,
+	nationality:           -1,
+	nationalIdentifier:    -1,
+	nationalIdentifierExt: -1,
+	passportNumber:        -1,
+	passportExt:           -1,
+	position:              -1,
+	gender:                -1,
+	townOfBirth:           -1,
+	countryOfBirth:        UKSL_CountryOfBirthIdx,
+}
+
+// findUKSLColumns looks for the header row (the publications start with a report date line)
+// and returns the column layout along with the index of the first data row. A header without
+// the columns records are built from is a schema drift error.
+func findUKSLColumns(rows [][]string) (ukSLColumns, int, error) {
+	for i, row := range rows {
+		headers := make(map[string]int)
+		for idx, cell := range row {
+			key := schema.Normalize(cell)
+			if _, exists := headers[key]; !exists {
+				headers[key] = idx
+			}
+		}
+		if !isUKSLHeader(headers) {
+			continue
+		}
+		find := func(names ...string) int {
+			for _, name := range names {
+				if idx, ok := headers[name]; ok {
+					return idx
+				}
+			}
+			return -1
+		}
+		cols := ukSLColumns{
+			lastUpdated:           find("lastupdated"),
+			uniqueID:              find("uniqueid"),
+			ofsiGroupID:           find("ofsigroupid"),
+			unReferenceNumber:     find("unreferencenumber"),
+			nameType:              find("nametype"),
+			title:                 find("title"),
+			nonLatinScript:        find("namenonlatinscript"),
+			regimeName:            find("regimename", "regime"),
+			entityType:            find("entitytype", "individualentityship"),
+			designationSource:     find("designationsource"),
+			dateDesignated:        find("datedesignated"),
+			otherInformation:      find("otherinformation"),
+			statementOfReasons:    find("ukstatementofreasons"),
+			postalCode:            find("addresspostalcode", "postalcode"),
+			addressCountry:        find("addresscountry"),
+			dateOfBirth:           find("dob", "dateofbirth"),
+			nationality:           find("nationalityies", "nationalities", "nationality"),
+			nationalIdentifier:    find("nationalidentifiernumber"),
+			nationalIdentifierExt: find("nationalidentifieradditionalinformation"),
+			passportNumber:        find("passportnumber"),
+			passportExt:           find("passportadditionalinformation"),
+			position:              find("position"),
+			gender:                find("gender"),
+			townOfBirth:           find("townofbirth"),
+			countryOfBirth:        find("countryofbirth"),
+		}
+		for _, n := range []string{"name6", "name1", "name2", "name3", "name4", "name5"} {
+			cols.names = append(cols.names, find(n))
+		}
+		for n := 1; n <= 6; n++ {
+			cols.addressLines = append(cols.addressLines, find(fmt.Sprintf("addressline%d", n)))
+		}
+
+		var missing []string
+		for name, idx := range map[string]int{"Unique ID": cols.uniqueID, "Name 6": cols.names[0], "Name type": cols.nameType} {
+			if idx < 0 {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return cols, 0, &schema.DriftError{Missing: missing}
+		}
+		return cols, i + 1, nil
 	}
 
-	b := new(bytes.Buffer)
-	if !record[UKSL_LastUpdatedIdx].IsEmpty() && ukSLRecord.LastUpdated == "" {
-		ukSLRecord.LastUpdated = record[UKSL_LastUpdatedIdx].PlainText(b)
+	// the ODS publication has a report date, blank line and header before the first record
+	start := 3
+	if len(rows) < start {
+		start = len(rows)
 	}
+	return defaultUKSLColumns, start, nil
+}
 
-	if !record[UKSL_OFSI_GroupIDIdx].IsEmpty() && ukSLRecord.OFSIGroupID == "" {
-		ukSLRecord.OFSIGroupID = record[UKSL_OFSI_GroupIDIdx].PlainText(b)
+// isUKSLHeader reports if the (normalized) cells of a row look like the UK Sanctions List header
+func isUKSLHeader(headers map[string]int) bool {
+	var found int
+	for _, name := range []string{"lastupdated", "uniqueid", "ofsigroupid", "name6", "nametype"} {
+		if _, ok := headers[name]; ok {
+			found++
+		}
 	}
+	return found >= 2
+}
 
-	if !record[UKSL_UNReferenceNumberIdx].IsEmpty() && ukSLRecord.UNReferenceNumber == "" {
-		ukSLRecord.UNReferenceNumber = record[UKSL_UNReferenceNumberIdx].PlainText(b)
+func (cols ukSLColumns) get(row []string, idx int) string {
+	if idx < 0 || idx >= len(row) {
+		return ""
 	}
+	return strings.TrimSpace(row[idx])
+}
+
+func unmarshalUKSanctionsListRecord(record []string, cols ukSLColumns, ukSLRecord *UKSanctionsListRecord) {
+	setIfEmpty(&ukSLRecord.LastUpdated, cols.get(record, cols.lastUpdated))
+	setIfEmpty(&ukSLRecord.OFSIGroupID, cols.get(record, cols.ofsiGroupID))
+	setIfEmpty(&ukSLRecord.UNReferenceNumber, cols.get(record, cols.unReferenceNumber))
 
 	// consolidate names
 	var names []string
-	if !record[UKSL_Name6Idx].IsEmpty() {
-		names = append(names, record[UKSL_Name6Idx].PlainText(b))
-	}
-	if !record[UKSL_Name1Idx].IsEmpty() {
-		names = append(names, record[UKSL_Name1Idx].PlainText(b))
-	}
-	if !record[UKSL_Name2Idx].IsEmpty() {
-		names = append(names, record[UKSL_Name2Idx].PlainText(b))
-	}
-	if !record[UKSL_Name3Idx].IsEmpty() {
-		names = append(names, record[UKSL_Name3Idx].PlainText(b))
-	}
-	if !record[UKSL_Name4Idx].IsEmpty() {
-		names = append(names, record[UKSL_Name4Idx].PlainText(b))
-	}
-	if !record[UKSL_Name5Idx].IsEmpty() {
-		names = append(names, record[UKSL_Name5Idx].PlainText(b))
+	for _, idx := range cols.names {
+		if v := cols.get(record, idx); v != "" {
+			names = append(names, v)
+		}
 	}
 	name := strings.Join(names, " ")
 	if !strings.EqualFold(strings.TrimSpace(name), "") && !arrayContains(ukSLRecord.Names, name) {
 		ukSLRecord.Names = append(ukSLRecord.Names, name)
 	}
 
-	if !record[UKSL_NameTypeIdx].IsEmpty() && ukSLRecord.NameTitle == "" {
-		ukSLRecord.NameTitle = record[UKSL_NameTypeIdx].PlainText(b)
-	}
-
-	if !record[UKSL_NonLatinScriptIdx].IsEmpty() && !arrayContains(ukSLRecord.NonLatinScriptNames, record[UKSL_NonLatinScriptIdx].PlainText(b)) {
-		ukSLRecord.NonLatinScriptNames = append(ukSLRecord.NonLatinScriptNames, record[UKSL_NonLatinScriptIdx].PlainText(b))
-	}
+	setIfEmpty(&ukSLRecord.NameTitle, cols.get(record, cols.nameType))
+	ukSLRecord.Titles = appendUnique(ukSLRecord.Titles, cols.get(record, cols.title))
+	ukSLRecord.NonLatinScriptNames = appendUnique(ukSLRecord.NonLatinScriptNames, cols.get(record, cols.nonLatinScript))
 
-	if !record[UKSL_EntityTypeIdx].IsEmpty() && ukSLRecord.EntityType == nil {
-		cellValue := record[UKSL_EntityTypeIdx].PlainText(b)
-		entityType := EntityStringMap[cellValue]
+	if v := cols.get(record, cols.entityType); v != "" && ukSLRecord.EntityType == nil {
+		entityType := EntityStringMap[v]
 		ukSLRecord.EntityType = &entityType
 	}
 
+	setIfEmpty(&ukSLRecord.RegimeName, cols.get(record, cols.regimeName))
+	setIfEmpty(&ukSLRecord.DesignationSource, cols.get(record, cols.designationSource))
+	setIfEmpty(&ukSLRecord.DateDesignated, cols.get(record, cols.dateDesignated))
+	setIfEmpty(&ukSLRecord.OtherInformation, cols.get(record, cols.otherInformation))
+	setIfEmpty(&ukSLRecord.StatementOfReasons, cols.get(record, cols.statementOfReasons))
+
 	// consolidate addresses
 	var addresses []string
-	addr1 := record[UKSL_AddressLine1Idx]
-	addr2 := record[UKSL_AddressLine2Idx]
-	addr3 := record[UKSL_AddressLine3Idx]
-	addr4 := record[UKSL_AddressLine4Idx]
-	addr5 := record[UKSL_AddressLine5Idx]
-	addr6 := record[UKSL_AddressLine6Idx]
-
-	if !addr1.IsEmpty() {
-		addresses = append(addresses, addr1.PlainText(b))
-	}
-	if !addr2.IsEmpty() {
-		addresses = append(addresses, addr2.PlainText(b))
-	}
-	if !addr3.IsEmpty() {
-		addresses = append(addresses, addr3.PlainText(b))
-	}
-	if !addr4.IsEmpty() {
-		addresses = append(addresses, addr4.PlainText(b))
-	}
-	if !addr5.IsEmpty() {
-		addresses = append(addresses, addr5.PlainText(b))
-	}
-	addr6Value := addr6.PlainText(b)
-	if !addr6.IsEmpty() {
-		addresses = append(addresses, addr6Value)
-		if !arrayContains(ukSLRecord.StateLocalities, addr6Value) {
-			ukSLRecord.StateLocalities = append(ukSLRecord.StateLocalities, addr6Value)
+	for i, idx := range cols.addressLines {
+		v := cols.get(record, idx)
+		if v == "" {
+			continue
+		}
+		addresses = append(addresses, v)
+		if i == len(cols.addressLines)-1 {
+			ukSLRecord.StateLocalities = appendUnique(ukSLRecord.StateLocalities, v)
 		}
 	}
-	postalCode := record[UKSL_PostalCodeIdx]
-	postalCodeValue := record[UKSL_PostalCodeIdx].PlainText(b)
-	if !postalCode.IsEmpty() {
-		addresses = append(addresses, postalCodeValue)
+	if v := cols.get(record, cols.postalCode); v != "" {
+		addresses = append(addresses, v)
 	}
-	addrCountries := record[UKSL_AddressCountryIdx]
-	addrCountriesValue := record[UKSL_AddressCountryIdx].PlainText(b)
-	if !addrCountries.IsEmpty() {
-		addresses = append(addresses, addrCountriesValue)
-		if !arrayContains(ukSLRecord.AddressCountries, addrCountriesValue) {
-			ukSLRecord.AddressCountries = append(ukSLRecord.AddressCountries, addrCountriesValue)
-		}
+	if v := cols.get(record, cols.addressCountry); v != "" {
+		addresses = append(addresses, v)
+		ukSLRecord.AddressCountries = appendUnique(ukSLRecord.AddressCountries, v)
 	}
-
 	address := strings.Join(addresses, ", ")
 	if !strings.EqualFold(strings.TrimSpace(address), "") && !arrayContains(ukSLRecord.Addresses, address) {
 		ukSLRecord.Addresses = append(ukSLRecord.Addresses, address)
 	}
 
-	cob := record[UKSL_CountryOfBirthIdx]
-	cobValue := record[UKSL_CountryOfBirthIdx].PlainText(b)
-	if !cob.IsEmpty() && ukSLRecord.CountryOfBirth != "" {
-		ukSLRecord.CountryOfBirth = cobValue
+	// individual details
+	ukSLRecord.DatesOfBirth = appendUnique(ukSLRecord.DatesOfBirth, cols.get(record, cols.dateOfBirth))
+	ukSLRecord.Nationalities = appendUnique(ukSLRecord.Nationalities, cols.get(record, cols.nationality))
+	ukSLRecord.Positions = appendUnique(ukSLRecord.Positions, cols.get(record, cols.position))
+	ukSLRecord.TownsOfBirth = appendUnique(ukSLRecord.TownsOfBirth, cols.get(record, cols.townOfBirth))
+	setIfEmpty(&ukSLRecord.CountryOfBirth, cols.get(record, cols.countryOfBirth))
+	setIfEmpty(&ukSLRecord.Gender, cols.get(record, cols.gender))
+
+	ukSLRecord.Passports = appendUKSLDocument(ukSLRecord.Passports, UKSLDocument{
+		Number:                cols.get(record, cols.passportNumber),
+		AdditionalInformation: cols.get(record, cols.passportExt),
+	})
+	ukSLRecord.NationalIdentifiers = appendUKSLDocument(ukSLRecord.NationalIdentifiers, UKSLDocument{
+		Number:                cols.get(record, cols.nationalIdentifier),
+		AdditionalInformation: cols.get(record, cols.nationalIdentifierExt),
+	})
+}
+
+func appendUKSLDocument(docs []UKSLDocument, doc UKSLDocument) []UKSLDocument {
+	if doc.Number == "" {
+		return docs
+	}
+	for i := range docs {
+		if docs[i] == doc {
+			return docs
+		}
 	}
+	return append(docs, doc)
 }
 
 func arrayContains(checkArray []string, nameToCheck string) bool {
@@ -1,11 +1,14 @@
 package csl
 
 import (
+	"encoding/csv"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestReadUKCSL(t *testing.T) {
@@ -97,3 +100,106 @@ func TestReadUKSanctionsList(t *testing.T) {
 		t.Fatal("record not found")
 	}
 }
+
+func TestReadUKSanctionsList__Formats(t *testing.T) {
+	for _, name := range []string{"UK_Sanctions_List.csv", "UK_Sanctions_List.xml"} {
+		t.Run(name, func(t *testing.T) {
+			totalReport, report, err := ReadUKSanctionsListFile(filepath.Join("..", "..", "test", "testdata", name))
+			require.NoError(t, err)
+			require.Len(t, totalReport, 2)
+
+			entity := report["AFG0001"]
+			require.NotNil(t, entity)
+			assert.Equal(t, "12/01/2022", entity.LastUpdated)
+			assert.Equal(t, "12703", entity.OFSIGroupID)
+			assert.Equal(t, "TAe.010", entity.UNReferenceNumber)
+			assert.Equal(t, []string{"HAJI KHAIRULLAH HAJI SATTAR MONEY EXCHANGE", "HAJI KHAIRULLAH MONEY EXCHANGE"}, entity.Names)
+			assert.Equal(t, "Primary Name", entity.NameTitle)
+			assert.Len(t, entity.NonLatinScriptNames, 1)
+			assert.Equal(t, UKSLEntity, *entity.EntityType)
+			assert.Equal(t, "The Afghanistan (Sanctions) (EU Exit) Regulations 2020", entity.RegimeName)
+			assert.Equal(t, "UN", entity.DesignationSource)
+			assert.Equal(t, "29/06/2012", entity.DateDesignated)
+			assert.Equal(t, "Also operates in Pakistan.", entity.OtherInformation)
+			assert.Equal(t, "Used by Taliban leaders to transfer money.", entity.StatementOfReasons)
+			assert.Equal(t, []string{"Branch Number 1, Shop number 237, Kandahar City, Kandahar Province, Afghanistan"}, entity.Addresses)
+			assert.Equal(t, []string{"Kandahar Province"}, entity.StateLocalities)
+			assert.Equal(t, []string{"Afghanistan"}, entity.AddressCountries)
+			assert.Empty(t, entity.DatesOfBirth)
+
+			person := report["RUS0001"]
+			require.NotNil(t, person)
+			assert.Equal(t, "14001", person.OFSIGroupID)
+			assert.Equal(t, []string{"PETROV Ivan Sergeyevich", "PETROFF Ivan"}, person.Names)
+			assert.Equal(t, UKSLIndividual, *person.EntityType)
+			assert.Equal(t, []string{"General"}, person.Titles)
+			assert.Equal(t, []string{"12/05/1965", "12/05/1966"}, person.DatesOfBirth)
+			assert.Equal(t, []string{"Russia"}, person.Nationalities)
+			assert.Equal(t, []string{"Commander"}, person.Positions)
+			assert.Equal(t, []string{"Leningrad"}, person.TownsOfBirth)
+			assert.Equal(t, "Russia", person.CountryOfBirth)
+			assert.Equal(t, "Male", person.Gender)
+			assert.Equal(t, []UKSLDocument{{Number: "720000001", AdditionalInformation: "Russian passport"}}, person.Passports)
+			assert.Equal(t, []UKSLDocument{{Number: "1234567890", AdditionalInformation: "Tax ID"}}, person.NationalIdentifiers)
+		})
+	}
+}
+
+func TestReadUKSanctionsList__defaultColumns(t *testing.T) {
+	// rows without a header use the ODS layout
+	row := make([]string, UKSL_CountryOfBirthIdx+1)
+	row[UKSL_UniqueIDIdx] = "AFG0001"
+	row[UKSL_OFSI_GroupIDIdx] = "12703"
+	row[UKSL_Name6Idx] = "HAJI KHAIRULLAH"
+	row[UKSL_CountryOfBirthIdx] = "Afghanistan"
+
+	_, report, err := parseUKSanctionsListRows([][]string{{"Report Date"}, {}, {"header"}, row})
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	assert.Equal(t, "12703", report["AFG0001"].OFSIGroupID)
+	assert.Equal(t, []string{"HAJI KHAIRULLAH"}, report["AFG0001"].Names)
+	assert.Equal(t, "Afghanistan", report["AFG0001"].CountryOfBirth)
+}
+
+func TestReadUKSanctionsList__SchemaDrift(t *testing.T) {
+	header := []string{"Last Updated", "Unique ID", "OFSI Group ID", "Name Six", "Name type"}
+	row := []string{"12/01/2022", "AFG0001", "12703", "HAJI KHAIRULLAH", "Primary Name"}
+
+	_, _, err := parseUKSanctionsListRows([][]string{{"Report Date"}, header, row})
+	require.ErrorIs(t, err, ErrSchemaDrift)
+	require.Contains(t, err.Error(), `header is missing "Name 6"`)
+}
+
+func TestReadUKCSL__Header(t *testing.T) {
+	row := func(fields map[int]string) []string {
+		out := make([]string, len(UKCSLHeader))
+		for idx, v := range fields {
+			out[idx] = v
+		}
+		return out
+	}
+	toCSV := func(rows ...[]string) string {
+		var buf strings.Builder
+		w := csv.NewWriter(&buf)
+		require.NoError(t, w.WriteAll(rows))
+		return buf.String()
+	}
+
+	// Group ID moved to the front of the file
+	header := append([]string{"Group ID"}, UKCSLHeader[:GroupdIdx]...)
+	record := row(map[int]string{UKNameIdx: "HAJI", UKNameTwoIdx: "KHAIRULLAH", CountryIdx: "Afghanistan"})
+	record = append([]string{"12703"}, record[:GroupdIdx]...)
+
+	rows, _, err := ParseUKCSL(strings.NewReader(toCSV([]string{"Report Date: 05-Mar-2023"}, header, record)))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, 12703, rows[0].GroupID)
+	require.Equal(t, []string{"HAJI KHAIRULLAH"}, rows[0].Names)
+	require.Equal(t, []string{"Afghanistan"}, rows[0].Countries)
+
+	// a header missing a column fails the parse
+	header = append([]string{}, UKCSLHeader...)
+	header[CountryIdx] = "Country of Residence"
+	_, _, err = ParseUKCSL(strings.NewReader(toCSV([]string{"Report Date: 05-Mar-2023"}, header)))
+	require.ErrorIs(t, err, ErrSchemaDrift)
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ukSLDesignation is a Designation element from the UK Sanctions List XML publication
+type ukSLDesignation struct {
+	LastUpdated        string `xml:"LastUpdated"`
+	DateDesignated     string `xml:"DateDesignated"`
+	UniqueID           string `xml:"UniqueID"`
+	OFSIGroupID        string `xml:"OFSIGroupID"`
+	UNReferenceNumber  string `xml:"UNReferenceNumber"`
+	RegimeName         string `xml:"RegimeName"`
+	EntityType         string `xml:"IndividualEntityShip"`
+	DesignationSource  string `xml:"DesignationSource"`
+	OtherInformation   string `xml:"OtherInformation"`
+	StatementOfReasons string `xml:"UKStatementofReasons"`
+
+	Names []struct {
+		Name1    string `xml:"Name1"`
+		Name2    string `xml:"Name2"`
+		Name3    string `xml:"Name3"`
+		Name4    string `xml:"Name4"`
+		Name5    string `xml:"Name5"`
+		Name6    string `xml:"Name6"`
+		NameType string `xml:"NameType"`
+	} `xml:"Names>Name"`
+	NonLatinNames []string `xml:"NonLatinNames>NonLatinName>NameNonLatinScript"`
+	Titles        []string `xml:"Titles>Title"`
+
+	Addresses []struct {
+		Line1      string `xml:"AddressLine1"`
+		Line2      string `xml:"AddressLine2"`
+		Line3      string `xml:"AddressLine3"`
+		Line4      string `xml:"AddressLine4"`
+		Line5      string `xml:"AddressLine5"`
+		Line6      string `xml:"AddressLine6"`
+		PostalCode string `xml:"AddressPostalCode"`
+		Country    string `xml:"AddressCountry"`
+	} `xml:"Addresses>Address"`
+
+	Individual struct {
+		DatesOfBirth  []string `xml:"DOBs>DOB"`
+		Nationalities []string `xml:"Nationalities>Nationality"`
+		Positions     []string `xml:"Positions>Position"`
+		Genders       []string `xml:"Genders>Gender"`
+		BirthDetails  []struct {
+			TownOfBirth    string `xml:"TownOfBirth"`
+			CountryOfBirth string `xml:"CountryOfBirth"`
+		} `xml:"BirthDetails>Location"`
+		Passports []struct {
+			Number                string `xml:"PassportNumber"`
+			AdditionalInformation string `xml:"PassportAdditionalInformation"`
+		} `xml:"PassportDetails>Passport"`
+		NationalIdentifiers []struct {
+			Number                string `xml:"NationalIdentifierNumber"`
+			AdditionalInformation string `xml:"NationalIdentifierAdditionalInformation"`
+		} `xml:"NationalIdentifierDetails>NationalIdentifier"`
+	} `xml:"IndividualDetails>Individual"`
+}
+
+// ParseUKSanctionsListXML reads the XML publication of the UK Sanctions List
+func ParseUKSanctionsListXML(r io.Reader) ([]*UKSanctionsListRecord, UKSanctionsListMap, error) {
+	var doc struct {
+		Designations []ukSLDesignation `xml:"Designation"`
+	}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to read xml: %w", err)
+	}
+
+	var totalReport []*UKSanctionsListRecord
+	report := UKSanctionsListMap{}
+	for i := range doc.Designations {
+		d := doc.Designations[i]
+		uniqueID := strings.TrimSpace(d.UniqueID)
+		if uniqueID == "" {
+			continue
+		}
+		record, ok := report[uniqueID]
+		if !ok {
+			record = &UKSanctionsListRecord{UniqueID: uniqueID}
+			report[uniqueID] = record
+		}
+		d.merge(record)
+	}
+
+	for _, row := range report {
+		totalReport = append(totalReport, row)
+	}
+	return totalReport, report, nil
+}
+
+func (d ukSLDesignation) merge(record *UKSanctionsListRecord) {
+	setIfEmpty(&record.LastUpdated, strings.TrimSpace(d.LastUpdated))
+	setIfEmpty(&record.OFSIGroupID, strings.TrimSpace(d.OFSIGroupID))
+	setIfEmpty(&record.UNReferenceNumber, strings.TrimSpace(d.UNReferenceNumber))
+	setIfEmpty(&record.RegimeName, strings.TrimSpace(d.RegimeName))
+	setIfEmpty(&record.DesignationSource, strings.TrimSpace(d.DesignationSource))
+	setIfEmpty(&record.DateDesignated, strings.TrimSpace(d.DateDesignated))
+	setIfEmpty(&record.OtherInformation, strings.TrimSpace(d.OtherInformation))
+	setIfEmpty(&record.StatementOfReasons, strings.TrimSpace(d.StatementOfReasons))
+
+	if v := strings.TrimSpace(d.EntityType); v != "" && record.EntityType == nil {
+		entityType := EntityStringMap[v]
+		record.EntityType = &entityType
+	}
+
+	for _, n := range d.Names {
+		name := joinNonEmpty(" ", n.Name6, n.Name1, n.Name2, n.Name3, n.Name4, n.Name5)
+		record.Names = appendUnique(record.Names, name)
+		setIfEmpty(&record.NameTitle, strings.TrimSpace(n.NameType))
+	}
+	for _, n := range d.NonLatinNames {
+		record.NonLatinScriptNames = appendUnique(record.NonLatinScriptNames, strings.TrimSpace(n))
+	}
+	for _, t := range d.Titles {
+		record.Titles = appendUnique(record.Titles, strings.TrimSpace(t))
+	}
+
+	for _, addr := range d.Addresses {
+		record.StateLocalities = appendUnique(record.StateLocalities, strings.TrimSpace(addr.Line6))
+		record.AddressCountries = appendUnique(record.AddressCountries, strings.TrimSpace(addr.Country))
+		address := joinNonEmpty(", ", addr.Line1, addr.Line2, addr.Line3, addr.Line4, addr.Line5, addr.Line6, addr.PostalCode, addr.Country)
+		record.Addresses = appendUnique(record.Addresses, address)
+	}
+
+	ind := d.Individual
+	for _, v := range ind.DatesOfBirth {
+		record.DatesOfBirth = appendUnique(record.DatesOfBirth, strings.TrimSpace(v))
+	}
+	for _, v := range ind.Nationalities {
+		record.Nationalities = appendUnique(record.Nationalities, strings.TrimSpace(v))
+	}
+	for _, v := range ind.Positions {
+		record.Positions = appendUnique(record.Positions, strings.TrimSpace(v))
+	}
+	for _, v := range ind.Genders {
+		setIfEmpty(&record.Gender, strings.TrimSpace(v))
+	}
+	for _, v := range ind.BirthDetails {
+		record.TownsOfBirth = appendUnique(record.TownsOfBirth, strings.TrimSpace(v.TownOfBirth))
+		setIfEmpty(&record.CountryOfBirth, strings.TrimSpace(v.CountryOfBirth))
+	}
+	for _, v := range ind.Passports {
+		record.Passports = appendUKSLDocument(record.Passports, UKSLDocument{
+			Number:                strings.TrimSpace(v.Number),
+			AdditionalInformation: strings.TrimSpace(v.AdditionalInformation),
+		})
+	}
+	for _, v := range ind.NationalIdentifiers {
+		record.NationalIdentifiers = appendUKSLDocument(record.NationalIdentifiers, UKSLDocument{
+			Number:                strings.TrimSpace(v.Number),
+			AdditionalInformation: strings.TrimSpace(v.AdditionalInformation),
+		})
+	}
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderDrift(t *testing.T) {
+	require.Empty(t, HeaderDrift(CSLHeader, CSLHeader))
+	require.Empty(t, HeaderDrift([]string{"a", "b"}, []string{"\ufeffA", " b ", "c"}))
+
+	drift := HeaderDrift([]string{"name", "title", "addresses"}, []string{"name", "addresses"})
+	require.Equal(t, []string{
+		`column 1: expected "title", found "addresses"`,
+		`column 2: expected "addresses", but it is missing`,
+	}, drift)
+}
+
+func TestRead__Quality(t *testing.T) {
+	report, err := ReadFile(filepath.Join("..", "..", "test", "testdata", "csl.csv"))
+	require.NoError(t, err)
+	require.Empty(t, report.HeaderDrift)
+
+	// swap the name and title columns
+	columns := append([]string{"_id"}, CSLHeader...)
+	columns[NameIdx+1], columns[TitleIdx+1] = columns[TitleIdx+1], columns[NameIdx+1]
+	row := make([]string, len(columns))
+	row[0], row[SourceIdx+1] = "1", "Entity List (EL) - Bureau of Industry and Security"
+	row[NameIdx+1], row[TitleIdx+1] = "Director", "Acme Corp"
+
+	input := strings.Join(columns, ",") + "\n" + strings.Join(row, ",") + "\n"
+	input += `2,Entity List (EL) - Bureau of Industry and Security,"bad"quote` + "\n"
+
+	report, err = Parse(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`column 5: expected "name", found "title"`,
+		`column 6: expected "title", found "name"`,
+	}, report.HeaderDrift)
+	require.Equal(t, 1, report.Skipped[SkipMalformedRow])
+
+	// the moved columns are read by name
+	require.Len(t, report.ELs, 1)
+	require.Equal(t, "1", report.ELs[0].ID)
+	require.Equal(t, "Acme Corp", report.ELs[0].Name)
+}
+
+func TestRead__SchemaDrift(t *testing.T) {
+	columns := append([]string{}, CSLHeader...)
+	columns[AddressesIdx] = "street_addresses"
+
+	_, err := Parse(strings.NewReader(strings.Join(columns, ",") + "\n"))
+	require.ErrorIs(t, err, ErrSchemaDrift)
+	require.Contains(t, err.Error(), `header is missing "addresses"`)
+}
@@ -103,6 +103,26 @@ type UKSanctionsListRecord struct {
 	StateLocalities     []string
 	AddressCountries    []string
 	CountryOfBirth      string
+
+	Titles              []string
+	RegimeName          string
+	DesignationSource   string
+	DateDesignated      string
+	OtherInformation    string
+	StatementOfReasons  string
+	DatesOfBirth        []string
+	TownsOfBirth        []string
+	Nationalities       []string
+	Positions           []string
+	Gender              string
+	Passports           []UKSLDocument
+	NationalIdentifiers []UKSLDocument
+}
+
+// UKSLDocument is a passport or national identifier listed for an individual
+type UKSLDocument struct {
+	Number                string
+	AdditionalInformation string
 }
 
 type UKSLEntityType string
@@ -129,3 +149,46 @@ const (
 func (et UKSLEntityType) String() string {
 	return string(et)
 }
+
+// ListedBirthDates returns the dates of birth listed for the entity
+func (r UKCSLRecord) ListedBirthDates() []string {
+	return r.DatesOfBirth
+}
+
+// ListedIDNumbers returns nil, as the UK CSL doesn't list IDs
+func (r UKCSLRecord) ListedIDNumbers() []string {
+	return nil
+}
+
+// ListedCountries returns the nationalities, countries of birth and address countries listed for the entity
+func (r UKCSLRecord) ListedCountries() []string {
+	out := append([]string{}, r.Nationalities...)
+	out = append(out, r.CountriesOfBirth...)
+	return append(out, r.Countries...)
+}
+
+// ListedBirthDates returns the dates of birth listed for the entity
+func (r UKSanctionsListRecord) ListedBirthDates() []string {
+	return r.DatesOfBirth
+}
+
+// ListedIDNumbers returns the numbers of the passports and national identifiers listed for the entity
+func (r UKSanctionsListRecord) ListedIDNumbers() []string {
+	var out []string
+	for i := range r.Passports {
+		out = append(out, r.Passports[i].Number)
+	}
+	for i := range r.NationalIdentifiers {
+		out = append(out, r.NationalIdentifiers[i].Number)
+	}
+	return out
+}
+
+// ListedCountries returns the nationalities, country of birth and address countries listed for the entity
+func (r UKSanctionsListRecord) ListedCountries() []string {
+	out := append([]string{}, r.Nationalities...)
+	if r.CountryOfBirth != "" {
+		out = append(out, r.CountryOfBirth)
+	}
+	return append(out, r.AddressCountries...)
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cacheEntry describes a file kept in the cache directory, which is written alongside
+// the file as <filename>.json
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Size         int64     `json:"size"`
+	DownloadedAt time.Time `json:"downloadedAt"`
+}
+
+// cached returns the cache entry for a file if it was downloaded from the same URL and
+// can be requested conditionally.
+func (dl *Downloader) cached(filename, downloadURL string) *cacheEntry {
+	if dl.CacheDir == "" {
+		return nil
+	}
+	bs, err := os.ReadFile(filepath.Join(dl.CacheDir, filename+".json"))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(bs, &entry); err != nil {
+		return nil
+	}
+	if entry.URL != downloadURL || (entry.ETag == "" && entry.LastModified == "") {
+		return nil
+	}
+	info, err := os.Stat(filepath.Join(dl.CacheDir, filename))
+	if err != nil || info.Size() != entry.Size {
+		return nil
+	}
+	return &entry
+}
+
+// store copies a downloaded file into the cache directory
+func (dl *Downloader) store(path, filename string, entry cacheEntry) error {
+	if err := os.MkdirAll(dl.CacheDir, 0755); err != nil {
+		return err
+	}
+	if err := copyFile(path, filepath.Join(dl.CacheDir, filename)); err != nil {
+		return err
+	}
+	bs, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dl.CacheDir, filename+".json"), bs, 0644)
+}
+
+func (dl *Downloader) copyFromCache(dir, filename string) error {
+	if err := copyFile(filepath.Join(dl.CacheDir, filename), filepath.Join(dir, filename)); err != nil {
+		return fmt.Errorf("reading cached file: %v", err)
+	}
+	return nil
+}
+
+// copyFile copies src to dst through a temporary file so dst is never partially written
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name())
+
+	_, err = io.Copy(out, in)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(out.Name(), dst)
+}
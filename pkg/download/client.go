@@ -5,9 +5,11 @@
 package download
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
@@ -24,12 +26,22 @@ var (
 	HTTPClient = &http.Client{
 		Timeout: 15 * time.Second,
 	}
+
+	// CacheDir is where downloaded files are kept between runs so unchanged files can be
+	// requested conditionally. It's read from DOWNLOAD_CACHE_DIRECTORY and empty disables caching.
+	CacheDir = os.Getenv("DOWNLOAD_CACHE_DIRECTORY")
+)
+
+const (
+	defaultAttempts = 3
+	defaultBackoff  = 500 * time.Millisecond
 )
 
 func New(logger log.Logger, httpClient *http.Client) *Downloader {
 	return &Downloader{
-		HTTP:   httpClient,
-		Logger: logger,
+		HTTP:     httpClient,
+		Logger:   logger,
+		CacheDir: CacheDir,
 	}
 }
 
@@ -41,6 +53,63 @@ func New(logger log.Logger, httpClient *http.Client) *Downloader {
 type Downloader struct {
 	HTTP   *http.Client
 	Logger log.Logger
+
+	// CacheDir keeps each downloaded file along with its ETag and Last-Modified headers.
+	// Later downloads send a conditional request and reuse the cached file when the server
+	// responds 304 Not Modified.
+	CacheDir string
+
+	// Expectations are checked against downloaded files keyed by their filename
+	Expectations map[string]Expectations
+
+	// Attempts is how many times a file is requested before giving up. Backoff is the delay
+	// after the first failed attempt, which doubles after each attempt.
+	Attempts int
+	Backoff  time.Duration
+}
+
+// Expectations describe what a valid download of a file looks like
+type Expectations struct {
+	// MinRecords is the fewest lines the file must have
+	MinRecords int
+}
+
+// FileError is returned when a file couldn't be downloaded or failed validation
+type FileError struct {
+	Filename string
+	URL      string
+	Err      error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Filename, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// StatusError is an unexpected HTTP status code
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// retryable reports if a failed download could succeed on another attempt. Client errors
+// other than timeouts and rate limits won't.
+func retryable(err error) bool {
+	var status *StatusError
+	if errors.As(err, &status) {
+		switch status.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests:
+			return true
+		}
+		return status.StatusCode >= 500
+	}
+	return true
 }
 
 // GetFiles will download all provided files, return their filepaths, and store them in a
@@ -48,6 +117,9 @@ type Downloader struct {
 //
 // initialDir is an optional filepath to look for files in before attempting to download.
 //
+// The filepaths of files which were found or downloaded are returned along with a
+// *FileError for each file which couldn't be downloaded.
+//
 // Callers are expected to cleanup the temp directory.
 func (dl *Downloader) GetFiles(initialDir string, namesAndSources map[string]string) ([]string, error) {
 	if dl == nil {
@@ -81,6 +153,7 @@ func (dl *Downloader) GetFiles(initialDir string, namesAndSources map[string]str
 
 	var mu sync.Mutex
 	var out []string
+	var errs []error
 
 	var wg sync.WaitGroup
 	wg.Add(len(namesAndSources))
@@ -109,23 +182,24 @@ func (dl *Downloader) GetFiles(initialDir string, namesAndSources map[string]str
 			logger := dl.createLogger(filename, downloadURL)
 
 			startTime := time.Now().In(time.UTC)
-			err := dl.retryDownload(dir, filename, downloadURL)
+			err := dl.retryDownload(logger, dir, filename, downloadURL)
 			dur := time.Now().In(time.UTC).Sub(startTime)
 
+			mu.Lock()
+			defer mu.Unlock()
+
 			if err != nil {
 				logger.Error().LogErrorf("FAILURE after %v to download: %v", dur, err)
-			} else {
-				logger.Info().Logf("successful download after %v", dur)
+				errs = append(errs, &FileError{Filename: filename, URL: downloadURL, Err: err})
+				return
 			}
-
-			mu.Lock()
+			logger.Info().Logf("successful download after %v", dur)
 			out = append(out, filepath.Join(dir, filename))
-			mu.Unlock()
 		}(&wg, name, source)
 	}
 	wg.Wait()
 
-	return out, nil
+	return out, errors.Join(errs...)
 }
 
 func (dl *Downloader) createLogger(filename, downloadURL string) log.Logger {
@@ -140,41 +214,147 @@ func (dl *Downloader) createLogger(filename, downloadURL string) log.Logger {
 	})
 }
 
-func (dl *Downloader) retryDownload(dir, filename, downloadURL string) error {
-	// Allow a couple retries for various sources (some are flakey)
-	for i := 0; i < 3; i++ {
-		req, err := http.NewRequest(http.MethodGet, downloadURL, nil)
-		if err != nil {
-			return dl.Logger.Error().LogErrorf("error building HTTP request: %v", err).Err()
-		}
-		req.Header.Set("User-Agent", fmt.Sprintf("moov-io/watchman:%v", watchman.Version))
-		// in order to get passed europes 406 (Not Accepted)
-		req.Header.Set("accept-language", "en-US,en;q=0.9")
+func (dl *Downloader) retryDownload(logger log.Logger, dir, filename, downloadURL string) error {
+	attempts := dl.Attempts
+	if attempts <= 0 {
+		attempts = defaultAttempts
+	}
+	backoff := dl.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
 
-		resp, err := dl.HTTP.Do(req)
-		if err != nil {
-			dl.Logger.Error().LogErrorf("err while doing client request: ", err)
-			time.Sleep(100 * time.Millisecond)
-			continue // retry
+	var err error
+	for i := 1; i <= attempts; i++ {
+		err = dl.download(logger, dir, filename, downloadURL)
+		if err == nil || !retryable(err) {
+			return err
 		}
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			dl.Logger.Error().LogErrorf("we experienced a problem in the dl: %v", resp.StatusCode)
+		if i < attempts {
+			logger.Warn().Logf("attempt %d of %d failed, retrying in %v: %v", i, attempts, backoff, err)
+			time.Sleep(backoff)
+			backoff *= 2
 		}
+	}
+	return err
+}
 
-		// Copy resp.Body into a file in our temp dir
-		fd, err := os.Create(filepath.Join(dir, filename))
-		if err != nil {
-			resp.Body.Close()
-			return fmt.Errorf("attempt %d failed to create file: %v", i, err)
+func (dl *Downloader) download(logger log.Logger, dir, filename, downloadURL string) error {
+	req, err := http.NewRequest(http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return fmt.Errorf("error building HTTP request: %v", err)
+	}
+	req.Header.Set("User-Agent", fmt.Sprintf("moov-io/watchman:%v", watchman.Version))
+	// in order to get passed europes 406 (Not Accepted)
+	req.Header.Set("accept-language", "en-US,en;q=0.9")
+
+	cached := dl.cached(filename, downloadURL)
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
 		}
+	}
+
+	resp, err := dl.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		logger.Info().Log("not modified, using cached file")
+		return dl.copyFromCache(dir, filename)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+	if err := checkContentType(filename, resp.Header.Get("Content-Type")); err != nil {
+		return err
+	}
+
+	// Write the file under a temporary name so failed downloads never replace it
+	fd, err := os.CreateTemp(dir, filename+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	defer os.Remove(fd.Name())
+
+	lines := &lineCounter{}
+	n, err := io.Copy(io.MultiWriter(fd, lines), resp.Body)
+	if cerr := fd.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("reading response after %d bytes: %v", n, err)
+	}
+	if resp.ContentLength >= 0 && n != resp.ContentLength {
+		return fmt.Errorf("got %d bytes but Content-Length was %d", n, resp.ContentLength)
+	}
+	if n == 0 {
+		return errors.New("empty response")
+	}
+	if min := dl.Expectations[filename].MinRecords; min > 0 && lines.records() < min {
+		return fmt.Errorf("found %d records but expected at least %d", lines.records(), min)
+	}
 
-		io.Copy(fd, resp.Body) // copy file contents
+	path := filepath.Join(dir, filename)
+	if err := os.Rename(fd.Name(), path); err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
 
-		// close the open files
-		fd.Close()
-		resp.Body.Close()
-		return nil // quit after successful download
+	if dl.CacheDir != "" {
+		entry := cacheEntry{
+			URL:          downloadURL,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Size:         n,
+			DownloadedAt: time.Now().In(time.UTC),
+		}
+		if err := dl.store(path, filename, entry); err != nil {
+			logger.Warn().Logf("problem caching download: %v", err)
+		}
 	}
 	return nil
 }
+
+// checkContentType rejects HTML responses (e.g. an error page served with a 200 status)
+// for files which aren't HTML.
+func checkContentType(filename, contentType string) error {
+	if contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil // servers send all kinds of values, only reject ones we understand
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+	if mediaType == "text/html" && ext != ".html" && ext != ".htm" {
+		return fmt.Errorf("unexpected Content-Type %s for %s file", mediaType, ext)
+	}
+	return nil
+}
+
+// lineCounter counts the lines written to it
+type lineCounter struct {
+	lines    int
+	lastByte byte
+}
+
+func (c *lineCounter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		c.lines += bytes.Count(p, []byte{'\n'})
+		c.lastByte = p[len(p)-1]
+	}
+	return len(p), nil
+}
+
+// records is the number of lines, including a final line without a newline
+func (c *lineCounter) records() int {
+	if c.lastByte != 0 && c.lastByte != '\n' {
+		return c.lines + 1
+	}
+	return c.lines
+}
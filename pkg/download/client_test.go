@@ -0,0 +1,192 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testDownloader(t *testing.T) *Downloader {
+	t.Helper()
+
+	dl := New(log.NewNopLogger(), http.DefaultClient)
+	dl.CacheDir = ""
+	dl.Backoff = time.Millisecond
+	return dl
+}
+
+func TestDownloader__GetFiles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("1,a\n2,b\n3,c"))
+	}))
+	defer srv.Close()
+
+	dl := testDownloader(t)
+	dl.Expectations = map[string]Expectations{
+		"sdn.csv": {MinRecords: 3},
+	}
+	files, err := dl.GetFiles("", map[string]string{
+		"sdn.csv": srv.URL + "/sdn.csv",
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	defer os.RemoveAll(filepath.Dir(files[0]))
+
+	bs, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Equal(t, "1,a\n2,b\n3,c", string(bs))
+}
+
+func TestDownloader__validation(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		switch r.URL.Path {
+		case "/error.csv":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("<html>Internal Server Error</html>"))
+		case "/missing.csv":
+			w.WriteHeader(http.StatusNotFound)
+		case "/html.csv":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html>Maintenance</html>"))
+		case "/short.csv":
+			w.Write([]byte("1,a\n"))
+		case "/empty.csv":
+		}
+	}))
+	defer srv.Close()
+
+	dl := testDownloader(t)
+	dl.Expectations = map[string]Expectations{
+		"short.csv": {MinRecords: 100},
+	}
+
+	cases := map[string]string{
+		"error.csv":   "unexpected HTTP status 500",
+		"missing.csv": "unexpected HTTP status 404",
+		"html.csv":    "unexpected Content-Type text/html",
+		"short.csv":   "found 1 records but expected at least 100",
+		"empty.csv":   "empty response",
+	}
+	for filename, expected := range cases {
+		attempts.Store(0)
+		dir := t.TempDir()
+
+		files, err := dl.GetFiles(dir, map[string]string{
+			filename: srv.URL + "/" + filename,
+		})
+		require.Empty(t, files)
+		require.ErrorContains(t, err, filename+": "+expected)
+
+		var fileErr *FileError
+		require.True(t, errors.As(err, &fileErr))
+		require.Equal(t, filename, fileErr.Filename)
+
+		// nothing is left behind
+		_, err = os.Stat(filepath.Join(dir, filename))
+		require.True(t, os.IsNotExist(err))
+
+		// client errors aren't retried
+		if filename == "missing.csv" {
+			require.Equal(t, int32(1), attempts.Load())
+		} else {
+			require.Equal(t, int32(defaultAttempts), attempts.Load(), filename)
+		}
+	}
+}
+
+func TestDownloader__retry(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("1,a\n"))
+	}))
+	defer srv.Close()
+
+	dl := testDownloader(t)
+	files, err := dl.GetFiles(t.TempDir(), map[string]string{
+		"sdn.csv": srv.URL,
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, int32(3), attempts.Load())
+}
+
+func TestDownloader__conditional(t *testing.T) {
+	var downloads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Tue, 21 Mar 2023 09:04:00 GMT")
+		w.Write([]byte("1,a\n2,b\n"))
+	}))
+	defer srv.Close()
+
+	dl := testDownloader(t)
+	dl.CacheDir = t.TempDir()
+
+	for i := 0; i < 2; i++ {
+		dir := t.TempDir()
+		files, err := dl.GetFiles(dir, map[string]string{
+			"sdn.csv": srv.URL,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{filepath.Join(dir, "sdn.csv")}, files)
+
+		bs, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		require.Equal(t, "1,a\n2,b\n", string(bs))
+	}
+	require.Equal(t, int32(1), downloads.Load())
+
+	entry := dl.cached("sdn.csv", srv.URL)
+	require.NotNil(t, entry)
+	require.Equal(t, `"v1"`, entry.ETag)
+	require.Equal(t, int64(8), entry.Size)
+
+	// a different URL isn't requested conditionally
+	require.Nil(t, dl.cached("sdn.csv", srv.URL+"/other"))
+}
+
+func TestDownloader__lineCounter(t *testing.T) {
+	for input, expected := range map[string]int{
+		"":          0,
+		"a":         1,
+		"a\n":       1,
+		"a\nb":      2,
+		"a\nb\nc\n": 3,
+	} {
+		c := &lineCounter{}
+		c.Write([]byte(input))
+		require.Equal(t, expected, c.records(), input)
+	}
+
+	// lines split across writes
+	c := &lineCounter{}
+	for _, part := range strings.SplitAfter("a,b\nc,d\ne,f", ",") {
+		c.Write([]byte(part))
+	}
+	require.Equal(t, 3, c.records())
+}
@@ -25,6 +25,9 @@ var (
 // Download returns an array of absolute filepaths for files downloaded
 func Download(logger log.Logger, initialDir string) (string, error) {
 	dl := download.New(logger, download.HTTPClient)
+	dl.Expectations = map[string]download.Expectations{
+		"dpl.txt": {MinRecords: 100},
+	}
 
 	addrs := make(map[string]string)
 	addrs["dpl.txt"] = fmt.Sprintf(dplDownloadTemplate, "dpl.txt")
@@ -7,18 +7,51 @@ package dpl
 import (
 	"encoding/csv"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+
+	"github.com/moov-io/watchman/internal/schema"
+)
+
+// Header is the header row of the DPL file, in the order Read expects its columns
+var Header = []string{
+	"Name", "Street_Address", "City", "State", "Country", "Postal_Code", "Effective_Date",
+	"Expiration_Date", "Standard_Order", "Last_Update", "Action", "FR_Citation",
+}
+
+// ErrSchemaDrift is matched (with errors.Is) by the error returned when the file's header
+// is missing columns Read needs
+var ErrSchemaDrift = schema.ErrDrift
+
+// Reasons a row is skipped while reading the DPL file
+const (
+	SkipMalformedRow = "malformed row"
+	SkipColumnCount  = "unexpected column count"
 )
 
+// ReadReport describes the rows left out while reading a DPL file
+type ReadReport struct {
+	// Skipped counts the rows left out of the results by reason
+	Skipped map[string]int
+	// HeaderDrift describes how the file's header row differs from Header
+	HeaderDrift []string
+}
+
 // Read parses DPL records from a TXT file and populates the associated arrays.
 //
 // For more details on the raw DPL files see https://moov-io.github.io/watchman/file-structure.html
 func Read(path string) ([]*DPL, error) {
+	out, _, err := ReadWithReport(path)
+	return out, err
+}
+
+// ReadWithReport parses DPL records like Read and also returns a report of the rows which were skipped.
+func ReadWithReport(path string) ([]*DPL, *ReadReport, error) {
 	// open txt file
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer f.Close()
 
@@ -28,6 +61,10 @@ func Read(path string) ([]*DPL, error) {
 
 	// Loop through all lines we can
 	var out []*DPL
+	report := &ReadReport{
+		Skipped: make(map[string]int),
+	}
+	var layout *schema.Layout
 	for {
 		line, err := reader.Read()
 		if err != nil {
@@ -40,14 +77,27 @@ func Read(path string) ([]*DPL, error) {
 				if errors.Is(err, csv.ErrFieldCount) ||
 					errors.Is(err, csv.ErrBareQuote) ||
 					errors.Is(err, csv.ErrQuote) {
+					report.Skipped[SkipMalformedRow]++
 					continue
 				}
-				return nil, err
+				return nil, nil, err
 			}
 		}
 
-		if len(line) < 12 || (len(line) >= 2 && line[1] == "Street_Address") {
-			continue // skip malformed headers
+		if isHeader(line) {
+			report.HeaderDrift = schema.Drift(Header, line)
+			layout, err = schema.Resolve(Header, line)
+			if err != nil {
+				return nil, report, fmt.Errorf("DPL: %w", err)
+			}
+			continue
+		}
+		line = layout.Row(line)
+		if len(line) < 12 {
+			if len(line) > 1 {
+				report.Skipped[SkipColumnCount]++
+			}
+			continue // skip malformed rows
 		}
 
 		deniedPerson := &DPL{
@@ -66,5 +116,19 @@ func Read(path string) ([]*DPL, error) {
 		}
 		out = append(out, deniedPerson)
 	}
-	return out, nil
+	return out, report, nil
+}
+
+// isHeader reports if line is the header row, wherever its columns are
+func isHeader(line []string) bool {
+	var name, street bool
+	for i := range line {
+		switch schema.Normalize(line[i]) {
+		case "name":
+			name = true
+		case "streetaddress":
+			street = true
+		}
+	}
+	return name && street
 }
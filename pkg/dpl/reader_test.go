@@ -5,7 +5,10 @@
 package dpl
 
 import (
+	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -27,3 +30,54 @@ func TestDPL__read(t *testing.T) {
 		t.Errorf("found %d DPL records, wanted 0", len(got))
 	}
 }
+
+func TestDPL__readWithReport(t *testing.T) {
+	dpls, report, err := ReadWithReport(filepath.Join("..", "..", "test", "testdata", "dpl.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dpls) != 546 {
+		t.Errorf("found %d DPL records", len(dpls))
+	}
+	if len(report.HeaderDrift) != 0 {
+		t.Errorf("unexpected header drift: %v", report.HeaderDrift)
+	}
+
+	if len(report.Skipped) != 0 {
+		t.Errorf("unexpected skipped rows: %v", report.Skipped)
+	}
+}
+
+func TestDPL__schemaDrift(t *testing.T) {
+	dir := t.TempDir()
+	write := func(lines ...string) string {
+		path := filepath.Join(dir, "dpl.txt")
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// City and Street_Address have swapped places
+	header := append([]string{}, Header...)
+	header[1], header[2] = header[2], header[1]
+	row := []string{"JOHN DOE", "TUCSON", "123 MAIN ST", "AZ", "US", "85701", "10/16/2017", "10/13/2020", "Y", "2017-10-23", "FR NOTICE ADDED", "82 F.R. 48792"}
+
+	dpls, report, err := ReadWithReport(write(strings.Join(header, "\t"), strings.Join(row, "\t")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.HeaderDrift) != 2 {
+		t.Errorf("unexpected header drift: %v", report.HeaderDrift)
+	}
+	if len(dpls) != 1 || dpls[0].StreetAddress != "123 MAIN ST" || dpls[0].City != "TUCSON" {
+		t.Errorf("unexpected DPL records: %#v", dpls)
+	}
+
+	// a column which can't be found fails the read
+	header[5] = "Zip"
+	_, _, err = ReadWithReport(write(strings.Join(header, "\t"), strings.Join(row, "\t")))
+	if !errors.Is(err, ErrSchemaDrift) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
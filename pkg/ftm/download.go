@@ -0,0 +1,27 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ftm
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DefaultFilename is the name of the FtM JSON Lines file imported from the initial data directory.
+// It matches the filename OpenSanctions publishes its exports under.
+const DefaultFilename = "entities.ftm.json"
+
+// LocateFile returns the filepath of an FtM entities file within initialDir.
+// An empty string is returned when no file exists.
+func LocateFile(initialDir string) string {
+	if initialDir == "" {
+		return ""
+	}
+	path := filepath.Join(initialDir, DefaultFilename)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
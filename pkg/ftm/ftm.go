@@ -0,0 +1,175 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ftm
+
+import (
+	"strings"
+)
+
+// Entity is a FollowTheMoney (FtM) entity as published by OpenSanctions and other tools.
+//
+// See: https://followthemoney.tech/explorer/
+type Entity struct {
+	// ID is the unique identifier of the entity within its dataset
+	ID string `json:"id"`
+	// Schema is the FtM schema name (e.g. Person, Company, Organization, Occupancy)
+	Schema string `json:"schema"`
+	// Caption is a human readable label for the entity
+	Caption string `json:"caption,omitempty"`
+	// Properties holds each property value of the entity. FtM properties are always multi-valued.
+	Properties map[string][]string `json:"properties"`
+	// Datasets lists which datasets the entity was published in
+	Datasets []string `json:"datasets,omitempty"`
+	// Referents are previous IDs of the entity which have been merged into this one
+	Referents []string `json:"referents,omitempty"`
+	// FirstSeen is when the entity was first published
+	FirstSeen string `json:"first_seen,omitempty"`
+	// LastSeen is when the entity was most recently published
+	LastSeen string `json:"last_seen,omitempty"`
+	// Target is true when the entity is a sanctions target
+	Target bool `json:"target,omitempty"`
+}
+
+// Commonly used FtM schemas
+const (
+	SchemaPerson       = "Person"
+	SchemaCompany      = "Company"
+	SchemaOrganization = "Organization"
+	SchemaLegalEntity  = "LegalEntity"
+	SchemaVessel       = "Vessel"
+	SchemaAirplane     = "Airplane"
+	SchemaPosition     = "Position"
+	SchemaOccupancy    = "Occupancy"
+	SchemaAddress      = "Address"
+	SchemaIdentifier   = "Identification"
+	SchemaPassport     = "Passport"
+	SchemaSanction     = "Sanction"
+)
+
+// TopicPEP is the topic OpenSanctions assigns to politically exposed persons
+const TopicPEP = "role.pep"
+
+// Get returns every value of a property.
+func (e *Entity) Get(prop string) []string {
+	if e == nil || e.Properties == nil {
+		return nil
+	}
+	return e.Properties[prop]
+}
+
+// First returns the first value of a property or an empty string.
+func (e *Entity) First(prop string) string {
+	if vs := e.Get(prop); len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+// Add appends non-empty values onto a property, skipping any duplicates.
+func (e *Entity) Add(prop string, values ...string) {
+	if e.Properties == nil {
+		e.Properties = make(map[string][]string)
+	}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || contains(e.Properties[prop], v) {
+			continue
+		}
+		e.Properties[prop] = append(e.Properties[prop], v)
+	}
+}
+
+// ListedBirthDates returns the entity's birthDate values.
+func (e *Entity) ListedBirthDates() []string {
+	return e.Get("birthDate")
+}
+
+// ListedIDNumbers returns the entity's ID, passport, tax and registration numbers.
+func (e *Entity) ListedIDNumbers() []string {
+	var out []string
+	for _, prop := range []string{"idNumber", "passportNumber", "taxNumber", "registrationNumber"} {
+		out = append(out, e.Get(prop)...)
+	}
+	return out
+}
+
+// ListedCountries returns the entity's country, nationality, citizenship and jurisdiction values.
+func (e *Entity) ListedCountries() []string {
+	var out []string
+	for _, prop := range []string{"country", "nationality", "citizenship", "jurisdiction"} {
+		out = append(out, e.Get(prop)...)
+	}
+	return out
+}
+
+// HasTopic returns true if the entity has been tagged with the given topic.
+func (e *Entity) HasTopic(topic string) bool {
+	return contains(e.Get("topics"), topic)
+}
+
+// Names returns the primary name followed by any aliases and weak aliases.
+func (e *Entity) Names() []string {
+	var out []string
+	for _, prop := range []string{"name", "alias", "weakAlias", "previousName"} {
+		for _, v := range e.Get(prop) {
+			if !contains(out, v) {
+				out = append(out, v)
+			}
+		}
+	}
+	if len(out) == 0 && e.Caption != "" {
+		out = append(out, e.Caption)
+	}
+	return out
+}
+
+// AlternateNames returns every name of the entity except the primary name.
+func (e *Entity) AlternateNames() []string {
+	names := e.Names()
+	if len(names) <= 1 {
+		return nil
+	}
+	return names[1:]
+}
+
+// IsA returns true when the entity's schema is any of the provided schemas.
+func (e *Entity) IsA(schemas ...string) bool {
+	for i := range schemas {
+		if strings.EqualFold(e.Schema, schemas[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchableSchemas are the schemas of entities which can be screened by name.
+var SearchableSchemas = []string{
+	SchemaPerson,
+	SchemaCompany,
+	SchemaOrganization,
+	SchemaLegalEntity,
+	SchemaVessel,
+	SchemaAirplane,
+}
+
+// Filter returns the entities whose schema is any of the provided schemas.
+func Filter(entities []*Entity, schemas ...string) []*Entity {
+	var out []*Entity
+	for i := range entities {
+		if entities[i] != nil && entities[i].IsA(schemas...) {
+			out = append(out, entities[i])
+		}
+	}
+	return out
+}
+
+func contains(values []string, needle string) bool {
+	for i := range values {
+		if values[i] == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ftm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// maxLineSize is the largest single entity (in bytes) we'll read from a JSON Lines file.
+const maxLineSize = 16 * 1024 * 1024
+
+// ReadFile parses the FtM entities stored at path.
+func ReadFile(path string) ([]*Entity, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return Read(fd)
+}
+
+// Read parses FtM entities from r. Both JSON Lines (one entity per line) and a
+// JSON array of entities are accepted.
+func Read(r io.Reader) ([]*Entity, error) {
+	br := bufio.NewReader(r)
+
+	// Peek at the first non-whitespace character to decide on the format
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n' {
+			br.ReadByte()
+			continue
+		}
+		if b[0] == '[' {
+			var out []*Entity
+			if err := json.NewDecoder(br).Decode(&out); err != nil {
+				return nil, fmt.Errorf("ftm: reading JSON array: %w", err)
+			}
+			return out, nil
+		}
+		break
+	}
+
+	var out []*Entity
+	scanner := bufio.NewScanner(br)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		bs := bytes.TrimSpace(scanner.Bytes())
+		if len(bs) == 0 {
+			continue
+		}
+		var entity Entity
+		if err := json.Unmarshal(bs, &entity); err != nil {
+			return nil, fmt.Errorf("ftm: line %d: %w", line, err)
+		}
+		out = append(out, &entity)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ftm: line %d: %w", line, err)
+	}
+	return out, nil
+}
+
+// UnmarshalJSON reads an FtM entity. Nested entities (found in "nested" exports)
+// are collapsed into their ID so properties always hold strings.
+func (e *Entity) UnmarshalJSON(data []byte) error {
+	type plain Entity
+	var aux struct {
+		plain
+		Properties map[string][]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*e = Entity(aux.plain)
+	e.Properties = make(map[string][]string, len(aux.Properties))
+	for prop, values := range aux.Properties {
+		for _, raw := range values {
+			var str string
+			if err := json.Unmarshal(raw, &str); err == nil {
+				e.Properties[prop] = append(e.Properties[prop], str)
+				continue
+			}
+			var nested Entity
+			if err := json.Unmarshal(raw, &nested); err == nil && nested.ID != "" {
+				e.Properties[prop] = append(e.Properties[prop], nested.ID)
+			}
+		}
+	}
+	return nil
+}
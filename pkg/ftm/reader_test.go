@@ -0,0 +1,49 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ftm
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFile(t *testing.T) {
+	entities, err := ReadFile(filepath.Join("..", "..", "test", "testdata", "pep.json"))
+	require.NoError(t, err)
+	require.Len(t, entities, 8)
+
+	jane := entities[0]
+	require.Equal(t, "Q1001", jane.ID)
+	require.True(t, jane.IsA(SchemaPerson))
+	require.True(t, jane.HasTopic(TopicPEP))
+	require.Equal(t, []string{"Jane Example", "Jane Q. Example"}, jane.Names())
+	require.Equal(t, "1961-04-12", jane.First("birthDate"))
+
+	// nested entities are collapsed into their ID
+	occ := entities[7]
+	require.Equal(t, []string{"Q1002"}, occ.Get("holder"))
+}
+
+func TestRead__Array(t *testing.T) {
+	entities, err := Read(strings.NewReader(`
+  [{"id": "a", "schema": "Company", "properties": {"name": ["Acme"]}}]`))
+	require.NoError(t, err)
+	require.Len(t, entities, 1)
+	require.Equal(t, "Acme", entities[0].First("name"))
+}
+
+func TestRead__Empty(t *testing.T) {
+	entities, err := Read(strings.NewReader(""))
+	require.NoError(t, err)
+	require.Len(t, entities, 0)
+}
+
+func TestRead__Invalid(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"id": "a"}` + "\n" + `{"id": `))
+	require.ErrorContains(t, err, "line 2")
+}
@@ -0,0 +1,27 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ftm
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Writer encodes FtM entities as JSON Lines, one entity per line.
+type Writer struct {
+	enc *json.Encoder
+}
+
+// NewWriter returns a Writer which streams entities into w.
+func NewWriter(w io.Writer) *Writer {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &Writer{enc: enc}
+}
+
+// Write encodes a single entity followed by a newline.
+func (w *Writer) Write(e *Entity) error {
+	return w.enc.Encode(e)
+}
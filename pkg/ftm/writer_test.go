@@ -0,0 +1,51 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ftm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	acme := &Entity{ID: "acme", Schema: SchemaCompany, Caption: "Acme & Sons"}
+	acme.Add("name", "Acme & Sons")
+	acme.Add("alias", "Acme", " ", "Acme")
+	require.NoError(t, w.Write(acme))
+
+	jane := &Entity{ID: "jane", Schema: SchemaPerson}
+	jane.Add("name", "Jane Doe")
+	require.NoError(t, w.Write(jane))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "Acme & Sons")
+
+	// read back what was written
+	entities, err := Read(&buf)
+	require.NoError(t, err)
+	require.Len(t, entities, 2)
+	require.Equal(t, []string{"Acme"}, entities[0].AlternateNames())
+	require.Nil(t, entities[1].AlternateNames())
+}
+
+func TestFilter(t *testing.T) {
+	entities := []*Entity{
+		{ID: "1", Schema: SchemaPerson},
+		{ID: "2", Schema: SchemaOccupancy},
+		{ID: "3", Schema: SchemaVessel},
+		nil,
+	}
+	out := Filter(entities, SearchableSchemas...)
+	require.Len(t, out, 2)
+	require.Equal(t, "1", out[0].ID)
+	require.Equal(t, "3", out[1].ID)
+}
@@ -20,6 +20,14 @@ var (
 		"sdn_comments.csv", // Specially Designated National Comments
 	}
 
+	// ofacExpectations are the fewest records we expect in each file. OFAC lists
+	// thousands of entries so a shorter file is likely an error page or truncated.
+	ofacExpectations = map[string]download.Expectations{
+		"add.csv": {MinRecords: 1000},
+		"alt.csv": {MinRecords: 1000},
+		"sdn.csv": {MinRecords: 1000},
+	}
+
 	ofacURLTemplate = func() string {
 		if v := os.Getenv("OFAC_DOWNLOAD_TEMPLATE"); v != "" {
 			return v
@@ -30,6 +38,7 @@ var (
 
 func Download(logger log.Logger, initialDir string) ([]string, error) {
 	dl := download.New(logger, download.HTTPClient)
+	dl.Expectations = ofacExpectations
 
 	addrs := make(map[string]string)
 	for i := range ofacFilenames {
@@ -52,7 +52,10 @@ func TestDownloader__initialDir(t *testing.T) {
 	}
 
 	// create each file
+	mk(t, "add.csv", "file=add.csv")
+	mk(t, "alt.csv", "file=alt.csv")
 	mk(t, "sdn.csv", "file=sdn.csv")
+	mk(t, "sdn_comments.csv", "file=sdn_comments.csv")
 	mk(t, "dpl.txt", "file=dpl.txt")
 
 	files, err := Download(log.NewNopLogger(), dir)
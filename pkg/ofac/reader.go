@@ -62,6 +62,21 @@ type Results struct {
 
 	// SDNComments returns an array of OFAC Specially Designated National Comments
 	SDNComments []*SDNComments `json:"sdnComments"`
+
+	// Skipped counts the rows left out of the results by reason
+	Skipped map[string]int `json:"-"`
+}
+
+const (
+	SkipMalformedRow = "malformed row"
+	SkipColumnCount  = "unexpected column count"
+)
+
+func skipRow(skipped map[string]int, record []string, reason string) {
+	if len(record) <= 1 {
+		return // blank lines and the trailing EOF marker
+	}
+	skipped[reason]++
 }
 
 func csvAddressFile(path string) (*Results, error) {
@@ -73,6 +88,7 @@ func csvAddressFile(path string) (*Results, error) {
 	defer f.Close()
 
 	var out []*Address
+	skipped := make(map[string]int)
 
 	// Read File into a Variable
 	reader := csv.NewReader(f)
@@ -87,11 +103,13 @@ func csvAddressFile(path string) (*Results, error) {
 			if errors.Is(err, csv.ErrFieldCount) ||
 				errors.Is(err, csv.ErrBareQuote) ||
 				errors.Is(err, csv.ErrQuote) {
+				skipped[SkipMalformedRow]++
 				continue
 			}
 			return nil, err
 		}
 		if len(record) != 6 {
+			skipRow(skipped, record, SkipColumnCount)
 			continue
 		}
 
@@ -105,7 +123,7 @@ func csvAddressFile(path string) (*Results, error) {
 			AddressRemarks:              record[5],
 		})
 	}
-	return &Results{Addresses: out}, nil
+	return &Results{Addresses: out, Skipped: skipped}, nil
 }
 
 func csvAlternateIdentityFile(path string) (*Results, error) {
@@ -117,6 +135,7 @@ func csvAlternateIdentityFile(path string) (*Results, error) {
 	defer f.Close()
 
 	var out []*AlternateIdentity
+	skipped := make(map[string]int)
 
 	// Read File into a Variable
 	reader := csv.NewReader(f)
@@ -131,11 +150,13 @@ func csvAlternateIdentityFile(path string) (*Results, error) {
 			if errors.Is(err, csv.ErrFieldCount) ||
 				errors.Is(err, csv.ErrBareQuote) ||
 				errors.Is(err, csv.ErrQuote) {
+				skipped[SkipMalformedRow]++
 				continue
 			}
 			return nil, err
 		}
 		if len(record) != 5 {
+			skipRow(skipped, record, SkipColumnCount)
 			continue
 		}
 		record = replaceNull(record)
@@ -147,7 +168,7 @@ func csvAlternateIdentityFile(path string) (*Results, error) {
 			AlternateRemarks: record[4],
 		})
 	}
-	return &Results{AlternateIdentities: out}, nil
+	return &Results{AlternateIdentities: out, Skipped: skipped}, nil
 }
 
 func csvSDNFile(path string) (*Results, error) {
@@ -159,6 +180,7 @@ func csvSDNFile(path string) (*Results, error) {
 	defer f.Close()
 
 	var out []*SDN
+	skipped := make(map[string]int)
 
 	// Read File into a Variable
 	reader := csv.NewReader(f)
@@ -173,11 +195,13 @@ func csvSDNFile(path string) (*Results, error) {
 			if errors.Is(err, csv.ErrFieldCount) ||
 				errors.Is(err, csv.ErrBareQuote) ||
 				errors.Is(err, csv.ErrQuote) {
+				skipped[SkipMalformedRow]++
 				continue
 			}
 			return nil, err
 		}
 		if len(record) != 12 {
+			skipRow(skipped, record, SkipColumnCount)
 			continue
 		}
 		record = replaceNull(record)
@@ -196,7 +220,7 @@ func csvSDNFile(path string) (*Results, error) {
 			Remarks:                record[11],
 		})
 	}
-	return &Results{SDNs: out}, nil
+	return &Results{SDNs: out, Skipped: skipped}, nil
 }
 
 func csvSDNCommentsFile(path string) (*Results, error) {
@@ -213,6 +237,7 @@ func csvSDNCommentsFile(path string) (*Results, error) {
 
 	// Loop through lines & turn into object
 	var out []*SDNComments
+	skipped := make(map[string]int)
 	for {
 		line, err := r.Read()
 		if err != nil {
@@ -224,11 +249,13 @@ func csvSDNCommentsFile(path string) (*Results, error) {
 			if errors.Is(err, csv.ErrFieldCount) ||
 				errors.Is(err, csv.ErrBareQuote) ||
 				errors.Is(err, csv.ErrQuote) {
+				skipped[SkipMalformedRow]++
 				continue
 			}
 			return nil, err
 		}
 		if len(line) != 2 {
+			skipRow(skipped, line, SkipColumnCount)
 			continue
 		}
 		line = replaceNull(line)
@@ -237,7 +264,7 @@ func csvSDNCommentsFile(path string) (*Results, error) {
 			RemarksExtended: line[1],
 		})
 	}
-	return &Results{SDNComments: out}, nil
+	return &Results{SDNComments: out, Skipped: skipped}, nil
 }
 
 // replaceNull replaces a CSV field that contain -0- with "".  Null values for all four formats consist of "-0-"
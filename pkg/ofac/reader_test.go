@@ -113,3 +113,21 @@ func TestSDNComments(t *testing.T) {
 		}
 	}
 }
+
+func TestOFAC__readSkipped(t *testing.T) {
+	res, err := Read(filepath.Join("..", "..", "test", "testdata", "invalidFiles", "sdn.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Skipped) == 0 {
+		t.Errorf("expected skipped rows")
+	}
+
+	res, err = Read(filepath.Join("..", "..", "test", "testdata", "sdn.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Skipped) != 0 {
+		t.Errorf("unexpected skipped rows: %v", res.Skipped)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package pep
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DefaultFilename is the name of the PEP dataset looked for in the initial data directory.
+const DefaultFilename = "pep.json"
+
+// LocateFile returns the filepath of a PEP dataset. PEP datasets are not downloaded, instead
+// PEP_DATA_FILE is used or DefaultFilename from within initialDir.
+//
+// An empty string is returned when no dataset is configured.
+func LocateFile(initialDir string) string {
+	if path := os.Getenv("PEP_DATA_FILE"); path != "" {
+		return path
+	}
+	return FileIn(initialDir)
+}
+
+// FileIn returns the filepath of DefaultFilename within dir, ignoring PEP_DATA_FILE.
+// An empty string is returned when dir has no dataset.
+func FileIn(dir string) string {
+	if dir != "" {
+		path := filepath.Join(dir, DefaultFilename)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package pep
+
+import (
+	"strings"
+	"time"
+)
+
+// PEP is a Politically Exposed Person along with the public positions they have held.
+type PEP struct {
+	// EntityID is the unique identifier of the person in the source dataset
+	EntityID string `json:"entityID"`
+	// Name is the primary name of the person
+	Name string `json:"name"`
+	// AlternateNames is a list of aliases associated with the person
+	AlternateNames []string `json:"alternateNames,omitempty"`
+	// BirthDates is a list of known dates of birth
+	BirthDates []string `json:"birthDates,omitempty"`
+	// Countries is a list of countries the person is associated with
+	Countries []string `json:"countries,omitempty"`
+	// Nationalities is a list of the person's nationalities or citizenships
+	Nationalities []string `json:"nationalities,omitempty"`
+	// Positions is every public position held by the person
+	Positions []Position `json:"positions,omitempty"`
+	// Datasets lists the source datasets the person was published in
+	Datasets []string `json:"datasets,omitempty"`
+	// SourceURL is a link to more information about the person
+	SourceURL string `json:"sourceURL,omitempty"`
+}
+
+// Position is a public office or role held by a PEP.
+type Position struct {
+	// Name of the position (e.g. Member of Parliament)
+	Name string `json:"name"`
+	// Country the position is held in
+	Country string `json:"country,omitempty"`
+	// StartDate is when the person took the position
+	StartDate string `json:"startDate,omitempty"`
+	// EndDate is when the person left the position. Empty if they still hold it.
+	EndDate string `json:"endDate,omitempty"`
+	// Status is the occupancy status reported by the dataset (e.g. current, ended, unknown)
+	Status string `json:"status,omitempty"`
+}
+
+// Status is whether a PEP currently holds a public position or not
+type Status string
+
+const (
+	StatusActive Status = "active"
+	StatusFormer Status = "former"
+)
+
+// ParseStatus returns the Status for a user provided value, or an empty Status if unknown.
+func ParseStatus(value string) Status {
+	switch Status(strings.ToLower(strings.TrimSpace(value))) {
+	case StatusActive, "current":
+		return StatusActive
+	case StatusFormer, "ended", "inactive":
+		return StatusFormer
+	}
+	return ""
+}
+
+// Status returns StatusActive if the PEP holds any position as of now. People without any
+// known positions are considered active as datasets only publish them while they're exposed.
+func (p PEP) Status(now time.Time) Status {
+	if len(p.Positions) == 0 {
+		return StatusActive
+	}
+	for i := range p.Positions {
+		if p.Positions[i].Active(now) {
+			return StatusActive
+		}
+	}
+	return StatusFormer
+}
+
+// ListedBirthDates returns the person's known dates of birth
+func (p PEP) ListedBirthDates() []string {
+	return p.BirthDates
+}
+
+// ListedIDNumbers returns nil, as PEP datasets don't list IDs
+func (p PEP) ListedIDNumbers() []string {
+	return nil
+}
+
+// ListedCountries returns the person's nationalities and the countries they're associated with
+func (p PEP) ListedCountries() []string {
+	return append(append([]string{}, p.Nationalities...), p.Countries...)
+}
+
+// Active returns true if the position is held as of now.
+func (p Position) Active(now time.Time) bool {
+	switch strings.ToLower(p.Status) {
+	case "current":
+		return true
+	case "ended":
+		return false
+	}
+	if p.EndDate == "" {
+		return true
+	}
+	end, ok := parseDate(p.EndDate)
+	if !ok {
+		return true // keep unparsable dates as active to avoid missing a match
+	}
+	return end.After(now)
+}
+
+// parseDate reads the partial dates FtM uses (YYYY, YYYY-MM and YYYY-MM-DD) and returns the
+// last moment the date could represent.
+func parseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) > 10 {
+		value = value[:10]
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.AddDate(0, 0, 1), true
+	}
+	if t, err := time.Parse("2006-01", value); err == nil {
+		return t.AddDate(0, 1, 0), true
+	}
+	if t, err := time.Parse("2006", value); err == nil {
+		return t.AddDate(1, 0, 0), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package pep
+
+import (
+	"github.com/moov-io/watchman/pkg/ftm"
+)
+
+// ReadFile parses a FollowTheMoney (FtM) dataset (such as the OpenSanctions PEP dataset)
+// stored at path and returns each politically exposed person.
+func ReadFile(path string) ([]*PEP, error) {
+	entities, err := ftm.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return FromEntities(entities), nil
+}
+
+// FromEntities maps FtM entities into PEP records.
+//
+// A Person is considered a PEP when tagged with the role.pep topic or when they hold a Position
+// through an Occupancy. Positions come from Occupancy entities when present, otherwise the
+// free-form "position" property of the Person is used.
+func FromEntities(entities []*ftm.Entity) []*PEP {
+	positions := make(map[string]*ftm.Entity)
+	occupancies := make(map[string][]*ftm.Entity) // keyed by holder ID
+	for _, e := range entities {
+		switch {
+		case e.IsA(ftm.SchemaPosition):
+			positions[e.ID] = e
+		case e.IsA(ftm.SchemaOccupancy):
+			for _, holder := range e.Get("holder") {
+				occupancies[holder] = append(occupancies[holder], e)
+			}
+		}
+	}
+
+	var out []*PEP
+	for _, e := range entities {
+		if !e.IsA(ftm.SchemaPerson) {
+			continue
+		}
+		occs := occupancies[e.ID]
+		if !e.HasTopic(ftm.TopicPEP) && len(occs) == 0 {
+			continue
+		}
+		out = append(out, fromPerson(e, occs, positions))
+	}
+	return out
+}
+
+func fromPerson(e *ftm.Entity, occupancies []*ftm.Entity, positions map[string]*ftm.Entity) *PEP {
+	names := e.Names()
+
+	p := &PEP{
+		EntityID:   e.ID,
+		BirthDates: e.Get("birthDate"),
+		Countries:  e.Get("country"),
+		Datasets:   e.Datasets,
+		SourceURL:  e.First("sourceUrl"),
+	}
+	for _, prop := range []string{"nationality", "citizenship"} {
+		for _, v := range e.Get(prop) {
+			if !contains(p.Nationalities, v) {
+				p.Nationalities = append(p.Nationalities, v)
+			}
+		}
+	}
+	if len(names) > 0 {
+		p.Name = names[0]
+		p.AlternateNames = names[1:]
+	}
+
+	for _, occ := range occupancies {
+		pos := Position{
+			StartDate: occ.First("startDate"),
+			EndDate:   occ.First("endDate"),
+			Status:    occ.First("status"),
+		}
+		if post := positions[occ.First("post")]; post != nil {
+			pos.Name = post.First("name")
+			pos.Country = post.First("country")
+		}
+		if pos.Name == "" {
+			pos.Name = occ.Caption
+		}
+		p.Positions = append(p.Positions, pos)
+	}
+	if len(p.Positions) == 0 {
+		for _, name := range e.Get("position") {
+			p.Positions = append(p.Positions, Position{Name: name})
+		}
+	}
+	return p
+}
+
+func contains(values []string, needle string) bool {
+	for i := range values {
+		if values[i] == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package pep
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFile(t *testing.T) {
+	peps, err := ReadFile(filepath.Join("..", "..", "test", "testdata", "pep.json"))
+	require.NoError(t, err)
+	require.Len(t, peps, 3)
+
+	now := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	jane := peps[0]
+	require.Equal(t, "Q1001", jane.EntityID)
+	require.Equal(t, "Jane Example", jane.Name)
+	require.Equal(t, []string{"Jane Q. Example"}, jane.AlternateNames)
+	require.Equal(t, []string{"gb"}, jane.Nationalities)
+	require.Len(t, jane.Positions, 1)
+	require.Equal(t, "Member of Parliament", jane.Positions[0].Name)
+	require.Equal(t, "gb", jane.Positions[0].Country)
+	require.Equal(t, "2015-05-07", jane.Positions[0].StartDate)
+	require.Equal(t, StatusActive, jane.Status(now))
+
+	pedro := peps[1]
+	require.Equal(t, "Minister of Finance", pedro.Positions[0].Name)
+	require.Equal(t, "2004-01", pedro.Positions[0].EndDate)
+	require.Equal(t, StatusFormer, pedro.Status(now))
+
+	ivan := peps[2]
+	require.Equal(t, []Position{{Name: "Mayor of Sampleton"}}, ivan.Positions)
+	require.Equal(t, StatusActive, ivan.Status(now))
+}
+
+func TestPosition__Active(t *testing.T) {
+	now := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	require.True(t, Position{}.Active(now))
+	require.True(t, Position{EndDate: "2023"}.Active(now))
+	require.True(t, Position{EndDate: "2023-06"}.Active(now))
+	require.False(t, Position{EndDate: "2023-05-31"}.Active(now))
+	require.True(t, Position{EndDate: "2023-06-01"}.Active(now))
+	require.False(t, Position{EndDate: "2022"}.Active(now))
+	require.False(t, Position{Status: "ended"}.Active(now))
+	require.True(t, Position{EndDate: "2001", Status: "current"}.Active(now))
+}
+
+func TestParseStatus(t *testing.T) {
+	require.Equal(t, StatusActive, ParseStatus("Active"))
+	require.Equal(t, StatusActive, ParseStatus("current"))
+	require.Equal(t, StatusFormer, ParseStatus(" former "))
+	require.Equal(t, Status(""), ParseStatus("other"))
+}
+
+func TestLocateFile(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	require.Equal(t, filepath.Join(dir, DefaultFilename), LocateFile(dir))
+	require.Equal(t, "", LocateFile(t.TempDir()))
+
+	t.Setenv("PEP_DATA_FILE", "/tmp/peps.json")
+	require.Equal(t, "/tmp/peps.json", LocateFile(dir))
+	require.Equal(t, filepath.Join(dir, DefaultFilename), FileIn(dir))
+}